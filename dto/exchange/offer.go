@@ -0,0 +1,107 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
+
+type MakeOffer struct {
+	BuyerWalletId string `json:"buyerWalletId"`
+	NftTokenId    string `json:"nftTokenId"`
+	PriceTokenId  string `json:"priceTokenId"`
+	Price         string `json:"price"`
+	Expiry        int64  `json:"expiry"`
+	dto.Idempotency
+}
+
+func (m MakeOffer) IsValid() error {
+	if m.BuyerWalletId == "" {
+		return errors.New("buyer wallet id is invalid")
+	}
+
+	if m.NftTokenId == "" {
+		return errors.New("NFT token id is invalid")
+	}
+
+	if m.PriceTokenId == "" {
+		return errors.New("price token id is invalid")
+	}
+
+	if m.Price == "" || helper.CompareStringBalance(m.Price, "0") <= 0 {
+		return errors.New("price is invalid")
+	}
+
+	if m.Expiry < 0 {
+		return errors.New("expiry is invalid")
+	}
+
+	return nil
+}
+
+type CancelOffer struct {
+	OfferId       string `json:"offerId"`
+	BuyerWalletId string `json:"buyerWalletId"`
+	dto.Idempotency
+}
+
+func (c CancelOffer) IsValid() error {
+	if c.OfferId == "" {
+		return errors.New("offer id is invalid")
+	}
+
+	if c.BuyerWalletId == "" {
+		return errors.New("buyer wallet id is invalid")
+	}
+
+	return nil
+}
+
+type AcceptOffer struct {
+	OfferId        string `json:"offerId"`
+	SellerWalletId string `json:"sellerWalletId"`
+	dto.Idempotency
+}
+
+func (a AcceptOffer) IsValid() error {
+	if a.OfferId == "" {
+		return errors.New("offer id is invalid")
+	}
+
+	if a.SellerWalletId == "" {
+		return errors.New("seller wallet id is invalid")
+	}
+
+	return nil
+}
+
+type QueryOffer struct {
+	NftTokenId string `json:"nftTokenId"`
+}
+
+func (q QueryOffer) IsValid() error {
+	if q.NftTokenId == "" {
+		return errors.New("NFT token id is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
+
+type PlaceLimitOrder struct {
+	WalletId     string     `json:"walletId"`
+	BaseTokenId  string     `json:"baseTokenId"`
+	QuoteTokenId string     `json:"quoteTokenId"`
+	Side         order.Side `json:"side"`
+	Amount       string     `json:"amount"`
+	Price        string     `json:"price"`
+	dto.Idempotency
+}
+
+func (p PlaceLimitOrder) IsValid() error {
+	if p.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	if p.BaseTokenId == "" || p.QuoteTokenId == "" || p.BaseTokenId == p.QuoteTokenId {
+		return errors.New("token pair is invalid")
+	}
+
+	if !p.Side.IsValidate() {
+		return errors.New("side of order is invalid")
+	}
+
+	if p.Amount == "" || helper.CompareStringBalance(p.Amount, "0") <= 0 {
+		return errors.New("amount is invalid")
+	}
+
+	if p.Price == "" || helper.CompareStringBalance(p.Price, "0") <= 0 {
+		return errors.New("price is invalid")
+	}
+
+	return nil
+}
+
+type CancelOrder struct {
+	OrderId  string `json:"orderId"`
+	WalletId string `json:"walletId"`
+	dto.Idempotency
+}
+
+func (c CancelOrder) IsValid() error {
+	if c.OrderId == "" {
+		return errors.New("order id is invalid")
+	}
+
+	if c.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	return nil
+}
+
+// MatchOrder match a buy order with a sell order. Amount is optional, the orders are filled as much as possible when empty.
+type MatchOrder struct {
+	ReqId       string `json:"reqId"`
+	BuyOrderId  string `json:"buyOrderId"`
+	SellOrderId string `json:"sellOrderId"`
+	Amount      string `json:"amount"`
+}
+
+type MatchResult struct {
+	Status      transaction.Status `json:"status"`
+	TradeId     string             `json:"tradeId"`
+	Amount      string             `json:"amount"`
+	Price       string             `json:"price"`
+	QuoteAmount string             `json:"quoteAmount"`
+	ReqId       string             `json:"reqId"`
+}
+
+type MatchBatchOrders struct {
+	Requests []MatchOrder `json:"requests"`
+}
+
+func (m MatchBatchOrders) IsValid() error {
+	if len(m.Requests) <= 0 {
+		return errors.New("Input invalidate")
+	}
+	return nil
+}
+
+func (m MatchOrder) CloneToResult() MatchResult {
+	return MatchResult{
+		ReqId: m.ReqId,
+	}
+}
+
+type QueryDepth struct {
+	BaseTokenId  string `json:"baseTokenId"`
+	QuoteTokenId string `json:"quoteTokenId"`
+}
+
+func (q QueryDepth) IsValid() error {
+	if q.BaseTokenId == "" || q.QuoteTokenId == "" {
+		return errors.New("token pair is invalid")
+	}
+
+	return nil
+}
+
+type QueryOrderHistory struct {
+	WalletId string `json:"walletId"`
+}
+
+func (q QueryOrderHistory) IsValid() error {
+	if q.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
+
+type CreatePool struct {
+	TokenA string `json:"tokenA"`
+	TokenB string `json:"tokenB"`
+	FeeBps int64  `json:"feeBps"`
+	dto.Idempotency
+}
+
+func (c CreatePool) IsValid() error {
+	if c.TokenA == "" || c.TokenB == "" || c.TokenA == c.TokenB {
+		return errors.New("token pair is invalid")
+	}
+
+	if c.FeeBps < 0 || c.FeeBps >= glossary.BasisPointBase {
+		return errors.New("fee rate is invalid")
+	}
+
+	return nil
+}
+
+type AddLiquidity struct {
+	PoolId   string `json:"poolId"`
+	WalletId string `json:"walletId"`
+	AmountA  string `json:"amountA"`
+	AmountB  string `json:"amountB"`
+	dto.Idempotency
+}
+
+func (a AddLiquidity) IsValid() error {
+	if a.PoolId == "" {
+		return errors.New("pool id is invalid")
+	}
+
+	if a.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	if a.AmountA == "" || helper.CompareStringBalance(a.AmountA, "0") <= 0 {
+		return errors.New("amount of token A is invalid")
+	}
+
+	if a.AmountB == "" || helper.CompareStringBalance(a.AmountB, "0") <= 0 {
+		return errors.New("amount of token B is invalid")
+	}
+
+	return nil
+}
+
+type RemoveLiquidity struct {
+	PoolId    string `json:"poolId"`
+	WalletId  string `json:"walletId"`
+	Liquidity string `json:"liquidity"`
+	dto.Idempotency
+}
+
+func (r RemoveLiquidity) IsValid() error {
+	if r.PoolId == "" {
+		return errors.New("pool id is invalid")
+	}
+
+	if r.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	if r.Liquidity == "" || helper.CompareStringBalance(r.Liquidity, "0") <= 0 {
+		return errors.New("liquidity is invalid")
+	}
+
+	return nil
+}
+
+type SwapExactIn struct {
+	PoolId       string `json:"poolId"`
+	WalletId     string `json:"walletId"`
+	FromTokenId  string `json:"fromTokenId"`
+	AmountIn     string `json:"amountIn"`
+	MinAmountOut string `json:"minAmountOut"`
+	dto.Idempotency
+}
+
+func (s SwapExactIn) IsValid() error {
+	if s.PoolId == "" {
+		return errors.New("pool id is invalid")
+	}
+
+	if s.WalletId == "" {
+		return errors.New("wallet id is invalid")
+	}
+
+	if s.FromTokenId == "" {
+		return errors.New("from token id is invalid")
+	}
+
+	if s.AmountIn == "" || helper.CompareStringBalance(s.AmountIn, "0") <= 0 {
+		return errors.New("amount in is invalid")
+	}
+
+	if s.MinAmountOut == "" || helper.CompareStringBalance(s.MinAmountOut, "0") < 0 {
+		return errors.New("minimum amount out is invalid")
+	}
+
+	return nil
+}
+
+// QueryPool query the state of a pool, the quote of AmountIn of FromTokenId is returned when both are given
+type QueryPool struct {
+	PoolId      string `json:"poolId"`
+	FromTokenId string `json:"fromTokenId"`
+	AmountIn    string `json:"amountIn"`
+}
+
+func (q QueryPool) IsValid() error {
+	if q.PoolId == "" {
+		return errors.New("pool id is invalid")
+	}
+
+	if q.AmountIn != "" && helper.CompareStringBalance(q.AmountIn, "0") <= 0 {
+		return errors.New("amount in is invalid")
+	}
+
+	return nil
+}
@@ -37,6 +37,8 @@ type ResultHandle struct {
 	NumberATFilled string             `json:"numberATFilled"`
 	NumberST       string             `json:"numberST"`
 	ReqId          string             `json:"reqId"`
+	// Reason is why an eligibility or purchase limit rejected the request
+	Reason string `json:"reason,omitempty"`
 }
 
 type BuyBatchAsset struct {
@@ -19,7 +19,10 @@
 
 package iao
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/pkg/errors"
+)
 
 type CreateAsset struct {
 	Code        string `json:"code"`
@@ -30,6 +33,7 @@ type CreateAsset struct {
 	MaxSupply   string `json:"maxSupply"`
 	TotalValue  string `json:"totalValue"`
 	DocumentUrl string `json:"documentUrl"`
+	dto.Idempotency
 }
 
 func (c CreateAsset) IsValid() error {
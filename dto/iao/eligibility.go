@@ -0,0 +1,110 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package iao
+
+import (
+	"fmt"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/pkg/errors"
+)
+
+// Eligibility allows a wallet of a KYC tier to buy in an iao until Expiry (unix seconds, zero for none)
+type Eligibility struct {
+	WalletId     string `json:"walletId"`
+	Tier         string `json:"tier"`
+	Jurisdiction string `json:"jurisdiction,omitempty" metadata:",optional"`
+	Expiry       int64  `json:"expiry,omitempty" metadata:",optional"`
+}
+
+// AddEligibility adds or replaces wallets in the eligibility list of an iao
+type AddEligibility struct {
+	IaoId   string        `json:"iaoId"`
+	Entries []Eligibility `json:"entries"`
+	dto.Idempotency
+}
+
+func (a AddEligibility) IsValid() error {
+	if a.IaoId == "" {
+		return errors.New("IaoId is empty")
+	}
+	if len(a.Entries) == 0 {
+		return errors.New("entries are empty")
+	}
+	if len(a.Entries) > glossary.BatchSize {
+		return fmt.Errorf("batch is larger than %d entries", glossary.BatchSize)
+	}
+
+	wallets := make(map[string]bool, len(a.Entries))
+	for _, entry := range a.Entries {
+		if entry.WalletId == "" || entry.Tier == "" {
+			return errors.New("wallet id/tier of entry is empty")
+		}
+		if entry.Expiry < 0 {
+			return errors.New("expiry of entry is invalid")
+		}
+		if wallets[entry.WalletId] {
+			return errors.New("wallet id of entry is duplicated")
+		}
+		wallets[entry.WalletId] = true
+	}
+	return nil
+}
+
+// RemoveEligibility removes wallets from the eligibility list of an iao
+type RemoveEligibility struct {
+	IaoId     string   `json:"iaoId"`
+	WalletIds []string `json:"walletIds"`
+	dto.Idempotency
+}
+
+func (r RemoveEligibility) IsValid() error {
+	if r.IaoId == "" {
+		return errors.New("IaoId is empty")
+	}
+	if len(r.WalletIds) == 0 {
+		return errors.New("wallet ids are empty")
+	}
+	if len(r.WalletIds) > glossary.BatchSize {
+		return fmt.Errorf("batch is larger than %d wallets", glossary.BatchSize)
+	}
+	for _, walletId := range r.WalletIds {
+		if walletId == "" {
+			return errors.New("wallet id is empty")
+		}
+	}
+	return nil
+}
+
+// QueryEligibility return the eligibility of WalletId, or a page of the eligibility list of the iao when it is empty.
+// The list is in order of wallet id and starts after LastWalletId, the wallet of the last entry of the previous page.
+// A page shorter than the pagination size is the last one
+type QueryEligibility struct {
+	IaoId        string `json:"iaoId"`
+	WalletId     string `json:"walletId,omitempty" metadata:",optional"`
+	LastWalletId string `json:"lastWalletId,omitempty" metadata:",optional"`
+}
+
+func (q QueryEligibility) IsValid() error {
+	if q.IaoId == "" {
+		return errors.New("IaoId is empty")
+	}
+	return nil
+}
@@ -19,10 +19,14 @@
 
 package iao
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/pkg/errors"
+)
 
 type FinishIao struct {
 	InvestorBookId []string `json:"investorBookId"`
+	dto.Idempotency
 }
 
 func (f FinishIao) IsValid() error {
@@ -19,7 +19,17 @@
 
 package iao
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/entity"
+	statusIao "github.com/Akachain/gringotts/glossary/iao"
+	"github.com/Akachain/gringotts/glossary/vesting"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/pkg/unit"
+	vestingPlan "github.com/Akachain/gringotts/pkg/vesting"
+	"github.com/pkg/errors"
+	"strconv"
+)
 
 type AssetIao struct {
 	AssetId          string `json:"assetId"`
@@ -27,6 +37,80 @@ type AssetIao struct {
 	StartDate        string `json:"startDate"`
 	EndDate          string `json:"endDate"`
 	Rate             int64  `json:"rate"`
+	// StableTokenId is the token paid at Rate, it is required when Prices is empty
+	StableTokenId string `json:"stableTokenId,omitempty" metadata:",optional"`
+	// optional vesting plan of the distributed asset token, no vesting when VestingType is empty
+	VestingType     vesting.Type `json:"vestingType,omitempty" metadata:",optional"`
+	VestingCliff    int64        `json:"vestingCliff,omitempty" metadata:",optional"`
+	VestingDuration int64        `json:"vestingDuration,omitempty" metadata:",optional"`
+	VestingStep     int64        `json:"vestingStep,omitempty" metadata:",optional"`
+	// optional allocation of the sale, FirstCome when it is empty. Tiered fills every commitment up to AllocationTier first
+	Allocation     statusIao.Allocation `json:"allocation,omitempty" metadata:",optional"`
+	AllocationTier string               `json:"allocationTier,omitempty" metadata:",optional"`
+	// optional limits of the sale in asset token, the min/max purchase apply to the total of a wallet
+	SoftCap     string `json:"softCap,omitempty" metadata:",optional"`
+	HardCap     string `json:"hardCap,omitempty" metadata:",optional"`
+	MinPurchase string `json:"minPurchase,omitempty" metadata:",optional"`
+	MaxPurchase string `json:"maxPurchase,omitempty" metadata:",optional"`
+	// optional KYC tiers, only wallets of the eligibility list of the iao can buy when it is not empty
+	KycTiers []KycTier `json:"kycTiers,omitempty" metadata:",optional"`
+	// optional accepted payment tokens besides StableTokenId
+	Prices []Price `json:"prices,omitempty" metadata:",optional"`
+	dto.Idempotency
+}
+
+// KycTier is a KYC tier of investors, MaxInvestment is in asset token and not applied when it is empty
+type KycTier struct {
+	Tier          string `json:"tier"`
+	MaxInvestment string `json:"maxInvestment,omitempty" metadata:",optional"`
+}
+
+// Price is the price of one asset token in TokenId, a rational (3/7) or decimal (0.5) string.
+// The cost of a purchase is rounded up to the base unit of TokenId.
+type Price struct {
+	TokenId string `json:"tokenId"`
+	Price   string `json:"price"`
+}
+
+// IaoPrices return the prices of the accepted payment tokens of the iao, the stable token is priced at Rate
+func (a AssetIao) IaoPrices() []entity.IaoPrice {
+	prices := make([]entity.IaoPrice, 0, len(a.Prices)+1)
+	if a.StableTokenId != "" {
+		prices = append(prices, entity.IaoPrice{TokenId: a.StableTokenId, Price: strconv.FormatInt(a.Rate, 10)})
+	}
+	for _, price := range a.Prices {
+		prices = append(prices, entity.IaoPrice{TokenId: price.TokenId, Price: price.Price})
+	}
+	return prices
+}
+
+// Tiers return the KYC tiers of the iao
+func (a AssetIao) Tiers() []entity.KycTier {
+	tiers := make([]entity.KycTier, 0, len(a.KycTiers))
+	for _, tier := range a.KycTiers {
+		tiers = append(tiers, entity.KycTier{Tier: tier.Tier, MaxInvestment: tier.MaxInvestment})
+	}
+	return tiers
+}
+
+// Limit return the limits of the sale
+func (a AssetIao) Limit() entity.IaoLimit {
+	return entity.IaoLimit{
+		SoftCap:     a.SoftCap,
+		HardCap:     a.HardCap,
+		MinPurchase: a.MinPurchase,
+		MaxPurchase: a.MaxPurchase,
+	}
+}
+
+// VestingPlan return the vesting plan of the distributed asset token
+func (a AssetIao) VestingPlan() entity.VestingPlan {
+	return entity.VestingPlan{
+		VestingType: a.VestingType,
+		Cliff:       a.VestingCliff,
+		Duration:    a.VestingDuration,
+		Step:        a.VestingStep,
+	}
 }
 
 func (a AssetIao) IsValid() error {
@@ -37,9 +121,91 @@ func (a AssetIao) IsValid() error {
 		return errors.New("StartDate/EndDate id is empty")
 	}
 
-	if a.Rate <= 0 {
+	startTime, err := helper.ParseTimestamp(a.StartDate)
+	if err != nil {
+		return errors.WithMessage(err, "StartDate is invalid")
+	}
+	endTime, err := helper.ParseTimestamp(a.EndDate)
+	if err != nil {
+		return errors.WithMessage(err, "EndDate is invalid")
+	}
+	if startTime >= endTime {
+		return errors.New("StartDate must be before EndDate")
+	}
+
+	if len(a.Prices) == 0 && a.StableTokenId == "" {
+		return errors.New("StableTokenId is required when Prices is empty")
+	}
+	if a.StableTokenId != "" && a.Rate <= 0 {
 		return errors.New("Rate of asset token must be greater than zero")
 	}
+	if a.Rate < 0 {
+		return errors.New("Rate of asset token must not be negative")
+	}
+
+	tokens := make(map[string]bool, len(a.Prices)+1)
+	if a.StableTokenId != "" {
+		tokens[a.StableTokenId] = true
+	}
+	for _, price := range a.Prices {
+		if price.TokenId == "" || tokens[price.TokenId] {
+			return errors.New("TokenId of price is empty or duplicated")
+		}
+		if _, err := unit.NewPriceFromString(price.Price); err != nil {
+			return errors.WithMessagef(err, "Price of token %s is invalid", price.TokenId)
+		}
+		tokens[price.TokenId] = true
+	}
+
+	if a.Allocation != "" && !a.Allocation.IsValidate() {
+		return errors.New("Allocation is invalidate")
+	}
+	if a.Allocation == statusIao.Tiered && helper.CompareStringBalance(a.AllocationTier, "0") <= 0 {
+		return errors.New("AllocationTier must be greater than zero")
+	}
+
+	if err := a.validateLimit(); err != nil {
+		return err
+	}
+
+	tiers := make(map[string]bool, len(a.KycTiers))
+	for _, tier := range a.KycTiers {
+		if tier.Tier == "" || tiers[tier.Tier] {
+			return errors.New("KYC tier is empty or duplicated")
+		}
+		if tier.MaxInvestment != "" && helper.CompareStringBalance(tier.MaxInvestment, "0") <= 0 {
+			return errors.New("MaxInvestment of KYC tier must be greater than zero")
+		}
+		tiers[tier.Tier] = true
+	}
 
+	if a.VestingType != "" {
+		if err := vestingPlan.Validate(a.VestingPlan()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a AssetIao) validateLimit() error {
+	for _, limit := range []string{a.SoftCap, a.HardCap, a.MinPurchase, a.MaxPurchase} {
+		if limit != "" && helper.CompareStringBalance(limit, "0") <= 0 {
+			return errors.New("SoftCap/HardCap/MinPurchase/MaxPurchase must be greater than zero")
+		}
+	}
+
+	if a.HardCap != "" && helper.CompareStringBalance(a.HardCap, a.AssetTokenAmount) > 0 {
+		return errors.New("HardCap must not be greater than AssetTokenAmount")
+	}
+	if a.SoftCap != "" && helper.CompareStringBalance(a.SoftCap, a.AssetTokenAmount) > 0 {
+		return errors.New("SoftCap must not be greater than AssetTokenAmount")
+	}
+	if a.SoftCap != "" && a.HardCap != "" && helper.CompareStringBalance(a.SoftCap, a.HardCap) > 0 {
+		return errors.New("SoftCap must not be greater than HardCap")
+	}
+	if a.MinPurchase != "" && a.MaxPurchase != "" && helper.CompareStringBalance(a.MinPurchase, a.MaxPurchase) > 0 {
+		return errors.New("MinPurchase must not be greater than MaxPurchase")
+	}
 	return nil
 }
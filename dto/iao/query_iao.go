@@ -0,0 +1,57 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package iao
+
+import (
+	statusIao "github.com/Akachain/gringotts/glossary/iao"
+	"github.com/pkg/errors"
+)
+
+type QueryIao struct {
+	IaoId string `json:"iaoId"`
+}
+
+func (q QueryIao) IsValid() error {
+	if q.IaoId == "" {
+		return errors.New("IaoId is empty")
+	}
+
+	return nil
+}
+
+// IaoStatus is the progress of the sale of an iao toward its caps, amounts are in asset token
+type IaoStatus struct {
+	IaoId            string               `json:"iaoId"`
+	Status           statusIao.Status     `json:"status"`
+	Allocation       statusIao.Allocation `json:"allocation"`
+	Rate             int64                `json:"rate"`
+	Prices           []Price              `json:"prices"`
+	StartTime        int64                `json:"startTime"`
+	EndTime          int64                `json:"endTime"`
+	AssetTokenAmount string               `json:"assetTokenAmount"`
+	Sold             string               `json:"sold"`
+	Remaining        string               `json:"remaining"`
+	SoftCap          string               `json:"softCap"`
+	HardCap          string               `json:"hardCap"`
+	MinPurchase      string               `json:"minPurchase"`
+	MaxPurchase      string               `json:"maxPurchase"`
+	SoftCapReached   bool                 `json:"softCapReached"`
+	HardCapReached   bool                 `json:"hardCapReached"`
+}
@@ -20,6 +20,7 @@
 package iao
 
 import (
+	"github.com/Akachain/gringotts/dto"
 	statusIao "github.com/Akachain/gringotts/glossary/iao"
 	"github.com/pkg/errors"
 )
@@ -27,6 +28,7 @@ import (
 type UpdateIao struct {
 	IaoId  string           `json:"iaoId"`
 	Status statusIao.Status `json:"status"`
+	dto.Idempotency
 }
 
 func (u UpdateIao) IsValid() error {
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package nft
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
+
+type FractionalizeNFT struct {
+	NftTokenId  string `json:"nftTokenId"`
+	TokenName   string `json:"tokenName"`
+	TickerToken string `json:"tickerToken"`
+	Shares      string `json:"shares"`
+	dto.Idempotency
+}
+
+func (f FractionalizeNFT) IsValid() error {
+	if f.NftTokenId == "" {
+		return errors.New("NFT token id is invalid")
+	}
+
+	if f.TokenName == "" {
+		return errors.New("Token name is invalid")
+	}
+
+	if f.TickerToken == "" {
+		return errors.New("Ticker token is invalid")
+	}
+
+	if f.Shares == "" || helper.CompareStringBalance(f.Shares, "0") <= 0 {
+		return errors.New("Shares is invalid")
+	}
+
+	return nil
+}
+
+type RedeemNFT struct {
+	NftTokenId       string `json:"nftTokenId"`
+	RedeemerWalletId string `json:"redeemerWalletId"`
+	dto.Idempotency
+}
+
+func (r RedeemNFT) IsValid() error {
+	if r.NftTokenId == "" {
+		return errors.New("NFT token id is invalid")
+	}
+
+	if r.RedeemerWalletId == "" {
+		return errors.New("Redeemer wallet id is invalid")
+	}
+
+	return nil
+}
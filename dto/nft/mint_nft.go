@@ -19,13 +19,20 @@
 
 package nft
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/pkg/errors"
+)
 
 type MintNFT struct {
-	GS1Number     string `json:"gs1Number"`
-	OwnerWalletId string `json:"ownerWalletId"`
-	HashData      string `json:"hashData"`
-	Metadata      string `json:"metadata"`
+	GS1Number       string `json:"gs1Number"`
+	OwnerWalletId   string `json:"ownerWalletId"`
+	HashData        string `json:"hashData"`
+	Metadata        string `json:"metadata"`
+	RoyaltyWalletId string `json:"royaltyWalletId"`
+	RoyaltyBps      int64  `json:"royaltyBps"`
+	dto.Idempotency
 }
 
 func (m MintNFT) IsValid() error {
@@ -45,5 +52,13 @@ func (m MintNFT) IsValid() error {
 		return errors.New("Hash of data is invalid")
 	}
 
+	if m.RoyaltyBps < 0 || m.RoyaltyBps > glossary.BasisPointBase {
+		return errors.New("Royalty rate is invalid")
+	}
+
+	if m.RoyaltyBps > 0 && m.RoyaltyWalletId == "" {
+		return errors.New("Royalty wallet id is invalid")
+	}
+
 	return nil
 }
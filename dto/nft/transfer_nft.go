@@ -19,7 +19,10 @@
 
 package nft
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/pkg/errors"
+)
 
 type TransferNFT struct {
 	FromWalletId string  `json:"fromWalletId"`
@@ -27,6 +30,7 @@ type TransferNFT struct {
 	FromTokenId  string  `json:"fromTokenId"`
 	NftTokenId   string  `json:"nftTokenId"`
 	Price        float64 `json:"price"`
+	dto.Idempotency
 }
 
 func (t TransferNFT) IsValid() error {
@@ -0,0 +1,110 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proposal
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/pkg/errors"
+)
+
+// ApprovalPolicy requires Threshold of Approvers (client identity ids) to approve an operation of a token.
+// An empty TokenId applies to every token, Ttl is the lifetime of a proposal in seconds
+type ApprovalPolicy struct {
+	Operation proposal.Operation `json:"operation"`
+	TokenId   string             `json:"tokenId"`
+	Approvers []string           `json:"approvers"`
+	Threshold int                `json:"threshold"`
+	Ttl       int64              `json:"ttl"`
+	dto.Idempotency
+}
+
+func (a ApprovalPolicy) IsValid() error {
+	if !a.Operation.IsValidate() {
+		return errors.New("operation is invalid")
+	}
+
+	if a.Threshold < 0 || a.Threshold > len(a.Approvers) {
+		return errors.New("threshold is invalid")
+	}
+
+	// duplicates would count towards the threshold here but are dropped when the policy is stored
+	approvers := make(map[string]bool, len(a.Approvers))
+	for _, approver := range a.Approvers {
+		if approver == "" {
+			return errors.New("approver is empty")
+		}
+		if approvers[approver] {
+			return errors.New("approver is duplicated")
+		}
+		approvers[approver] = true
+	}
+
+	if a.Ttl < 0 {
+		return errors.New("ttl is invalid")
+	}
+
+	return nil
+}
+
+type QueryApprovalPolicy struct {
+	Operation proposal.Operation `json:"operation"`
+	TokenId   string             `json:"tokenId"`
+}
+
+func (q QueryApprovalPolicy) IsValid() error {
+	if !q.Operation.IsValidate() {
+		return errors.New("operation is invalid")
+	}
+
+	return nil
+}
+
+// CreateProposal is an operation with its dto serialized in Payload
+type CreateProposal struct {
+	Operation proposal.Operation `json:"operation"`
+	Payload   string             `json:"payload"`
+	dto.Idempotency
+}
+
+func (c CreateProposal) IsValid() error {
+	if !c.Operation.IsValidate() {
+		return errors.New("operation is invalid")
+	}
+
+	if c.Payload == "" {
+		return errors.New("payload is empty")
+	}
+
+	return nil
+}
+
+type VoteProposal struct {
+	ProposalId string `json:"proposalId"`
+	dto.Idempotency
+}
+
+func (v VoteProposal) IsValid() error {
+	if v.ProposalId == "" {
+		return errors.New("proposal id is empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/transaction"
+)
+
+// BatchItem is the recipient wallet and amount of one item of a batch
+type BatchItem struct {
+	WalletId string `json:"walletId"`
+	Amount   string `json:"amount"`
+}
+
+// BatchItemResult is the status of one item of a batch. The transaction of a Pending item is written,
+// a Rejected item is skipped with its error
+type BatchItemResult struct {
+	WalletId string             `json:"walletId"`
+	Amount   string             `json:"amount"`
+	TxId     string             `json:"txId,omitempty"`
+	Status   transaction.Status `json:"status"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// TransferBatch transfer token from one wallet to every item of the batch
+type TransferBatch struct {
+	FromWalletId string      `json:"fromWalletId"`
+	TokenId      string      `json:"tokenId"`
+	Items        []BatchItem `json:"items"`
+	dto.Idempotency
+}
+
+func (t TransferBatch) IsValid() error {
+	if t.FromWalletId == "" {
+		return errors.New("from wallet id is empty")
+	}
+
+	if t.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+	return validateBatchItems(t.Items)
+}
+
+// MintBatch mint token to every item of the batch
+type MintBatch struct {
+	TokenId string      `json:"tokenId"`
+	Items   []BatchItem `json:"items"`
+	dto.Idempotency
+}
+
+func (m MintBatch) IsValid() error {
+	if m.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+	return validateBatchItems(m.Items)
+}
+
+func validateBatchItems(items []BatchItem) error {
+	if len(items) == 0 {
+		return errors.New("batch is empty")
+	}
+
+	if len(items) > glossary.BatchSize {
+		return fmt.Errorf("batch is larger than %d items", glossary.BatchSize)
+	}
+
+	for i, item := range items {
+		if item.WalletId == "" || item.Amount == "" {
+			return fmt.Errorf("wallet id or amount of item %d is empty", i)
+		}
+	}
+	return nil
+}
@@ -19,12 +19,16 @@
 
 package token
 
-import "errors"
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
 
 type BurnToken struct {
 	WalletId string `json:"walletId"`
 	TokenId  string `json:"tokenId"`
 	Amount   string `json:"amount"`
+	dto.Idempotency
 }
 
 func (b BurnToken) IsValid() error {
@@ -21,6 +21,7 @@ package token
 
 import (
 	"errors"
+	"github.com/Akachain/gringotts/dto"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/glossary/doc"
@@ -32,6 +33,7 @@ type CreateTokenType struct {
 	Name        string `json:"name"`
 	TickerToken string `json:"tickerToken"`
 	MaxSupply   string `json:"maxSupply"`
+	dto.Idempotency
 }
 
 func (c CreateTokenType) ToEntity(ctx contractapi.TransactionContextInterface) *entity.Token {
@@ -21,6 +21,7 @@ package token
 
 import (
 	"errors"
+	"github.com/Akachain/gringotts/dto"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/glossary/doc"
@@ -28,9 +29,13 @@ import (
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// CreateWallet create a wallet of token. A wallet with threshold is a multi-signature wallet of the owners (client identity ids)
 type CreateWallet struct {
-	TokenId string          `json:"tokenId"`
-	Status  glossary.Status `json:"status"`
+	TokenId   string          `json:"tokenId"`
+	Status    glossary.Status `json:"status"`
+	Owners    []string        `json:"owners,omitempty" metadata:",optional"`
+	Threshold int             `json:"threshold,omitempty" metadata:",optional"`
+	dto.Idempotency
 }
 
 func (c CreateWallet) ToEntity(ctx contractapi.TransactionContextInterface) *entity.Wallet {
@@ -49,6 +54,14 @@ func (c CreateWallet) IsValid() error {
 	if c.TokenId == "" {
 		return errors.New("token id is empty")
 	}
+	if c.Threshold < 0 || c.Threshold > len(c.Owners) {
+		return errors.New("threshold must be between 0 and the number of owners")
+	}
+	for _, owner := range c.Owners {
+		if owner == "" {
+			return errors.New("owner id is empty")
+		}
+	}
 	switch c.Status {
 	case glossary.Active, glossary.InActive:
 		return nil
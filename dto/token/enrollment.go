@@ -19,12 +19,17 @@
 
 package token
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
 
 type Enrollment struct {
 	TokenId      string   `json:"tokenId"`
 	FromWalletId []string `json:"fromWalletId"`
 	ToWalletId   []string `json:"toWalletId"`
+	dto.Idempotency
 }
 
 func (e Enrollment) IsValid() error {
@@ -32,8 +37,40 @@ func (e Enrollment) IsValid() error {
 		return errors.New("Token Id is empty")
 	}
 
-	if len(e.FromWalletId) <= 0 {
-		return errors.New("From wallet id is empty")
+	if len(e.FromWalletId) <= 0 && len(e.ToWalletId) <= 0 {
+		return errors.New("From/To wallet id is empty")
+	}
+
+	return nil
+}
+
+// IssuanceQuota is the maximum total amount of token issued to a wallet, an empty quota removes the limit
+type IssuanceQuota struct {
+	TokenId  string `json:"tokenId"`
+	WalletId string `json:"walletId"`
+	Quota    string `json:"quota"`
+	dto.Idempotency
+}
+
+func (i IssuanceQuota) IsValid() error {
+	if i.TokenId == "" || i.WalletId == "" {
+		return errors.New("Token/Wallet Id is empty")
+	}
+
+	if i.Quota != "" && helper.CompareStringBalance(i.Quota, "0") < 0 {
+		return errors.New("Quota is invalid")
+	}
+
+	return nil
+}
+
+type QueryEnrollment struct {
+	TokenId string `json:"tokenId"`
+}
+
+func (q QueryEnrollment) IsValid() error {
+	if q.TokenId == "" {
+		return errors.New("Token Id is empty")
 	}
 
 	return nil
@@ -20,6 +20,7 @@
 package token
 
 import (
+	"github.com/Akachain/gringotts/dto"
 	"github.com/pkg/errors"
 )
 
@@ -30,6 +31,8 @@ type ExchangeToken struct {
 	ToTokenId       string `json:"toTokenId"`
 	FromTokenAmount string `json:"fromTokenAmount"`
 	ToTokenAmount   string `json:"toTokenAmount"`
+	Expiry          int64  `json:"expiry,omitempty" metadata:",optional"`
+	dto.Idempotency
 }
 
 func (s ExchangeToken) IsValid() error {
@@ -0,0 +1,167 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/fee"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+)
+
+type FeeTier struct {
+	MinAmount  string `json:"minAmount"`
+	FlatAmount string `json:"flatAmount"`
+	RateBps    int64  `json:"rateBps"`
+}
+
+// FeeSchedule is the fee of a transaction type of a token. Status is Active when it is empty
+type FeeSchedule struct {
+	TokenId    string           `json:"tokenId"`
+	TxType     transaction.Type `json:"txType"`
+	FeeType    fee.Type         `json:"feeType"`
+	FlatAmount string           `json:"flatAmount"`
+	RateBps    int64            `json:"rateBps"`
+	Tiers      []FeeTier        `json:"tiers"`
+	MinFee     string           `json:"minFee"`
+	MaxFee     string           `json:"maxFee"`
+	FeeWallet  string           `json:"feeWallet"`
+	FeeTokenId string           `json:"feeTokenId"`
+	Status     glossary.Status  `json:"status"`
+	dto.Idempotency
+}
+
+func (f FeeSchedule) IsValid() error {
+	if f.TokenId == "" || f.TxType == "" {
+		return errors.New("token id/transaction type is empty")
+	}
+
+	if !f.FeeType.IsValidate() {
+		return errors.New("fee type is invalid")
+	}
+
+	if f.FeeWallet == "" {
+		return errors.New("fee wallet is empty")
+	}
+
+	if f.Status != "" && f.Status != glossary.Active && f.Status != glossary.InActive {
+		return errors.New("status is invalid")
+	}
+
+	switch f.FeeType {
+	case fee.Flat:
+		if !isAmount(f.FlatAmount) {
+			return errors.New("flat amount is invalid")
+		}
+	case fee.BasisPoint:
+		if !isRate(f.RateBps) {
+			return errors.New("fee rate is invalid")
+		}
+	case fee.Tiered:
+		if len(f.Tiers) == 0 {
+			return errors.New("fee tiers are empty")
+		}
+		for _, tier := range f.Tiers {
+			if !isAmount(tier.MinAmount) || (tier.FlatAmount != "" && !isAmount(tier.FlatAmount)) || !isRate(tier.RateBps) {
+				return errors.New("fee tier is invalid")
+			}
+		}
+	}
+
+	if f.MinFee != "" && !isAmount(f.MinFee) {
+		return errors.New("min fee is invalid")
+	}
+
+	if f.MaxFee != "" && !isAmount(f.MaxFee) {
+		return errors.New("max fee is invalid")
+	}
+
+	if f.MinFee != "" && f.MaxFee != "" && helper.CompareStringBalance(f.MinFee, f.MaxFee) > 0 {
+		return errors.New("min fee is greater than max fee")
+	}
+
+	return nil
+}
+
+// ToEntity set the fee schedule to the entity
+func (f FeeSchedule) ToEntity(schedule *entity.FeeSchedule) {
+	schedule.TokenId = f.TokenId
+	schedule.TxType = f.TxType
+	schedule.FeeType = f.FeeType
+	schedule.FlatAmount = f.FlatAmount
+	schedule.RateBps = f.RateBps
+	schedule.MinFee = f.MinFee
+	schedule.MaxFee = f.MaxFee
+	schedule.FeeWallet = f.FeeWallet
+	schedule.FeeTokenId = f.FeeTokenId
+	schedule.Status = glossary.Active
+	if f.Status != "" {
+		schedule.Status = f.Status
+	}
+
+	schedule.Tiers = make([]entity.FeeTier, 0, len(f.Tiers))
+	for _, tier := range f.Tiers {
+		schedule.Tiers = append(schedule.Tiers, entity.FeeTier{
+			MinAmount:  tier.MinAmount,
+			FlatAmount: tier.FlatAmount,
+			RateBps:    tier.RateBps,
+		})
+	}
+}
+
+type QueryFeeSchedule struct {
+	TokenId string           `json:"tokenId"`
+	TxType  transaction.Type `json:"txType"`
+}
+
+func (q QueryFeeSchedule) IsValid() error {
+	if q.TokenId == "" || q.TxType == "" {
+		return errors.New("token id/transaction type is empty")
+	}
+	return nil
+}
+
+type QuoteFee struct {
+	TokenId string           `json:"tokenId"`
+	TxType  transaction.Type `json:"txType"`
+	Amount  string           `json:"amount"`
+}
+
+func (q QuoteFee) IsValid() error {
+	if q.TokenId == "" || q.TxType == "" {
+		return errors.New("token id/transaction type is empty")
+	}
+
+	if !isAmount(q.Amount) {
+		return errors.New("amount is invalid")
+	}
+	return nil
+}
+
+func isAmount(amount string) bool {
+	return amount != "" && helper.CompareStringBalance(amount, "0") >= 0
+}
+
+func isRate(bps int64) bool {
+	return bps >= 0 && bps <= glossary.BasisPointBase
+}
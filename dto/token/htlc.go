@@ -0,0 +1,102 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
+
+// LockTransfer hold amount of the from wallet for the to wallet until timeout (unix seconds).
+// HashLock is the hex sha256 of the preimage revealed by the receiver to claim the transfer
+type LockTransfer struct {
+	FromWalletId string `json:"fromWalletId"`
+	ToWalletId   string `json:"toWalletId"`
+	TokenId      string `json:"tokenId"`
+	Amount       string `json:"amount"`
+	HashLock     string `json:"hashLock"`
+	Timeout      int64  `json:"timeout"`
+	dto.Idempotency
+}
+
+func (l LockTransfer) IsValid() error {
+	if l.FromWalletId == "" || l.ToWalletId == "" {
+		return errors.New("From/To wallet id is empty")
+	}
+
+	if l.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+
+	if l.Amount == "" {
+		return errors.New("the transfer amount is empty")
+	}
+
+	if l.HashLock == "" {
+		return errors.New("hash lock is empty")
+	}
+
+	if l.Timeout <= 0 {
+		return errors.New("timeout is invalid")
+	}
+	return nil
+}
+
+// ClaimTransfer is the locked transfer to settle to its receiver with the hex preimage of the hash lock
+type ClaimTransfer struct {
+	LockId   string `json:"lockId"`
+	Preimage string `json:"preimage"`
+	dto.Idempotency
+}
+
+func (c ClaimTransfer) IsValid() error {
+	if c.LockId == "" {
+		return errors.New("lock id is empty")
+	}
+
+	if c.Preimage == "" {
+		return errors.New("preimage is empty")
+	}
+	return nil
+}
+
+// RefundTransfer is the locked transfer to settle back to its sender after the timeout
+type RefundTransfer struct {
+	LockId string `json:"lockId"`
+	dto.Idempotency
+}
+
+func (r RefundTransfer) IsValid() error {
+	if r.LockId == "" {
+		return errors.New("lock id is empty")
+	}
+	return nil
+}
+
+type QueryLock struct {
+	LockId string `json:"lockId"`
+}
+
+func (q QueryLock) IsValid() error {
+	if q.LockId == "" {
+		return errors.New("lock id is empty")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
+
+// SignIntent is the open intent of a multi-signature wallet to sign or cancel
+type SignIntent struct {
+	IntentId string `json:"intentId"`
+	dto.Idempotency
+}
+
+func (s SignIntent) IsValid() error {
+	if s.IntentId == "" {
+		return errors.New("intent id is empty")
+	}
+	return nil
+}
+
+type QueryIntent struct {
+	WalletId string `json:"walletId"`
+}
+
+func (q QueryIntent) IsValid() error {
+	if q.WalletId == "" {
+		return errors.New("wallet id is empty")
+	}
+	return nil
+}
@@ -19,7 +19,10 @@
 
 package token
 
-import "github.com/pkg/errors"
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/pkg/errors"
+)
 
 type IssueToken struct {
 	// wallet use to issue new token
@@ -36,6 +39,7 @@ type IssueToken struct {
 
 	// number of new token will be issue. Use base unit (ax10^8)
 	ToTokenAmount string `json:"toTokenAmount"`
+	dto.Idempotency
 }
 
 func (i IssueToken) IsValid() error {
@@ -21,12 +21,14 @@ package token
 
 import (
 	"errors"
+	"github.com/Akachain/gringotts/dto"
 )
 
 type MintToken struct {
 	WalletId string `json:"walletId"`
 	TokenId  string `json:"tokenId"`
 	Amount   string `json:"amount"`
+	dto.Idempotency
 }
 
 func (m MintToken) IsValid() error {
@@ -0,0 +1,91 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
+
+// CreateSchedule is a standing order transferring amount at startTime and then every interval seconds until
+// endTime or count runs (unix seconds). A schedule without interval runs once
+type CreateSchedule struct {
+	FromWalletId string `json:"fromWalletId"`
+	ToWalletId   string `json:"toWalletId"`
+	TokenId      string `json:"tokenId"`
+	Amount       string `json:"amount"`
+	StartTime    int64  `json:"startTime"`
+	Interval     int64  `json:"interval,omitempty" metadata:",optional"`
+	EndTime      int64  `json:"endTime,omitempty" metadata:",optional"`
+	Count        int    `json:"count,omitempty" metadata:",optional"`
+	dto.Idempotency
+}
+
+func (c CreateSchedule) IsValid() error {
+	if c.FromWalletId == "" || c.ToWalletId == "" {
+		return errors.New("From/To wallet id is empty")
+	}
+
+	if c.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+
+	if c.Amount == "" {
+		return errors.New("the transfer amount is empty")
+	}
+
+	if c.StartTime <= 0 || c.Interval < 0 || c.EndTime < 0 || c.Count < 0 {
+		return errors.New("schedule time is invalid")
+	}
+
+	if c.EndTime > 0 && c.EndTime < c.StartTime {
+		return errors.New("end time is before start time")
+	}
+	return nil
+}
+
+// UpdateSchedule is the schedule from the wallet to pause, resume or cancel
+type UpdateSchedule struct {
+	WalletId   string `json:"walletId"`
+	ScheduleId string `json:"scheduleId"`
+	dto.Idempotency
+}
+
+func (u UpdateSchedule) IsValid() error {
+	if u.WalletId == "" {
+		return errors.New("wallet id is empty")
+	}
+
+	if u.ScheduleId == "" {
+		return errors.New("schedule id is empty")
+	}
+	return nil
+}
+
+type QuerySchedule struct {
+	WalletId string `json:"walletId"`
+}
+
+func (q QuerySchedule) IsValid() error {
+	if q.WalletId == "" {
+		return errors.New("wallet id is empty")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/glossary/transaction"
+)
+
+// SignedTransfer is a transfer signed off-chain by the key of the from wallet and submitted by a relayer.
+// Payload is the JSON of TransferToken, ExchangeToken or nft TransferNFT for the action Transfer, Exchange or TransferNft.
+// Signature is the base64 ECDSA signature of the canonical message of channel id, chaincode name, action, nonce,
+// expiry and payload.
+type SignedTransfer struct {
+	Action    transaction.Type `json:"action"`
+	Payload   string           `json:"payload"`
+	Nonce     int64            `json:"nonce"`
+	Expiry    int64            `json:"expiry"`
+	Signature string           `json:"signature"`
+	dto.Idempotency
+}
+
+func (s SignedTransfer) IsValid() error {
+	switch s.Action {
+	case transaction.Transfer, transaction.Exchange, transaction.TransferNft:
+	default:
+		return errors.New("action is not supported")
+	}
+
+	if s.Payload == "" || s.Signature == "" {
+		return errors.New("payload/signature is empty")
+	}
+
+	if s.Nonce <= 0 {
+		return errors.New("nonce must be positive")
+	}
+	return nil
+}
+
+// WalletKey is the public key of a wallet to verify its signed transfers
+type WalletKey struct {
+	WalletId  string `json:"walletId"`
+	PublicKey string `json:"publicKey"`
+	dto.Idempotency
+}
+
+func (w WalletKey) IsValid() error {
+	if w.WalletId == "" || w.PublicKey == "" {
+		return errors.New("wallet id/public key is empty")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
+
+// CreateSnapshot is the token whose holder balances are recorded
+type CreateSnapshot struct {
+	TokenId string `json:"tokenId"`
+	dto.Idempotency
+}
+
+func (c CreateSnapshot) IsValid() error {
+	if c.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+	return nil
+}
+
+// ScanSnapshot is the snapshot to scan the next page of holders
+type ScanSnapshot struct {
+	SnapshotId string `json:"snapshotId"`
+	dto.Idempotency
+}
+
+func (s ScanSnapshot) IsValid() error {
+	if s.SnapshotId == "" {
+		return errors.New("snapshot id is empty")
+	}
+	return nil
+}
+
+type QuerySnapshot struct {
+	SnapshotId string `json:"snapshotId"`
+}
+
+func (q QuerySnapshot) IsValid() error {
+	if q.SnapshotId == "" {
+		return errors.New("snapshot id is empty")
+	}
+	return nil
+}
+
+// DistributeProRata pay total amount of payout token from a wallet to the holders of a snapshot,
+// the rounding remainder is paid to the remainder wallet
+type DistributeProRata struct {
+	SnapshotId        string `json:"snapshotId"`
+	FromWalletId      string `json:"fromWalletId"`
+	PayoutTokenId     string `json:"payoutTokenId"`
+	TotalAmount       string `json:"totalAmount"`
+	RemainderWalletId string `json:"remainderWalletId"`
+	dto.Idempotency
+}
+
+func (d DistributeProRata) IsValid() error {
+	if d.SnapshotId == "" {
+		return errors.New("snapshot id is empty")
+	}
+
+	if d.FromWalletId == "" || d.RemainderWalletId == "" {
+		return errors.New("from/remainder wallet id is empty")
+	}
+
+	if d.PayoutTokenId == "" {
+		return errors.New("payout token id is empty")
+	}
+
+	if d.TotalAmount == "" {
+		return errors.New("the total amount is empty")
+	}
+	return nil
+}
+
+// ContinueDistribution is the distribution to pay the next page of holders
+type ContinueDistribution struct {
+	DistributionId string `json:"distributionId"`
+	dto.Idempotency
+}
+
+func (c ContinueDistribution) IsValid() error {
+	if c.DistributionId == "" {
+		return errors.New("distribution id is empty")
+	}
+	return nil
+}
+
+type QueryDistribution struct {
+	DistributionId string `json:"distributionId"`
+}
+
+func (q QueryDistribution) IsValid() error {
+	if q.DistributionId == "" {
+		return errors.New("distribution id is empty")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/pkg/errors"
+)
+
+type ProposeSwap struct {
+	MakerWalletId string `json:"makerWalletId"`
+	TakerWalletId string `json:"takerWalletId"`
+	MakerTokenId  string `json:"makerTokenId"`
+	TakerTokenId  string `json:"takerTokenId"`
+	MakerAmount   string `json:"makerAmount"`
+	TakerAmount   string `json:"takerAmount"`
+	Expiry        int64  `json:"expiry"`
+	dto.Idempotency
+}
+
+func (p ProposeSwap) IsValid() error {
+	if p.MakerWalletId == "" || p.TakerWalletId == "" {
+		return errors.New("Maker/Taker wallet id is empty")
+	}
+
+	if p.MakerWalletId == p.TakerWalletId {
+		return errors.New("Maker and taker wallet id are the same")
+	}
+
+	if p.MakerTokenId == "" || p.TakerTokenId == "" {
+		return errors.New("Maker/Taker token id is empty")
+	}
+
+	if p.MakerAmount == "" || helper.CompareStringBalance(p.MakerAmount, "0") <= 0 {
+		return errors.New("Maker amount is invalid")
+	}
+
+	if p.TakerAmount == "" || helper.CompareStringBalance(p.TakerAmount, "0") <= 0 {
+		return errors.New("Taker amount is invalid")
+	}
+
+	if p.Expiry < 0 {
+		return errors.New("Expiry is invalid")
+	}
+
+	return nil
+}
+
+type AcceptSwap struct {
+	SwapId        string `json:"swapId"`
+	TakerWalletId string `json:"takerWalletId"`
+	dto.Idempotency
+}
+
+func (a AcceptSwap) IsValid() error {
+	if a.SwapId == "" {
+		return errors.New("Swap id is empty")
+	}
+
+	if a.TakerWalletId == "" {
+		return errors.New("Taker wallet id is empty")
+	}
+
+	return nil
+}
+
+type CancelSwap struct {
+	SwapId   string `json:"swapId"`
+	WalletId string `json:"walletId"`
+	dto.Idempotency
+}
+
+func (c CancelSwap) IsValid() error {
+	if c.SwapId == "" {
+		return errors.New("Swap id is empty")
+	}
+
+	if c.WalletId == "" {
+		return errors.New("Wallet id is empty")
+	}
+
+	return nil
+}
+
+type QuerySwap struct {
+	SwapId string `json:"swapId"`
+}
+
+func (q QuerySwap) IsValid() error {
+	if q.SwapId == "" {
+		return errors.New("Swap id is empty")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+)
+
+// TokenControl is the token to pause, unpause or deactivate
+type TokenControl struct {
+	TokenId string `json:"tokenId"`
+	dto.Idempotency
+}
+
+func (t TokenControl) IsValid() error {
+	if t.TokenId == "" {
+		return errors.New("token id is empty")
+	}
+	return nil
+}
+
+type FreezeBalance struct {
+	WalletId string `json:"walletId"`
+	TokenId  string `json:"tokenId"`
+	Reason   string `json:"reason"`
+	dto.Idempotency
+}
+
+func (f FreezeBalance) IsValid() error {
+	if f.WalletId == "" || f.TokenId == "" {
+		return errors.New("wallet/token id is empty")
+	}
+
+	if f.Reason == "" {
+		return errors.New("the freeze reason is empty")
+	}
+	return nil
+}
+
+type UnfreezeBalance struct {
+	WalletId string `json:"walletId"`
+	TokenId  string `json:"tokenId"`
+	dto.Idempotency
+}
+
+func (u UnfreezeBalance) IsValid() error {
+	if u.WalletId == "" || u.TokenId == "" {
+		return errors.New("wallet/token id is empty")
+	}
+	return nil
+}
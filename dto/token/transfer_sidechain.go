@@ -20,6 +20,7 @@
 package token
 
 import (
+	"github.com/Akachain/gringotts/dto"
 	"github.com/Akachain/gringotts/glossary/sidechain"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ type TransferSideChain struct {
 	FromChain sidechain.SideName `json:"fromChain"`
 	ToChain   sidechain.SideName `json:"toChain"`
 	Amount    string             `json:"amount"`
+	dto.Idempotency
 }
 
 func (t TransferSideChain) IsValid() error {
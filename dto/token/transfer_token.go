@@ -21,6 +21,7 @@ package token
 
 import (
 	"errors"
+	"github.com/Akachain/gringotts/dto"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/glossary/doc"
 	"github.com/Akachain/gringotts/glossary/transaction"
@@ -33,6 +34,8 @@ type TransferToken struct {
 	ToWalletId   string `json:"toWalletId"`
 	TokenId      string `json:"tokenId"`
 	Amount       string `json:"amount"`
+	Expiry       int64  `json:"expiry,omitempty" metadata:",optional"`
+	dto.Idempotency
 }
 
 func (t TransferToken) ToEntity(ctx contractapi.TransactionContextInterface) *entity.Transaction {
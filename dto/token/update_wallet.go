@@ -21,12 +21,14 @@ package token
 
 import (
 	"errors"
+	"github.com/Akachain/gringotts/dto"
 	"github.com/Akachain/gringotts/glossary"
 )
 
 type UpdateWallet struct {
 	WalletId string          `json:"walletId"`
 	Status   glossary.Status `json:"status"`
+	dto.Idempotency
 }
 
 func (u UpdateWallet) IsValid() error {
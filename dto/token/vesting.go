@@ -0,0 +1,82 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package token
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/entity"
+)
+
+// RevokeVesting takes the unvested amount of a vesting grant back to the issuer wallet of the grant
+type RevokeVesting struct {
+	IssuerWalletId string `json:"issuerWalletId"`
+	WalletId       string `json:"walletId"`
+	TokenId        string `json:"tokenId"`
+	VestingId      string `json:"vestingId"`
+	dto.Idempotency
+}
+
+func (r RevokeVesting) IsValid() error {
+	if r.IssuerWalletId == "" || r.WalletId == "" {
+		return errors.New("issuer/wallet id is empty")
+	}
+
+	if r.TokenId == "" || r.VestingId == "" {
+		return errors.New("token/vesting id is empty")
+	}
+	return nil
+}
+
+type QueryVesting struct {
+	WalletId string `json:"walletId"`
+	TokenId  string `json:"tokenId"`
+}
+
+func (q QueryVesting) IsValid() error {
+	if q.WalletId == "" || q.TokenId == "" {
+		return errors.New("wallet/token id is empty")
+	}
+	return nil
+}
+
+// VestingGrant is a vesting grant with its amounts at the transaction time
+type VestingGrant struct {
+	VestingId    string             `json:"vestingId"`
+	IaoId        string             `json:"iaoId"`
+	IssuerWallet string             `json:"issuerWallet"`
+	Total        string             `json:"total"`
+	Vested       string             `json:"vested"`
+	Unvested     string             `json:"unvested"`
+	Revoked      string             `json:"revoked"`
+	StartTime    int64              `json:"startTime"`
+	RevokedAt    int64              `json:"revokedAt"`
+	Plan         entity.VestingPlan `json:"plan"`
+}
+
+// VestingStatus is the locked balance of token in wallet, Unvested of it can not be spent at the transaction time
+type VestingStatus struct {
+	WalletId string         `json:"walletId"`
+	TokenId  string         `json:"tokenId"`
+	Locked   string         `json:"locked"`
+	Vested   string         `json:"vested"`
+	Unvested string         `json:"unvested"`
+	Grants   []VestingGrant `json:"grants"`
+}
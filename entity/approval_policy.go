@@ -0,0 +1,52 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// ApprovalPolicy requires Threshold of Approvers to approve a proposal before Operation is executed.
+// The policy of an empty TokenId applies to every token without its own policy.
+// Approvers is the sorted set of client identity ids, Ttl is the lifetime of a proposal in seconds.
+type ApprovalPolicy struct {
+	Operation proposal.Operation
+	TokenId   string
+	Approvers []string
+	Threshold int
+	Ttl       int64
+	Base      `mapstructure:",squash"`
+}
+
+func NewApprovalPolicy(ctx ...contractapi.TransactionContextInterface) *ApprovalPolicy {
+	if len(ctx) <= 0 {
+		return &ApprovalPolicy{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &ApprovalPolicy{
+		Base: Base{
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+	}
+}
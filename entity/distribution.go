@@ -0,0 +1,61 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/snapshot"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Distribution is the payout of TotalAmount of PayoutTokenId from FromWallet to the holders of a snapshot in proportion
+// to their balances. TotalAmount is held when the distribution is created and paid in batches in order of wallet id,
+// LastWalletId is the last holder paid and Paid the number of payouts. The shares are rounded down and the remainder
+// is paid to RemainderWallet when all holders are paid.
+type Distribution struct {
+	SnapshotId      string
+	FromWallet      string
+	PayoutTokenId   string
+	TotalAmount     string
+	RemainderWallet string
+	Distributed     string
+	LastWalletId    string
+	Paid            int
+	Status          snapshot.Status
+	Base            `mapstructure:",squash"`
+}
+
+func NewDistribution(ctx ...contractapi.TransactionContextInterface) *Distribution {
+	if len(ctx) <= 0 {
+		return &Distribution{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Distribution{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Distribution, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Distributed: "0",
+		Status:      snapshot.Distributing,
+	}
+}
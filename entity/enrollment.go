@@ -25,11 +25,23 @@ import (
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// Enrollment is the issuance policy of TokenId.
+// Issuers and Recipients are sorted sets of wallet id. A set is restricted once a wallet is enrolled in it,
+// a restricted set allows only its wallets, even after they are all removed, an unrestricted set allows every wallet.
+// Quota is the maximum total amount a wallet can be issued or minted and Issued is the amount it has been,
+// a wallet without quota is not limited.
+// FromWalletId and ToWalletId are the comma-joined lists of old policies, they are moved to the sets when the policy is loaded.
 type Enrollment struct {
-	TokenId      string
-	FromWalletId string
-	ToWalletId   string
-	Base         `mapstructure:",squash"`
+	TokenId              string
+	Issuers              []string
+	Recipients           []string
+	IssuersRestricted    bool
+	RecipientsRestricted bool
+	Quota                map[string]string
+	Issued               map[string]string
+	FromWalletId         string
+	ToWalletId           string
+	Base                 `mapstructure:",squash"`
 }
 
 func NewEnrollment(ctx ...contractapi.TransactionContextInterface) *Enrollment {
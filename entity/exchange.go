@@ -21,17 +21,22 @@ package entity
 
 import (
 	"github.com/Akachain/gringotts/glossary/doc"
-	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/glossary/listing"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// Exchange is a listing of an nft token in the marketplace.
+// The nft token is locked by the listing until it is canceled or filled.
+// Price is in base unit of PriceTokenId and Expiry is a time unix, zero means no expiry.
 type Exchange struct {
 	OwnerWalletId string
 	ToWalletId    string
 	NftTokenId    string
-	Price         float64
-	Status        transaction.Status
+	PriceTokenId  string
+	Price         string
+	Expiry        int64
+	Status        listing.Status
 	Base          `mapstructure:",squash"`
 }
 
@@ -47,5 +52,6 @@ func NewExchange(ctx ...contractapi.TransactionContextInterface) *Exchange {
 			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
 			BlockChainId: ctx[0].GetStub().GetTxID(),
 		},
+		Status: listing.Open,
 	}
 }
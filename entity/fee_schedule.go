@@ -0,0 +1,68 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/fee"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// FeeTier applies to amounts from MinAmount up to the MinAmount of the next tier
+type FeeTier struct {
+	MinAmount  string
+	FlatAmount string
+	RateBps    int64
+}
+
+// FeeSchedule is the fee charged on transactions of TxType for TokenId.
+// The fee is paid by the from wallet in FeeTokenId, or in TokenId when FeeTokenId is empty, to FeeWallet.
+// MinFee and MaxFee bound the fee when they are not empty.
+type FeeSchedule struct {
+	TokenId    string
+	TxType     transaction.Type
+	FeeType    fee.Type
+	FlatAmount string
+	RateBps    int64
+	Tiers      []FeeTier
+	MinFee     string
+	MaxFee     string
+	FeeWallet  string
+	FeeTokenId string
+	Status     glossary.Status
+	Base       `mapstructure:",squash"`
+}
+
+func NewFeeSchedule(ctx ...contractapi.TransactionContextInterface) *FeeSchedule {
+	if len(ctx) <= 0 {
+		return &FeeSchedule{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &FeeSchedule{
+		Base: Base{
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Status: glossary.Active,
+	}
+}
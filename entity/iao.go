@@ -20,12 +20,19 @@
 package entity
 
 import (
+	"errors"
 	"github.com/Akachain/gringotts/glossary/doc"
 	"github.com/Akachain/gringotts/glossary/iao"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// Iao is the sale of AssetTokenAmount of an asset at Rate stable token per asset token.
+// StartTime and EndTime (unix seconds) are parsed from StartDate and EndDate, they are zero for iao created before.
+// With a commitment Allocation, purchases add up in CommittedAssetToken and are filled by the allocation run.
+// With KycTiers, only wallets of the eligibility list of the iao can buy.
+// The iao is only paid in the tokens of Prices at their price, the stable token of the iao is listed at Rate.
+// An iao without Prices accepts no token.
 type Iao struct {
 	AssetId             string
 	AssetTokenId        string
@@ -33,9 +40,19 @@ type Iao struct {
 	StableTokenAmount   string
 	StartDate           string
 	EndDate             string
+	StartTime           int64
+	EndTime             int64
 	RemainingAssetToken string
 	Status              iao.Status
 	Rate                int64
+	Vesting             VestingPlan
+	Allocation          iao.Allocation
+	AllocationTier      string
+	CommittedAssetToken string
+	Allocated           bool
+	Limit               IaoLimit
+	KycTiers            []KycTier
+	Prices              []IaoPrice
 	Base                `mapstructure:",squash"`
 }
 
@@ -54,3 +71,105 @@ func NewIao(ctx ...contractapi.TransactionContextInterface) *Iao {
 		Status: iao.New,
 	}
 }
+
+// IsOnSale return true if the iao is open and the time unix now is in its sale window
+func (i *Iao) IsOnSale(now int64) bool {
+	if i.Status != iao.Open {
+		return false
+	}
+	return now >= i.StartTime && (i.EndTime == 0 || now < i.EndTime)
+}
+
+// IaoPrice is the price of one asset token in TokenId, a rational (3/7) or decimal (0.5) string
+type IaoPrice struct {
+	TokenId string
+	Price   string
+}
+
+// IaoLimit bounds the sale of an iao in asset token, the raise is the amount times Rate. Empty limits are not applied.
+// The iao is canceled when SoftCap is not sold by the end date, no more than HardCap is sold, and the total bought
+// by a wallet over all its purchases is between MinPurchase and MaxPurchase.
+type IaoLimit struct {
+	SoftCap     string
+	HardCap     string
+	MinPurchase string
+	MaxPurchase string
+}
+
+// IaoInvestor is the total asset token a wallet bought or committed to buy in an iao over all its purchases
+type IaoInvestor struct {
+	IaoId            string
+	WalletId         string
+	AssetTokenAmount string
+	Base             `mapstructure:",squash"`
+}
+
+func NewIaoInvestor(ctx ...contractapi.TransactionContextInterface) *IaoInvestor {
+	if len(ctx) <= 0 {
+		return &IaoInvestor{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &IaoInvestor{
+		Base: Base{
+			Id:           helper.GenerateID(doc.IaoInvestor, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		AssetTokenAmount: "0",
+	}
+}
+
+// SoldAssetToken return the asset token sold, or committed when the iao allocates commitments
+func (i *Iao) SoldAssetToken() string {
+	if i.Allocation.IsCommitment() {
+		return orZero(i.CommittedAssetToken)
+	}
+	sold, err := helper.SubBalance(orZero(i.AssetTokenAmount), orZero(i.RemainingAssetToken))
+	if err != nil {
+		return "0"
+	}
+	return sold
+}
+
+// IsSoftCapReached return true if the iao has no soft cap or has sold it
+func (i *Iao) IsSoftCapReached() bool {
+	return i.Limit.SoftCap == "" || helper.CompareStringBalance(i.SoldAssetToken(), i.Limit.SoftCap) >= 0
+}
+
+func orZero(amount string) string {
+	if amount == "" {
+		return "0"
+	}
+	return amount
+}
+
+// KycTier return the KYC tier of iao named tier
+func (i *Iao) KycTier(tier string) (*KycTier, bool) {
+	for index := range i.KycTiers {
+		if i.KycTiers[index].Tier == tier {
+			return &i.KycTiers[index], true
+		}
+	}
+	return nil, false
+}
+
+// IsAccepted return true if the iao is paid in tokenId
+func (i *Iao) IsAccepted(tokenId string) bool {
+	for _, price := range i.Prices {
+		if price.TokenId == tokenId {
+			return true
+		}
+	}
+	return false
+}
+
+// Cost return the amount of tokenId paid for amount of asset token, rounded up to the base unit
+func (i *Iao) Cost(tokenId, amount string) (string, error) {
+	for _, price := range i.Prices {
+		if price.TokenId == tokenId {
+			return helper.PriceBalance(amount, price.Price)
+		}
+	}
+	return "", errors.New("Token is not accepted by iao")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// KycTier is a KYC tier of the investors of an iao, a wallet of the tier invests no more than MaxInvestment
+// asset token over all its purchases. An empty MaxInvestment is not applied.
+type KycTier struct {
+	Tier          string
+	MaxInvestment string
+}
+
+// IaoEligibility allows WalletId to buy in an iao with KYC tiers until Expiry (unix seconds, zero for none)
+type IaoEligibility struct {
+	IaoId        string
+	WalletId     string
+	Tier         string
+	Jurisdiction string
+	Expiry       int64
+	Base         `mapstructure:",squash"`
+}
+
+func NewIaoEligibility(ctx ...contractapi.TransactionContextInterface) *IaoEligibility {
+	if len(ctx) <= 0 {
+		return &IaoEligibility{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &IaoEligibility{
+		Base: Base{
+			Id:           helper.GenerateID(doc.IaoEligibility, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+	}
+}
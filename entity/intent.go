@@ -0,0 +1,65 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/intent"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Intent is an outgoing transaction of a multi-signature wallet waiting for signatures of its owners.
+// The pending transaction is written once Threshold of Owners have signed, TxId is its id.
+type Intent struct {
+	WalletId        string
+	FromWallet      string
+	ToWallet        string
+	FromTokenId     string
+	ToTokenId       string
+	FromTokenAmount string
+	ToTokenAmount   string
+	TxType          transaction.Type
+	Note            string
+	Expiry          int64
+	Owners          []string
+	Threshold       int
+	Signatures      []string
+	TxId            string
+	Status          intent.Status
+	Base            `mapstructure:",squash"`
+}
+
+func NewIntent(ctx ...contractapi.TransactionContextInterface) *Intent {
+	if len(ctx) <= 0 {
+		return &Intent{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Intent{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Intent, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Status: intent.Open,
+	}
+}
@@ -25,12 +25,20 @@ import (
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
+// NFT is a non-fungible token owned by a single wallet.
+// RoyaltyWalletId receives RoyaltyBps (in basis points) of the price every time
+// the NFT is sold.
+// LockedBy is the id of the listing, offer or vault holding the NFT in escrow,
+// a locked NFT cannot be transferred directly.
 type NFT struct {
-	HashData  string
-	GS1Number string
-	MetaData  string
-	OwnerId   string
-	Base      `mapstructure:",squash"`
+	HashData        string
+	GS1Number       string
+	MetaData        string
+	OwnerId         string
+	RoyaltyWalletId string
+	RoyaltyBps      int64
+	LockedBy        string
+	Base            `mapstructure:",squash"`
 }
 
 func NewNFT(ctx ...contractapi.TransactionContextInterface) *NFT {
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Order is a limit order to buy or sell Amount of BaseTokenId at Price in QuoteTokenId.
+// Price is the amount of quote token in base unit for one token of base.
+// LockedAmount is the part of the balance still locked in the exchange balance of the wallet,
+// base token of a sell order or quote token of a buy order.
+// Sequence is the place of the order in the order sequence of its token pair, it gives the time priority of the order.
+type Order struct {
+	WalletId     string
+	BaseTokenId  string
+	QuoteTokenId string
+	Side         order.Side
+	Price        string
+	Amount       string
+	FilledAmount string
+	LockedAmount string
+	Status       order.Status
+	Sequence     int64
+	Base         `mapstructure:",squash"`
+}
+
+func NewOrder(ctx ...contractapi.TransactionContextInterface) *Order {
+	if len(ctx) <= 0 {
+		return &Order{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Order{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Order, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		FilledAmount: "0",
+		Status:       order.Open,
+	}
+}
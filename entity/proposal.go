@@ -0,0 +1,61 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Proposal is a privileged operation waiting for approval. Payload is the serialized dto of the operation.
+// Approvers and Threshold are taken from the approval policy when the proposal is created.
+// Expiry is a time unix, Result is the response of the operation once it is executed.
+type Proposal struct {
+	Operation  proposal.Operation
+	TokenId    string
+	Payload    string
+	Proposer   string
+	Approvers  []string
+	Threshold  int
+	Approvals  []string
+	Rejections []string
+	Expiry     int64
+	Result     string
+	Status     proposal.Status
+	Base       `mapstructure:",squash"`
+}
+
+func NewProposal(ctx ...contractapi.TransactionContextInterface) *Proposal {
+	if len(ctx) <= 0 {
+		return &Proposal{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Proposal{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Proposal, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Status: proposal.Pending,
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/schedule"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Schedule is a standing order transferring Amount from FromWallet to ToWallet at StartTime and then every Interval
+// seconds until EndTime or Count runs (unix seconds, zero for none). A schedule without Interval runs once.
+// Executed is the number of runs done and NextTime the time of the next run.
+type Schedule struct {
+	FromWallet string
+	ToWallet   string
+	TokenId    string
+	Amount     string
+	StartTime  int64
+	Interval   int64
+	EndTime    int64
+	Count      int
+	Executed   int
+	NextTime   int64
+	Status     schedule.Status
+	Base       `mapstructure:",squash"`
+}
+
+func NewSchedule(ctx ...contractapi.TransactionContextInterface) *Schedule {
+	if len(ctx) <= 0 {
+		return &Schedule{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Schedule{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Schedule, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Status: schedule.Active,
+	}
+}
+
+// IsDone return true when the schedule has no more runs after Executed runs
+func (s *Schedule) IsDone() bool {
+	if s.Interval <= 0 {
+		return s.Executed > 0
+	}
+	if s.Count > 0 && s.Executed >= s.Count {
+		return true
+	}
+	return s.EndTime > 0 && s.NextTime > s.EndTime
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/snapshot"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Snapshot is the record of the spot balances of all holders of TokenId. The balances are scanned page by page
+// in order of wallet id, LastWalletId is the last wallet scanned. TotalBalance is the sum of Holders balances
+type Snapshot struct {
+	TokenId      string
+	LastWalletId string
+	Holders      int
+	TotalBalance string
+	Status       snapshot.Status
+	Base         `mapstructure:",squash"`
+}
+
+func NewSnapshot(ctx ...contractapi.TransactionContextInterface) *Snapshot {
+	if len(ctx) <= 0 {
+		return &Snapshot{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Snapshot{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Snapshot, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		TotalBalance: "0",
+		Status:       snapshot.Scanning,
+	}
+}
+
+// SnapshotBalance is the balance of one holder in a snapshot
+type SnapshotBalance struct {
+	SnapshotId string
+	WalletId   string
+	Balance    string
+	Base       `mapstructure:",squash"`
+}
+
+func NewSnapshotBalance(ctx ...contractapi.TransactionContextInterface) *SnapshotBalance {
+	if len(ctx) <= 0 {
+		return &SnapshotBalance{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &SnapshotBalance{
+		Base: Base{
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+	}
+}
@@ -27,14 +27,16 @@ import (
 )
 
 // A Token structure will have the name of the token type,
-// the conversion rate to the base unit, and status (active/inactive)
-// the status is checked only when we create a new wallet.
+// the conversion rate to the base unit, and status (active/paused/inactive)
+// the status is checked when we create a new wallet and on every transaction of the token.
+// Manager is the vault or pool that alone changes the supply of the token, it can not be minted or issued.
 type Token struct {
 	Name        string
 	TickerToken string
 	MaxSupply   string
 	TotalSupply string
 	Status      glossary.Status
+	Manager     string
 	Base        `mapstructure:",squash"`
 }
 
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Trade is a fill between a buy order and a sell order of the same token pair.
+// Amount of base token is exchanged for QuoteAmount of quote token at Price.
+type Trade struct {
+	BuyOrderId     string
+	SellOrderId    string
+	BuyerWalletId  string
+	SellerWalletId string
+	BaseTokenId    string
+	QuoteTokenId   string
+	Price          string
+	Amount         string
+	QuoteAmount    string
+	Base           `mapstructure:",squash"`
+}
+
+// NewTrade return the trade of the index item in a batch of matching orders
+func NewTrade(index int, ctx ...contractapi.TransactionContextInterface) *Trade {
+	if len(ctx) <= 0 {
+		return &Trade{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Trade{
+		Base: Base{
+			Id:           helper.GenerateBatchID(doc.Trade, ctx[0].GetStub().GetTxID(), index),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+	}
+}
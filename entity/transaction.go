@@ -30,6 +30,8 @@ import (
 // From, To, Amount, type of transaction and its status.
 // The SpenderWallet is an additional field in case later on we want this is compatible
 // with ERC20
+// A pending transaction past its Expiry (unix seconds, 0 means never) is expired by accounting instead of settled.
+// ReversedAmount is the amount of a transfer taken back by Reverse transactions, Note of a Reverse is the reversed tx id.
 type Transaction struct {
 	SpenderWallet   string
 	FromWallet      string
@@ -42,6 +44,11 @@ type Transaction struct {
 	Status          transaction.Status
 	Note            string
 	Reason          string
+	FeeAmount       string
+	FeeTokenId      string
+	FeeWallet       string
+	Expiry          int64
+	ReversedAmount  string
 	Base            `mapstructure:",squash"`
 }
 
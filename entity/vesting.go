@@ -0,0 +1,72 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package entity
+
+import (
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/vesting"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// VestingPlan unlocks nothing before Cliff seconds from the start. A Cliff plan unlocks everything at the cliff,
+// a Linear plan unlocks continuously and a Step plan unlocks every Step seconds until Duration seconds from the start.
+type VestingPlan struct {
+	VestingType vesting.Type
+	Cliff       int64
+	Duration    int64
+	Step        int64
+}
+
+// IsEmpty return true if the plan does not lock anything
+func (v VestingPlan) IsEmpty() bool {
+	return v.VestingType == ""
+}
+
+// Vesting is a grant of Total token locked in the wallet at StartTime (unix seconds) and unlocked following Plan.
+// IssuerWallet may revoke the unvested amount, Revoked is the amount taken back at RevokedAt.
+type Vesting struct {
+	WalletId     string
+	TokenId      string
+	Total        string
+	StartTime    int64
+	Plan         VestingPlan
+	IssuerWallet string
+	IaoId        string
+	Revoked      string
+	RevokedAt    int64
+	Base         `mapstructure:",squash"`
+}
+
+func NewVesting(ctx ...contractapi.TransactionContextInterface) *Vesting {
+	if len(ctx) <= 0 {
+		return &Vesting{}
+	}
+	txTime, _ := ctx[0].GetStub().GetTxTimestamp()
+	return &Vesting{
+		Base: Base{
+			Id:           helper.GenerateID(doc.Vesting, ctx[0].GetStub().GetTxID()),
+			CreatedAt:    helper.TimestampISO(txTime.Seconds),
+			UpdatedAt:    helper.TimestampISO(txTime.Seconds),
+			BlockChainId: ctx[0].GetStub().GetTxID(),
+		},
+		Revoked: "0",
+	}
+}
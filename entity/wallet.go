@@ -27,9 +27,16 @@ import (
 )
 
 // A wallet only contains 1 type of token and its balance.
+// A wallet with a Threshold is a multi-signature wallet, its outgoing transactions need signatures of Threshold of Owners
+// (client identity ids).
+// PublicKey is the PEM ECDSA key of a wallet accepting signed transfers, Nonce is the last nonce it used.
 type Wallet struct {
-	Status glossary.Status
-	Base   `mapstructure:",squash"`
+	Status    glossary.Status
+	Owners    []string
+	Threshold int
+	PublicKey string
+	Nonce     int64
+	Base      `mapstructure:",squash"`
 }
 
 func NewWallet(ctx ...contractapi.TransactionContextInterface) *Wallet {
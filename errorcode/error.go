@@ -39,103 +39,349 @@ const (
 	InvalidWalletInActive ErrorCode = "103"
 
 	// Business error code
-	BizUnableParse              ErrorCode = "300"
-	BizUnableCreateTX           ErrorCode = "301"
-	BizUnableCreateWallet       ErrorCode = "302"
-	BizUnableGetWallet          ErrorCode = "303"
-	BizUnableUpdateWallet       ErrorCode = "304"
-	BizUnableMintToken          ErrorCode = "305"
-	BizUnableBurnToken          ErrorCode = "306"
-	BizUnableCreateToken        ErrorCode = "307"
-	BizBalanceNotEnough         ErrorCode = "308"
-	BizUnableMapDecode          ErrorCode = "309"
-	BizUnableGetTokenType       ErrorCode = "310"
-	BizUnableGetTx              ErrorCode = "311"
-	BizUnableApproveAllowance   ErrorCode = "312"
-	BizUnableGetAllowance       ErrorCode = "313"
-	BizAllowanceNotEnough       ErrorCode = "314"
-	BizUnableUpdateAllowance    ErrorCode = "315"
-	BizUnableTransferDiffType   ErrorCode = "316"
-	BizUnableGetEnrollment      ErrorCode = "317"
-	BizIssueNotPermission       ErrorCode = "318"
-	BizUnableCreateEnrollment   ErrorCode = "319"
-	BizUnableUpdateTX           ErrorCode = "320"
-	BizUnableCreateNFT          ErrorCode = "321"
-	BizUnableGetNFT             ErrorCode = "322"
-	BizUnableUpdateNFT          ErrorCode = "323"
-	BizUnableCreateExchange     ErrorCode = "324"
-	BizUnableGetExchange        ErrorCode = "325"
-	BizExchangeTxInvalidStatus  ErrorCode = "326"
-	BizUnableUpdateExchange     ErrorCode = "327"
-	BizUnableCreateBalance      ErrorCode = "328"
-	BizUnableUpdateBalance      ErrorCode = "329"
-	BizUnableGetBalance         ErrorCode = "330"
-	BizNftNotPermission         ErrorCode = "331"
-	BizUnableCreateAsset        ErrorCode = "332"
-	BizUnableGetAsset           ErrorCode = "333"
-	BizUnableCreateIao          ErrorCode = "334"
-	BizUnableGetIao             ErrorCode = "335"
-	BizUnableUpdateAsset        ErrorCode = "336"
-	BizOverMaxSupply            ErrorCode = "337"
-	BizUnableToIssue            ErrorCode = "338"
-	BizUnableUpdateIao          ErrorCode = "339"
-	BizUnableCreateInvestorBook ErrorCode = "340"
-	BizUnableCreateBuyCache     ErrorCode = "341"
-	BizUnableGetBuyCache        ErrorCode = "342"
-	BizUnableGetInvestorBook    ErrorCode = "343"
-	BizUnableUpdateInvestorBook ErrorCode = "344"
+	BizUnableParse                ErrorCode = "300"
+	BizUnableCreateTX             ErrorCode = "301"
+	BizUnableCreateWallet         ErrorCode = "302"
+	BizUnableGetWallet            ErrorCode = "303"
+	BizUnableUpdateWallet         ErrorCode = "304"
+	BizUnableMintToken            ErrorCode = "305"
+	BizUnableBurnToken            ErrorCode = "306"
+	BizUnableCreateToken          ErrorCode = "307"
+	BizBalanceNotEnough           ErrorCode = "308"
+	BizUnableMapDecode            ErrorCode = "309"
+	BizUnableGetTokenType         ErrorCode = "310"
+	BizUnableGetTx                ErrorCode = "311"
+	BizUnableApproveAllowance     ErrorCode = "312"
+	BizUnableGetAllowance         ErrorCode = "313"
+	BizAllowanceNotEnough         ErrorCode = "314"
+	BizUnableUpdateAllowance      ErrorCode = "315"
+	BizUnableTransferDiffType     ErrorCode = "316"
+	BizUnableGetEnrollment        ErrorCode = "317"
+	BizIssueNotPermission         ErrorCode = "318"
+	BizUnableCreateEnrollment     ErrorCode = "319"
+	BizUnableUpdateTX             ErrorCode = "320"
+	BizUnableCreateNFT            ErrorCode = "321"
+	BizUnableGetNFT               ErrorCode = "322"
+	BizUnableUpdateNFT            ErrorCode = "323"
+	BizUnableCreateExchange       ErrorCode = "324"
+	BizUnableGetExchange          ErrorCode = "325"
+	BizExchangeTxInvalidStatus    ErrorCode = "326"
+	BizUnableUpdateExchange       ErrorCode = "327"
+	BizUnableCreateBalance        ErrorCode = "328"
+	BizUnableUpdateBalance        ErrorCode = "329"
+	BizUnableGetBalance           ErrorCode = "330"
+	BizNftNotPermission           ErrorCode = "331"
+	BizUnableCreateAsset          ErrorCode = "332"
+	BizUnableGetAsset             ErrorCode = "333"
+	BizUnableCreateIao            ErrorCode = "334"
+	BizUnableGetIao               ErrorCode = "335"
+	BizUnableUpdateAsset          ErrorCode = "336"
+	BizOverMaxSupply              ErrorCode = "337"
+	BizUnableToIssue              ErrorCode = "338"
+	BizUnableUpdateIao            ErrorCode = "339"
+	BizUnableCreateInvestorBook   ErrorCode = "340"
+	BizUnableCreateBuyCache       ErrorCode = "341"
+	BizUnableGetBuyCache          ErrorCode = "342"
+	BizUnableGetInvestorBook      ErrorCode = "343"
+	BizUnableUpdateInvestorBook   ErrorCode = "344"
+	BizUnableCalculateRoyalty     ErrorCode = "345"
+	BizUnableCreateOffer          ErrorCode = "346"
+	BizUnableGetOffer             ErrorCode = "347"
+	BizUnableUpdateOffer          ErrorCode = "348"
+	BizListingInvalidStatus       ErrorCode = "349"
+	BizListingExpired             ErrorCode = "350"
+	BizNftLocked                  ErrorCode = "351"
+	BizUnableHoldBalance          ErrorCode = "352"
+	BizUnableReleaseBalance       ErrorCode = "353"
+	BizUnableQueryData            ErrorCode = "354"
+	BizUnableCreateVault          ErrorCode = "355"
+	BizUnableGetVault             ErrorCode = "356"
+	BizUnableUpdateVault          ErrorCode = "357"
+	BizVaultInvalidStatus         ErrorCode = "358"
+	BizUnableCreateSwap           ErrorCode = "359"
+	BizUnableGetSwap              ErrorCode = "360"
+	BizUnableUpdateSwap           ErrorCode = "361"
+	BizSwapInvalidStatus          ErrorCode = "362"
+	BizSwapExpired                ErrorCode = "363"
+	BizSwapNotPermission          ErrorCode = "364"
+	BizUnableCreateOrder          ErrorCode = "365"
+	BizUnableGetOrder             ErrorCode = "366"
+	BizUnableUpdateOrder          ErrorCode = "367"
+	BizOrderInvalidStatus         ErrorCode = "368"
+	BizOrderNotPermission         ErrorCode = "369"
+	BizUnableLockBalance          ErrorCode = "370"
+	BizUnableCreateTrade          ErrorCode = "371"
+	BizUnableCreatePool           ErrorCode = "372"
+	BizUnableGetPool              ErrorCode = "373"
+	BizUnableUpdatePool           ErrorCode = "374"
+	BizPoolExisted                ErrorCode = "375"
+	BizPoolInvalidToken           ErrorCode = "376"
+	BizPoolSlippage               ErrorCode = "377"
+	BizUnableCalculatePool        ErrorCode = "378"
+	BizNotAdmin                   ErrorCode = "379"
+	BizUnableGetFeeSchedule       ErrorCode = "380"
+	BizUnableUpdateFeeSchedule    ErrorCode = "381"
+	BizUnableCalculateFee         ErrorCode = "382"
+	BizUnableUpdateToken          ErrorCode = "383"
+	BizTokenPaused                ErrorCode = "384"
+	BizTokenInActive              ErrorCode = "385"
+	BizTokenInvalidStatus         ErrorCode = "386"
+	BizUnableUpdateFreeze         ErrorCode = "387"
+	BizBalanceFrozen              ErrorCode = "388"
+	BizUnableGetFreeze            ErrorCode = "389"
+	BizIssueOverQuota             ErrorCode = "390"
+	BizUnableGetIdentity          ErrorCode = "391"
+	BizUnableGetApprovalPolicy    ErrorCode = "392"
+	BizUnableUpdateApprovalPolicy ErrorCode = "393"
+	BizApprovalRequired           ErrorCode = "394"
+	BizUnableCreateProposal       ErrorCode = "395"
+	BizUnableGetProposal          ErrorCode = "396"
+	BizUnableUpdateProposal       ErrorCode = "397"
+	BizProposalInvalidStatus      ErrorCode = "398"
+	BizProposalExpired            ErrorCode = "399"
+	BizProposalNotApprover        ErrorCode = "400"
+	BizProposalAlreadyVoted       ErrorCode = "401"
+	BizUnableCreateIntent         ErrorCode = "402"
+	BizUnableGetIntent            ErrorCode = "403"
+	BizUnableUpdateIntent         ErrorCode = "404"
+	BizIntentInvalidStatus        ErrorCode = "405"
+	BizIntentNotOwner             ErrorCode = "406"
+	BizIntentAlreadySigned        ErrorCode = "407"
+	BizWalletKeyNotRegistered     ErrorCode = "408"
+	BizInvalidPublicKey           ErrorCode = "409"
+	BizInvalidSignature           ErrorCode = "410"
+	BizSignedTransferExpired      ErrorCode = "411"
+	BizInvalidNonce               ErrorCode = "412"
+	BizUnableGetIdempotency       ErrorCode = "413"
+	BizUnableUpdateIdempotency    ErrorCode = "414"
+	BizIdempotencyConflict        ErrorCode = "415"
+	BizTransactionNotPending      ErrorCode = "416"
+	BizNotSpender                 ErrorCode = "417"
+	BizTransactionNotReversible   ErrorCode = "418"
+	BizReverseOverAmount          ErrorCode = "419"
+	BizNotReceiver                ErrorCode = "420"
+	BizUnableCreateHashLock       ErrorCode = "421"
+	BizUnableGetHashLock          ErrorCode = "422"
+	BizUnableUpdateHashLock       ErrorCode = "423"
+	BizHashLockInvalidStatus      ErrorCode = "424"
+	BizHashLockTimeout            ErrorCode = "425"
+	BizHashLockNotTimeout         ErrorCode = "426"
+	BizInvalidPreimage            ErrorCode = "427"
+	BizUnableCreateSchedule       ErrorCode = "428"
+	BizUnableGetSchedule          ErrorCode = "429"
+	BizUnableUpdateSchedule       ErrorCode = "430"
+	BizScheduleInvalidStatus      ErrorCode = "431"
+	BizNotScheduleSource          ErrorCode = "432"
+	BizScheduleMultiSig           ErrorCode = "433"
+	BizBatchMultiSig              ErrorCode = "434"
+	BizUnableCreateSnapshot       ErrorCode = "435"
+	BizUnableGetSnapshot          ErrorCode = "436"
+	BizUnableUpdateSnapshot       ErrorCode = "437"
+	BizSnapshotInvalidStatus      ErrorCode = "438"
+	BizSnapshotEmpty              ErrorCode = "439"
+	BizUnableCreateDistribution   ErrorCode = "440"
+	BizUnableGetDistribution      ErrorCode = "441"
+	BizUnableUpdateDistribution   ErrorCode = "442"
+	BizDistributionInvalidStatus  ErrorCode = "443"
+	BizUnableCreateVesting        ErrorCode = "444"
+	BizUnableGetVesting           ErrorCode = "445"
+	BizUnableUpdateVesting        ErrorCode = "446"
+	BizNotVestingIssuer           ErrorCode = "447"
+	BizVestingRevoked             ErrorCode = "448"
+	BizIaoInvalidDate             ErrorCode = "449"
+	BizIaoInvalidTransition       ErrorCode = "450"
+	BizIaoInvalidStatus           ErrorCode = "451"
+	BizIaoNotCommitment           ErrorCode = "452"
+	BizIaoAllocated               ErrorCode = "453"
+	BizUnableGetIaoCommitment     ErrorCode = "454"
+	BizUnableUpdateIaoCommitment  ErrorCode = "455"
+	BizIaoSoftCapNotReached       ErrorCode = "456"
+	BizUnableGetIaoInvestor       ErrorCode = "457"
+	BizUnableUpdateIaoInvestor    ErrorCode = "458"
+	BizUnableGetIaoEligibility    ErrorCode = "459"
+	BizUnableUpdateIaoEligibility ErrorCode = "460"
+	BizIaoKycTierNotFound         ErrorCode = "461"
+	BizTransactionNotCancelable   ErrorCode = "462"
+	BizHashLockRevealed           ErrorCode = "463"
+	BizHashLockMultiSig           ErrorCode = "464"
+	BizTokenSupplyManaged         ErrorCode = "465"
+	BizSnapshotTokenNotPaused     ErrorCode = "466"
+	BizWalletMultiSig             ErrorCode = "467"
 )
 
 var mapErrorCode = map[ErrorCode]string{
-	Succeed:                     "Invoke transaction succeed",
-	InvalidArg:                  "Incorrect number of arguments",
-	InvalidParam:                "Parameter input invalidate",
-	InvalidWalletInActive:       "Wallet has status inactive",
-	BizUnableParse:              "Unable to parse argument",
-	BizUnableCreateTX:           "Unable to create transaction on blockchain",
-	BizUnableCreateWallet:       "Unable to create wallet on blockchain",
-	BizUnableGetWallet:          "Unable to get wallet on blockchain",
-	BizUnableUpdateWallet:       "Unable to update wallet on blockchain",
-	BizUnableMintToken:          "Unable to mint token for wallet on blockchain",
-	BizUnableBurnToken:          "Unable to burn token for wallet on blockchain",
-	BizUnableCreateToken:        "Unable to create token type on blockchain",
-	BizBalanceNotEnough:         "Balance of wallet insufficient",
-	BizUnableMapDecode:          "Unable to parse entity on blockchain",
-	BizUnableGetTokenType:       "Unable to get token type on blockchain",
-	BizUnableGetTx:              "Unable to get list transaction on blockchain",
-	BizUnableApproveAllowance:   "Unable to approve allowance of spender wallet on blockchain",
-	BizUnableGetAllowance:       "Unable to get allowance of spender wallet on blockchain",
-	BizAllowanceNotEnough:       "Transfer amount greater than allowance of spender",
-	BizUnableUpdateAllowance:    "Unable update allowance of spender wallet on blockchain",
-	BizUnableTransferDiffType:   "Unable to transfer token between wallet have different token type",
-	BizUnableGetEnrollment:      "Unable to get enrollment on blockchain",
-	BizIssueNotPermission:       "From/To wallet do not have permission to issue new token",
-	BizUnableCreateEnrollment:   "Unable to create/update wallet enrollment",
-	BizUnableUpdateTX:           "Unable to update transaction on blockchain",
-	BizUnableCreateNFT:          "Unable to create new NFT token on blockchain",
-	BizUnableGetNFT:             "Unable to get NFT token on blockchain",
-	BizUnableUpdateNFT:          "Unable to update NFT token on blockchain",
-	BizUnableCreateExchange:     "Unable to create new exchange nft record on blockchain",
-	BizUnableGetExchange:        "Unable to get exchange nft record on blockchain",
-	BizExchangeTxInvalidStatus:  "Exchange transaction have status difference pending on the blockchain",
-	BizUnableUpdateExchange:     "Unable to update exchange nft transaction on the blockchain",
-	BizUnableCreateBalance:      "Unable to create new balance of token on the blockchain",
-	BizUnableUpdateBalance:      "Unable to update balance of token on the blockchain",
-	BizUnableGetBalance:         "Unable to get balance of token on the blockchain",
-	BizNftNotPermission:         "Wallet Id not match owner of nft token",
-	BizUnableCreateAsset:        "Unable to create new asset on the blockchain",
-	BizUnableGetAsset:           "Unable to get asset on the blockchain",
-	BizUnableCreateIao:          "Unable to create new iao campaign on the blockchain",
-	BizUnableGetIao:             "Unable to get Iao campaign on the blockchain",
-	BizUnableUpdateAsset:        "Unable to update asset on the blockchain",
-	BizOverMaxSupply:            "New token issue over the max supply",
-	BizUnableToIssue:            "Unable to issue new token on the blockchain",
-	BizUnableUpdateIao:          "Unable to update IAO on the blockchain",
-	BizUnableCreateInvestorBook: "Unable to create new Investor Book on the blockchain",
-	BizUnableCreateBuyCache:     "Unable to create buy iao cache on the blockchain",
-	BizUnableGetBuyCache:        "Unable to get buy iao cache on the blockchain",
-	BizUnableGetInvestorBook:    "Unable to get investor book on the blockchain",
-	BizUnableUpdateInvestorBook: "Unable to update investor book on the blockchain",
+	Succeed:                       "Invoke transaction succeed",
+	InvalidArg:                    "Incorrect number of arguments",
+	InvalidParam:                  "Parameter input invalidate",
+	InvalidWalletInActive:         "Wallet has status inactive",
+	BizUnableParse:                "Unable to parse argument",
+	BizUnableCreateTX:             "Unable to create transaction on blockchain",
+	BizUnableCreateWallet:         "Unable to create wallet on blockchain",
+	BizUnableGetWallet:            "Unable to get wallet on blockchain",
+	BizUnableUpdateWallet:         "Unable to update wallet on blockchain",
+	BizUnableMintToken:            "Unable to mint token for wallet on blockchain",
+	BizUnableBurnToken:            "Unable to burn token for wallet on blockchain",
+	BizUnableCreateToken:          "Unable to create token type on blockchain",
+	BizBalanceNotEnough:           "Balance of wallet insufficient",
+	BizUnableMapDecode:            "Unable to parse entity on blockchain",
+	BizUnableGetTokenType:         "Unable to get token type on blockchain",
+	BizUnableGetTx:                "Unable to get list transaction on blockchain",
+	BizUnableApproveAllowance:     "Unable to approve allowance of spender wallet on blockchain",
+	BizUnableGetAllowance:         "Unable to get allowance of spender wallet on blockchain",
+	BizAllowanceNotEnough:         "Transfer amount greater than allowance of spender",
+	BizUnableUpdateAllowance:      "Unable update allowance of spender wallet on blockchain",
+	BizUnableTransferDiffType:     "Unable to transfer token between wallet have different token type",
+	BizUnableGetEnrollment:        "Unable to get enrollment on blockchain",
+	BizIssueNotPermission:         "From/To wallet do not have permission to issue new token",
+	BizUnableCreateEnrollment:     "Unable to create/update wallet enrollment",
+	BizUnableUpdateTX:             "Unable to update transaction on blockchain",
+	BizUnableCreateNFT:            "Unable to create new NFT token on blockchain",
+	BizUnableGetNFT:               "Unable to get NFT token on blockchain",
+	BizUnableUpdateNFT:            "Unable to update NFT token on blockchain",
+	BizUnableCreateExchange:       "Unable to create new exchange nft record on blockchain",
+	BizUnableGetExchange:          "Unable to get exchange nft record on blockchain",
+	BizExchangeTxInvalidStatus:    "Exchange transaction have status difference pending on the blockchain",
+	BizUnableUpdateExchange:       "Unable to update exchange nft transaction on the blockchain",
+	BizUnableCreateBalance:        "Unable to create new balance of token on the blockchain",
+	BizUnableUpdateBalance:        "Unable to update balance of token on the blockchain",
+	BizUnableGetBalance:           "Unable to get balance of token on the blockchain",
+	BizNftNotPermission:           "Wallet Id not match owner of nft token",
+	BizUnableCreateAsset:          "Unable to create new asset on the blockchain",
+	BizUnableGetAsset:             "Unable to get asset on the blockchain",
+	BizUnableCreateIao:            "Unable to create new iao campaign on the blockchain",
+	BizUnableGetIao:               "Unable to get Iao campaign on the blockchain",
+	BizUnableUpdateAsset:          "Unable to update asset on the blockchain",
+	BizOverMaxSupply:              "New token issue over the max supply",
+	BizUnableToIssue:              "Unable to issue new token on the blockchain",
+	BizUnableUpdateIao:            "Unable to update IAO on the blockchain",
+	BizUnableCreateInvestorBook:   "Unable to create new Investor Book on the blockchain",
+	BizUnableCreateBuyCache:       "Unable to create buy iao cache on the blockchain",
+	BizUnableGetBuyCache:          "Unable to get buy iao cache on the blockchain",
+	BizUnableGetInvestorBook:      "Unable to get investor book on the blockchain",
+	BizUnableUpdateInvestorBook:   "Unable to update investor book on the blockchain",
+	BizUnableCalculateRoyalty:     "Unable to calculate royalty of nft token",
+	BizUnableCreateOffer:          "Unable to create new nft offer on the blockchain",
+	BizUnableGetOffer:             "Unable to get nft offer on the blockchain",
+	BizUnableUpdateOffer:          "Unable to update nft offer on the blockchain",
+	BizListingInvalidStatus:       "Listing or offer is not open on the blockchain",
+	BizListingExpired:             "Listing or offer has expired",
+	BizNftLocked:                  "NFT token is locked in escrow",
+	BizUnableHoldBalance:          "Unable to hold balance of wallet in escrow",
+	BizUnableReleaseBalance:       "Unable to release balance of wallet from escrow",
+	BizUnableQueryData:            "Unable to query documents on the blockchain",
+	BizUnableCreateVault:          "Unable to create nft vault on the blockchain",
+	BizUnableGetVault:             "Unable to get nft vault on the blockchain",
+	BizUnableUpdateVault:          "Unable to update nft vault on the blockchain",
+	BizVaultInvalidStatus:         "NFT vault has been redeemed",
+	BizUnableCreateSwap:           "Unable to create token swap on the blockchain",
+	BizUnableGetSwap:              "Unable to get token swap on the blockchain",
+	BizUnableUpdateSwap:           "Unable to update token swap on the blockchain",
+	BizSwapInvalidStatus:          "Token swap is not open on the blockchain",
+	BizSwapExpired:                "Token swap has expired",
+	BizSwapNotPermission:          "Wallet Id not match party of token swap",
+	BizUnableCreateOrder:          "Unable to create limit order on the blockchain",
+	BizUnableGetOrder:             "Unable to get limit order on the blockchain",
+	BizUnableUpdateOrder:          "Unable to update limit order on the blockchain",
+	BizOrderInvalidStatus:         "Limit order is not open on the blockchain",
+	BizOrderNotPermission:         "Wallet Id not match owner of limit order",
+	BizUnableLockBalance:          "Unable to lock balance of wallet in exchange",
+	BizUnableCreateTrade:          "Unable to create trade on the blockchain",
+	BizUnableCreatePool:           "Unable to create liquidity pool on the blockchain",
+	BizUnableGetPool:              "Unable to get liquidity pool on the blockchain",
+	BizUnableUpdatePool:           "Unable to update liquidity pool on the blockchain",
+	BizPoolExisted:                "Liquidity pool of token pair already exists",
+	BizPoolInvalidToken:           "Token is not in the liquidity pool",
+	BizPoolSlippage:               "Amount out of pool is lower than minimum amount out",
+	BizUnableCalculatePool:        "Unable to calculate amount of liquidity pool",
+	BizNotAdmin:                   "Caller is not an administrator of the system",
+	BizUnableGetFeeSchedule:       "Unable to get fee schedule on the blockchain",
+	BizUnableUpdateFeeSchedule:    "Unable to update fee schedule on the blockchain",
+	BizUnableCalculateFee:         "Unable to calculate fee of transaction",
+	BizUnableUpdateToken:          "Unable to update token type on the blockchain",
+	BizTokenPaused:                "Token is paused",
+	BizTokenInActive:              "Token has been deactivated",
+	BizTokenInvalidStatus:         "Token status does not allow this change",
+	BizUnableUpdateFreeze:         "Unable to update balance freeze on the blockchain",
+	BizBalanceFrozen:              "Balance of wallet is frozen for the token",
+	BizUnableGetFreeze:            "Unable to get balance freeze on the blockchain",
+	BizIssueOverQuota:             "Amount is over the issuance quota of wallet",
+	BizUnableGetIdentity:          "Unable to get client identity of the caller",
+	BizUnableGetApprovalPolicy:    "Unable to get approval policy on the blockchain",
+	BizUnableUpdateApprovalPolicy: "Unable to update approval policy on the blockchain",
+	BizApprovalRequired:           "Operation requires an approved proposal",
+	BizUnableCreateProposal:       "Unable to create proposal on the blockchain",
+	BizUnableGetProposal:          "Unable to get proposal on the blockchain",
+	BizUnableUpdateProposal:       "Unable to update proposal on the blockchain",
+	BizProposalInvalidStatus:      "Proposal is not pending on the blockchain",
+	BizProposalExpired:            "Proposal has expired",
+	BizProposalNotApprover:        "Caller is not an approver of proposal",
+	BizProposalAlreadyVoted:       "Caller has already voted on proposal",
+	BizUnableCreateIntent:         "Unable to create multi-signature intent on the blockchain",
+	BizUnableGetIntent:            "Unable to get multi-signature intent on the blockchain",
+	BizUnableUpdateIntent:         "Unable to update multi-signature intent on the blockchain",
+	BizIntentInvalidStatus:        "Multi-signature intent is not open on the blockchain",
+	BizIntentNotOwner:             "Caller is not an owner of multi-signature wallet",
+	BizIntentAlreadySigned:        "Caller has already signed multi-signature intent",
+	BizWalletKeyNotRegistered:     "Wallet has no registered public key on the blockchain",
+	BizInvalidPublicKey:           "Public key is not a valid ECDSA key",
+	BizInvalidSignature:           "Signature of signed transfer is invalid",
+	BizSignedTransferExpired:      "Signed transfer is expired",
+	BizInvalidNonce:               "Nonce of signed transfer is already used",
+	BizUnableGetIdempotency:       "Unable to get idempotency record on the blockchain",
+	BizUnableUpdateIdempotency:    "Unable to update idempotency record on the blockchain",
+	BizIdempotencyConflict:        "Request id is already used with a different payload",
+	BizTransactionNotPending:      "Transaction is not pending on the blockchain",
+	BizNotSpender:                 "Wallet is not the spender of transaction",
+	BizTransactionNotReversible:   "Only confirmed transfers can be reversed",
+	BizReverseOverAmount:          "Reversal amount exceeds the amount sent",
+	BizNotReceiver:                "Wallet is not the receiver of transaction",
+	BizUnableCreateHashLock:       "Unable to create hashed time-locked transfer on the blockchain",
+	BizUnableGetHashLock:          "Unable to get hashed time-locked transfer on the blockchain",
+	BizUnableUpdateHashLock:       "Unable to update hashed time-locked transfer on the blockchain",
+	BizHashLockInvalidStatus:      "Hashed time-locked transfer is not locked",
+	BizHashLockTimeout:            "Time lock of transfer has passed",
+	BizHashLockNotTimeout:         "Time lock of transfer has not passed yet",
+	BizInvalidPreimage:            "Preimage does not match the hash lock",
+	BizUnableCreateSchedule:       "Unable to create schedule on the blockchain",
+	BizUnableGetSchedule:          "Unable to get schedule on the blockchain",
+	BizUnableUpdateSchedule:       "Unable to update schedule on the blockchain",
+	BizScheduleInvalidStatus:      "Schedule status does not allow the operation",
+	BizNotScheduleSource:          "Wallet is not the source of schedule",
+	BizScheduleMultiSig:           "Scheduled transfers are not supported for multi-signature wallets",
+	BizBatchMultiSig:              "Batch transfers are not supported for multi-signature wallets",
+	BizUnableCreateSnapshot:       "Unable to create snapshot on the blockchain",
+	BizUnableGetSnapshot:          "Unable to get snapshot on the blockchain",
+	BizUnableUpdateSnapshot:       "Unable to update snapshot on the blockchain",
+	BizSnapshotInvalidStatus:      "Snapshot status does not allow the operation",
+	BizSnapshotEmpty:              "Snapshot has no holder balance",
+	BizUnableCreateDistribution:   "Unable to create distribution on the blockchain",
+	BizUnableGetDistribution:      "Unable to get distribution on the blockchain",
+	BizUnableUpdateDistribution:   "Unable to update distribution on the blockchain",
+	BizDistributionInvalidStatus:  "Distribution status does not allow the operation",
+	BizUnableCreateVesting:        "Unable to create vesting on the blockchain",
+	BizUnableGetVesting:           "Unable to get vesting on the blockchain",
+	BizUnableUpdateVesting:        "Unable to update vesting on the blockchain",
+	BizNotVestingIssuer:           "Wallet is not the issuer of vesting",
+	BizVestingRevoked:             "Vesting was already revoked",
+	BizIaoInvalidDate:             "Start/End date of Iao is invalid",
+	BizIaoInvalidTransition:       "Iao status does not allow the transition",
+	BizIaoInvalidStatus:           "Iao status does not allow the operation",
+	BizIaoNotCommitment:           "Iao does not collect commitments",
+	BizIaoAllocated:               "Commitments of iao were already allocated",
+	BizUnableGetIaoCommitment:     "Unable to get iao commitment on the blockchain",
+	BizUnableUpdateIaoCommitment:  "Unable to update iao commitment on the blockchain",
+	BizIaoSoftCapNotReached:       "Iao has not sold its soft cap",
+	BizUnableGetIaoInvestor:       "Unable to get total of investor in iao on the blockchain",
+	BizUnableUpdateIaoInvestor:    "Unable to update total of investor in iao on the blockchain",
+	BizUnableGetIaoEligibility:    "Unable to get eligibility of iao on the blockchain",
+	BizUnableUpdateIaoEligibility: "Unable to update eligibility of iao on the blockchain",
+	BizIaoKycTierNotFound:         "KYC tier is not defined in iao",
+	BizTransactionNotCancelable:   "Transaction can not be canceled",
+	BizHashLockRevealed:           "Preimage of transfer is revealed, it can only be claimed",
+	BizHashLockMultiSig:           "Hashed time-locked transfers are not supported for multi-signature wallets",
+	BizTokenSupplyManaged:         "Supply of the token is managed by its vault or pool",
+	BizSnapshotTokenNotPaused:     "Token must be paused while its snapshot is scanned",
+	BizWalletMultiSig:             "Operation is not supported for multi-signature wallets",
 }
 
 func (e ErrorCode) Message() string {
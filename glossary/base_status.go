@@ -21,10 +21,12 @@
 // the whole project
 package glossary
 
-// An arbitrary object status is either Active or InActive
+// An arbitrary object status is either Active or InActive.
+// A token can also be Paused for a while, an InActive token is deactivated for good
 type Status string
 
 const (
 	Active   Status = "A"
 	InActive        = "I"
+	Paused          = "P"
 )
@@ -44,5 +44,20 @@ const StringUnitBase = 10
 // NftExchange work around to distinguish transfer token using for exchange nft
 const NftExchange = "NftExchange"
 
+// BasisPointBase is the denominator of rates expressed in basis points (1 bps = 0.01%)
+const BasisPointBase = 10000
+
 // NumberWorker use to bulk put state
 const NumberWorker = 20
+
+// AdminAttribute is the attribute of the client certificate that grants administration of the system
+const AdminAttribute = "gringotts.admin"
+
+// ProposalTtl is the lifetime in seconds of a proposal when its approval policy does not set one (7 days)
+const ProposalTtl = 7 * 24 * 60 * 60
+
+// IdempotencyTtl is the lifetime in seconds of the stored result of a request id (1 day)
+const IdempotencyTtl = 24 * 60 * 60
+
+// BatchSize is the maximum number of items of a batch operation, it limits the write set of a transaction
+const BatchSize = 500
@@ -37,4 +37,29 @@ const (
 	InvestorBook     = "InvestorBook"
 	Asset            = "Asset"
 	BuyIaoCache      = "BuyIaoCache"
+	Offer            = "Offer"
+	EscrowBalances   = "EscrowBalances"
+	Vault            = "Vault"
+	Swap             = "Swap"
+	Order            = "Order"
+	OrderSequence    = "OrderSequence"
+	Trade            = "Trade"
+	Pool             = "Pool"
+	PoolBalances     = "PoolBalances"
+	FeeSchedule      = "FeeSchedule"
+	Freeze           = "Freeze"
+	ApprovalPolicy   = "ApprovalPolicy"
+	Proposal         = "Proposal"
+	Intent           = "Intent"
+	Idempotency      = "Idempotency"
+	HashLock         = "HashLock"
+	Schedule         = "Schedule"
+	Snapshot         = "Snapshot"
+	SnapshotBalance  = "SnapshotBalance"
+	Distribution     = "Distribution"
+	LockedBalances   = "LockedBalances"
+	Vesting          = "Vesting"
+	IaoCommitment    = "IaoCommitment"
+	IaoInvestor      = "IaoInvestor"
+	IaoEligibility   = "IaoEligibility"
 )
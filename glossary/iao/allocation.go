@@ -0,0 +1,44 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package iao
+
+// Allocation is how the asset token of an iao is shared between investors.
+// FirstCome fills purchases in order until the iao is sold out, ProRata and Tiered collect commitments
+// during the sale and allocate them when it is closed.
+type Allocation string
+
+const (
+	FirstCome Allocation = "FirstCome"
+	ProRata              = "ProRata"
+	Tiered               = "Tiered"
+)
+
+func (a Allocation) IsValidate() bool {
+	switch a {
+	case FirstCome, ProRata, Tiered:
+		return true
+	}
+	return false
+}
+
+// IsCommitment return true if purchases are collected as commitments, an empty allocation is FirstCome
+func (a Allocation) IsCommitment() bool {
+	return a == ProRata || a == Tiered
+}
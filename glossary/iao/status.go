@@ -37,3 +37,19 @@ func (s Status) IsValidate() bool {
 	}
 	return false
 }
+
+// CanTransitTo return true if an iao is allowed to move from the status to next status.
+// The sale goes New -> Open -> Distributing -> Done, or Open -> Canceling -> Canceled when it is canceled.
+func (s Status) CanTransitTo(next Status) bool {
+	switch s {
+	case New:
+		return next == Open
+	case Open:
+		return next == Distributing || next == Canceling
+	case Distributing:
+		return next == Done
+	case Canceling:
+		return next == Canceled
+	}
+	return false
+}
@@ -28,4 +28,6 @@ const (
 	Pending   Status = "Pending"
 	Confirmed        = "Confirmed"
 	Rejected         = "Rejected"
+	Canceled         = "Canceled"
+	Expired          = "Expired"
 )
@@ -22,16 +22,25 @@ package transaction
 type Type string
 
 const (
-	Deposit           Type = "Deposit"
-	Withdraw               = "Withdraw"
-	Transfer               = "Transfer"
-	Mint                   = "Mint"
-	Burn                   = "Burn"
-	Exchange               = "Exchange"
-	Issue                  = "Issue"
-	TransferNft            = "TransferNft"
-	IaoDepositAT           = "IaoDepositAT"
-	SideChainTransfer      = "SideChainTransfer"
-	DistributionAT         = "DistributionAT"
-	ReturnST               = "ReturnST"
+	Deposit             Type = "Deposit"
+	Withdraw                 = "Withdraw"
+	Transfer                 = "Transfer"
+	Mint                     = "Mint"
+	Burn                     = "Burn"
+	Exchange                 = "Exchange"
+	Issue                    = "Issue"
+	TransferNft              = "TransferNft"
+	IaoDepositAT             = "IaoDepositAT"
+	SideChainTransfer        = "SideChainTransfer"
+	DistributionAT           = "DistributionAT"
+	ReturnST                 = "ReturnST"
+	NftSettlement            = "NftSettlement"
+	RedeemNft                = "RedeemNft"
+	PoolSwap                 = "PoolSwap"
+	PoolAddLiquidity         = "PoolAddLiquidity"
+	PoolRemoveLiquidity      = "PoolRemoveLiquidity"
+	Reverse                  = "Reverse"
+	HtlcClaim                = "HtlcClaim"
+	HtlcRefund               = "HtlcRefund"
+	ProRataDistribution      = "ProRataDistribution"
 )
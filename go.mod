@@ -7,10 +7,12 @@ replace golang.org/x/sys => github.com/golang/sys v0.0.0-20210124154548-22da62e1
 require (
 	github.com/Akachain/akc-go-sdk-v2 v1.0.2
 	github.com/davecgh/go-spew v1.1.1
+	github.com/golang/protobuf v1.4.3
 	github.com/google/addlicense v0.0.0-20210428195630-6d92264d7170 // indirect
 	github.com/hyperledger/fabric v2.1.1+incompatible
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20210319203922-6b661064d4d9
 	github.com/hyperledger/fabric-contract-api-go v1.1.1
+	github.com/hyperledger/fabric-protos-go v0.0.0-20210318103044-13fdee960194
 	github.com/mitchellh/mapstructure v1.3.2
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
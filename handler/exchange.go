@@ -0,0 +1,134 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/exchange"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type ExchangeHandler struct {
+	exchangeService services.Exchange
+}
+
+func NewExchangeHandler() ExchangeHandler {
+	return ExchangeHandler{exchange.NewExchangeService()}
+}
+
+func (e *ExchangeHandler) ListNft(ctx contractapi.TransactionContextInterface, listNft exchangeDto.ListNft) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - ListNft-----------")
+
+	// checking dto validate
+	if err := listNft.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - ListNft Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.ListNft(ctx, listNft.SellerWalletId, listNft.NftTokenId, listNft.PriceTokenId, listNft.Price, listNft.Expiry)
+}
+
+func (e *ExchangeHandler) CancelListing(ctx contractapi.TransactionContextInterface, cancelListing exchangeDto.CancelListing) error {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - CancelListing-----------")
+
+	// checking dto validate
+	if err := cancelListing.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - CancelListing Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.CancelListing(ctx, cancelListing.ListingId, cancelListing.SellerWalletId)
+}
+
+func (e *ExchangeHandler) BuyListing(ctx contractapi.TransactionContextInterface, buyListing exchangeDto.BuyListing) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - BuyListing-----------")
+
+	// checking dto validate
+	if err := buyListing.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - BuyListing Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.BuyListing(ctx, buyListing.ListingId, buyListing.BuyerWalletId)
+}
+
+func (e *ExchangeHandler) MakeOffer(ctx contractapi.TransactionContextInterface, makeOffer exchangeDto.MakeOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - MakeOffer-----------")
+
+	// checking dto validate
+	if err := makeOffer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - MakeOffer Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.MakeOffer(ctx, makeOffer.BuyerWalletId, makeOffer.NftTokenId, makeOffer.PriceTokenId, makeOffer.Price, makeOffer.Expiry)
+}
+
+func (e *ExchangeHandler) CancelOffer(ctx contractapi.TransactionContextInterface, cancelOffer exchangeDto.CancelOffer) error {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - CancelOffer-----------")
+
+	// checking dto validate
+	if err := cancelOffer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - CancelOffer Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.CancelOffer(ctx, cancelOffer.OfferId, cancelOffer.BuyerWalletId)
+}
+
+func (e *ExchangeHandler) AcceptOffer(ctx contractapi.TransactionContextInterface, acceptOffer exchangeDto.AcceptOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - AcceptOffer-----------")
+
+	// checking dto validate
+	if err := acceptOffer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - AcceptOffer Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.AcceptOffer(ctx, acceptOffer.OfferId, acceptOffer.SellerWalletId)
+}
+
+func (e *ExchangeHandler) GetListings(ctx contractapi.TransactionContextInterface, queryListing exchangeDto.QueryListing) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - GetListings-----------")
+
+	// checking dto validate
+	if err := queryListing.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - GetListings Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.GetListings(ctx, queryListing.NftTokenId, queryListing.SellerWalletId)
+}
+
+func (e *ExchangeHandler) GetOffers(ctx contractapi.TransactionContextInterface, queryOffer exchangeDto.QueryOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Handler - GetOffers-----------")
+
+	// checking dto validate
+	if err := queryOffer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Handler - GetOffers Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return e.exchangeService.GetOffers(ctx, queryOffer.NftTokenId)
+}
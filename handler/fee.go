@@ -0,0 +1,77 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/fee"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type FeeHandler struct {
+	feeService services.Fee
+}
+
+func NewFeeHandler() *FeeHandler {
+	return &FeeHandler{feeService: fee.NewFeeService()}
+}
+
+// SetFeeSchedule to create or update the fee schedule of a transaction type of a token.
+func (f *FeeHandler) SetFeeSchedule(ctx contractapi.TransactionContextInterface, feeSchedule tokenDto.FeeSchedule) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Handler - SetFeeSchedule-----------")
+
+	// checking dto validate
+	if err := feeSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FeeHandler - SetFeeSchedule Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return f.feeService.SetFeeSchedule(ctx, feeSchedule)
+}
+
+// GetFeeSchedule return the fee schedule of a transaction type of a token.
+func (f *FeeHandler) GetFeeSchedule(ctx contractapi.TransactionContextInterface, queryFeeSchedule tokenDto.QueryFeeSchedule) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Handler - GetFeeSchedule-----------")
+
+	// checking dto validate
+	if err := queryFeeSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FeeHandler - GetFeeSchedule Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return f.feeService.GetFeeSchedule(ctx, queryFeeSchedule.TokenId, queryFeeSchedule.TxType)
+}
+
+// QuoteFee return the fee of a transaction before it is submitted.
+func (f *FeeHandler) QuoteFee(ctx contractapi.TransactionContextInterface, quoteFee tokenDto.QuoteFee) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Handler - QuoteFee-----------")
+
+	// checking dto validate
+	if err := quoteFee.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FeeHandler - QuoteFee Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return f.feeService.QuoteFee(ctx, quoteFee.TokenId, quoteFee.TxType, quoteFee.Amount)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/htlc"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type HtlcHandler struct {
+	htlcService services.Htlc
+}
+
+func NewHtlcHandler() *HtlcHandler {
+	return &HtlcHandler{htlcService: htlc.NewHtlcService()}
+}
+
+// LockTransfer to hold amount for the receiver under a hash lock until timeout.
+func (h *HtlcHandler) LockTransfer(ctx contractapi.TransactionContextInterface, lockTransfer tokenDto.LockTransfer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Handler - LockTransfer-----------")
+
+	// checking dto validate
+	if err := lockTransfer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "HtlcHandler - LockTransfer Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return h.htlcService.LockTransfer(ctx, lockTransfer.FromWalletId, lockTransfer.ToWalletId, lockTransfer.TokenId,
+		lockTransfer.Amount, lockTransfer.HashLock, lockTransfer.Timeout)
+}
+
+// ClaimTransfer to settle a locked transfer to the receiver with the preimage.
+func (h *HtlcHandler) ClaimTransfer(ctx contractapi.TransactionContextInterface, claimTransfer tokenDto.ClaimTransfer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Handler - ClaimTransfer-----------")
+
+	// checking dto validate
+	if err := claimTransfer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "HtlcHandler - ClaimTransfer Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return h.htlcService.ClaimTransfer(ctx, claimTransfer.LockId, claimTransfer.Preimage)
+}
+
+// RefundTransfer to settle a locked transfer back to the sender after the timeout.
+func (h *HtlcHandler) RefundTransfer(ctx contractapi.TransactionContextInterface, refundTransfer tokenDto.RefundTransfer) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Handler - RefundTransfer-----------")
+
+	// checking dto validate
+	if err := refundTransfer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "HtlcHandler - RefundTransfer Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return h.htlcService.RefundTransfer(ctx, refundTransfer.LockId)
+}
+
+// GetLock return the hashed time-locked transfer.
+func (h *HtlcHandler) GetLock(ctx contractapi.TransactionContextInterface, queryLock tokenDto.QueryLock) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Handler - GetLock-----------")
+
+	// checking dto validate
+	if err := queryLock.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "HtlcHandler - GetLock Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return h.htlcService.GetLock(ctx, queryLock.LockId)
+}
@@ -59,7 +59,8 @@ func (i IaoHandler) CreateIao(ctx contractapi.TransactionContextInterface, asset
 		return "", helper.RespError(errorcode.InvalidParam)
 	}
 
-	return i.iaoService.CreateIao(ctx, assetIao.AssetId, assetIao.AssetTokenAmount, assetIao.StartDate, assetIao.EndDate, assetIao.Rate)
+	return i.iaoService.CreateIao(ctx, assetIao.AssetId, assetIao.AssetTokenAmount, assetIao.StartDate, assetIao.EndDate, assetIao.Rate, assetIao.VestingPlan(),
+		assetIao.Allocation, assetIao.AllocationTier, assetIao.Limit(), assetIao.Tiers(), assetIao.IaoPrices())
 }
 
 func (i IaoHandler) BuyBatchAsset(ctx contractapi.TransactionContextInterface, batchAsset iaoDto.BuyBatchAsset) (string, error) {
@@ -74,6 +75,66 @@ func (i IaoHandler) BuyBatchAsset(ctx contractapi.TransactionContextInterface, b
 	return i.iaoService.BuyBatchAsset(ctx, batchAsset.Requests)
 }
 
+func (i IaoHandler) AllocateIao(ctx contractapi.TransactionContextInterface, allocateIao iaoDto.AllocateIao) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Handler - AllocateIao-----------")
+
+	// checking dto validate
+	if err := allocateIao.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "IaoHandler - AllocateIao Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return i.iaoService.AllocateIao(ctx, allocateIao.IaoId)
+}
+
+func (i IaoHandler) GetIaoStatus(ctx contractapi.TransactionContextInterface, queryIao iaoDto.QueryIao) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Handler - GetIaoStatus-----------")
+
+	// checking dto validate
+	if err := queryIao.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "IaoHandler - GetIaoStatus Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return i.iaoService.GetIaoStatus(ctx, queryIao.IaoId)
+}
+
+func (i IaoHandler) AddEligibility(ctx contractapi.TransactionContextInterface, addEligibility iaoDto.AddEligibility) error {
+	glogger.GetInstance().Info(ctx, "-----------Iao Handler - AddEligibility-----------")
+
+	// checking dto validate
+	if err := addEligibility.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "IaoHandler - AddEligibility Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return i.iaoService.AddEligibility(ctx, addEligibility.IaoId, addEligibility.Entries)
+}
+
+func (i IaoHandler) RemoveEligibility(ctx contractapi.TransactionContextInterface, removeEligibility iaoDto.RemoveEligibility) error {
+	glogger.GetInstance().Info(ctx, "-----------Iao Handler - RemoveEligibility-----------")
+
+	// checking dto validate
+	if err := removeEligibility.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "IaoHandler - RemoveEligibility Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return i.iaoService.RemoveEligibility(ctx, removeEligibility.IaoId, removeEligibility.WalletIds)
+}
+
+func (i IaoHandler) GetEligibility(ctx contractapi.TransactionContextInterface, queryEligibility iaoDto.QueryEligibility) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Handler - GetEligibility-----------")
+
+	// checking dto validate
+	if err := queryEligibility.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "IaoHandler - GetEligibility Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return i.iaoService.GetEligibility(ctx, queryEligibility.IaoId, queryEligibility.WalletId, queryEligibility.LastWalletId)
+}
+
 func (i IaoHandler) UpdateStatusIao(ctx contractapi.TransactionContextInterface, updateIao iaoDto.UpdateIao) error {
 	glogger.GetInstance().Info(ctx, "-----------Iao Handler - UpdateStatusIao-----------")
 
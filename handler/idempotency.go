@@ -0,0 +1,85 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/idempotency"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// idempotentInput is a write dto embedding dto.Idempotency
+type idempotentInput interface {
+	GetRequestId() string
+}
+
+type IdempotencyHandler struct {
+	idempotencyService services.Idempotency
+}
+
+func NewIdempotencyHandler() *IdempotencyHandler {
+	return &IdempotencyHandler{idempotencyService: idempotency.NewIdempotencyService()}
+}
+
+// Execute run the write operation once per request id of the input. A retry returns the stored result.
+// The input without request id is always written.
+func (i *IdempotencyHandler) Execute(ctx contractapi.TransactionContextInterface, operation string, input idempotentInput,
+	write func() (string, error)) (string, error) {
+	requestId := input.GetRequestId()
+	if requestId == "" {
+		return write()
+	}
+
+	payload, _ := json.Marshal(input)
+	hash := helper.CalculateHash(operation + string(payload))
+	result, isReplay, err := i.idempotencyService.Load(ctx, requestId, operation, hash)
+	if err != nil || isReplay {
+		return result, err
+	}
+
+	result, err = write()
+	if err != nil {
+		return "", err
+	}
+
+	if err := i.idempotencyService.Save(ctx, requestId, operation, hash, result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// ExecuteNoResult run the write operation without result once per request id of the input.
+func (i *IdempotencyHandler) ExecuteNoResult(ctx contractapi.TransactionContextInterface, operation string, input idempotentInput,
+	write func() error) error {
+	_, err := i.Execute(ctx, operation, input, func() (string, error) {
+		return "", write()
+	})
+	return err
+}
+
+// Cleanup to delete the expired results of request ids.
+func (i *IdempotencyHandler) Cleanup(ctx contractapi.TransactionContextInterface) (int, error) {
+	glogger.GetInstance().Info(ctx, "-----------Idempotency Handler - Cleanup-----------")
+
+	return i.idempotencyService.Cleanup(ctx)
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/metatx"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type MetaTxHandler struct {
+	metaTxService services.MetaTx
+}
+
+func NewMetaTxHandler() *MetaTxHandler {
+	return &MetaTxHandler{metaTxService: metatx.NewMetaTxService()}
+}
+
+// RegisterWalletKey to set the public key verifying signed transfers of a wallet.
+func (m *MetaTxHandler) RegisterWalletKey(ctx contractapi.TransactionContextInterface, walletKey tokenDto.WalletKey) error {
+	glogger.GetInstance().Info(ctx, "-----------MetaTx Handler - RegisterWalletKey-----------")
+
+	// checking dto validate
+	if err := walletKey.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MetaTxHandler - RegisterWalletKey Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return m.metaTxService.RegisterWalletKey(ctx, walletKey.WalletId, walletKey.PublicKey)
+}
+
+// SubmitSignedTransfer to submit a transfer signed off-chain by the key of the from wallet.
+func (m *MetaTxHandler) SubmitSignedTransfer(ctx contractapi.TransactionContextInterface, signedTransfer tokenDto.SignedTransfer) error {
+	glogger.GetInstance().Info(ctx, "-----------MetaTx Handler - SubmitSignedTransfer-----------")
+
+	// checking dto validate
+	if err := signedTransfer.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MetaTxHandler - SubmitSignedTransfer Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return m.metaTxService.SubmitSignedTransfer(ctx, signedTransfer)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/multisig"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type MultiSigHandler struct {
+	multiSigService services.MultiSig
+}
+
+func NewMultiSigHandler() *MultiSigHandler {
+	return &MultiSigHandler{multiSigService: multisig.NewMultiSigService()}
+}
+
+// SignIntent to sign an open intent of a multi-signature wallet.
+func (m *MultiSigHandler) SignIntent(ctx contractapi.TransactionContextInterface, signIntent tokenDto.SignIntent) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Handler - SignIntent-----------")
+
+	// checking dto validate
+	if err := signIntent.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MultiSigHandler - SignIntent Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return m.multiSigService.SignIntent(ctx, signIntent.IntentId)
+}
+
+// CancelIntent to cancel an open intent of a multi-signature wallet.
+func (m *MultiSigHandler) CancelIntent(ctx contractapi.TransactionContextInterface, signIntent tokenDto.SignIntent) error {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Handler - CancelIntent-----------")
+
+	// checking dto validate
+	if err := signIntent.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MultiSigHandler - CancelIntent Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return m.multiSigService.CancelIntent(ctx, signIntent.IntentId)
+}
+
+// GetOpenIntents return open intents of a multi-signature wallet.
+func (m *MultiSigHandler) GetOpenIntents(ctx contractapi.TransactionContextInterface, queryIntent tokenDto.QueryIntent) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Handler - GetOpenIntents-----------")
+
+	// checking dto validate
+	if err := queryIntent.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MultiSigHandler - GetOpenIntents Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return m.multiSigService.GetOpenIntents(ctx, queryIntent.WalletId)
+}
@@ -46,7 +46,8 @@ func (n *NftHandler) Mint(ctx contractapi.TransactionContextInterface, mintNFT n
 		return "", helper.RespError(errorcode.InvalidParam)
 	}
 
-	return n.nftService.Mint(ctx, mintNFT.GS1Number, mintNFT.OwnerWalletId, mintNFT.Metadata, mintNFT.HashData)
+	return n.nftService.Mint(ctx, mintNFT.GS1Number, mintNFT.OwnerWalletId, mintNFT.Metadata, mintNFT.HashData,
+		mintNFT.RoyaltyWalletId, mintNFT.RoyaltyBps)
 }
 
 func (n *NftHandler) OwnerOf(ctx contractapi.TransactionContextInterface, ownerNFT nft2.OwnerNFT) (string, error) {
@@ -84,3 +85,40 @@ func (n *NftHandler) TransferNFT(ctx contractapi.TransactionContextInterface, tr
 
 	return n.nftService.TransferFrom(ctx, transferNFT.FromWalletId, transferNFT.ToWalletId, transferNFT.FromTokenId, transferNFT.NftTokenId, transferNFT.Price)
 }
+
+func (n *NftHandler) RoyaltyInfo(ctx contractapi.TransactionContextInterface, royaltyInfo nft2.RoyaltyInfoNFT) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------NFT Handler - RoyaltyInfo-----------")
+
+	// checking dto validate
+	if err := royaltyInfo.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NFT Handler - RoyaltyInfo Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return n.nftService.RoyaltyInfo(ctx, royaltyInfo.NftTokenId, royaltyInfo.Price)
+}
+
+func (n *NftHandler) FractionalizeNft(ctx contractapi.TransactionContextInterface, fractionalizeNFT nft2.FractionalizeNFT) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------NFT Handler - FractionalizeNft-----------")
+
+	// checking dto validate
+	if err := fractionalizeNFT.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NFT Handler - FractionalizeNft Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return n.nftService.FractionalizeNft(ctx, fractionalizeNFT.NftTokenId, fractionalizeNFT.TokenName,
+		fractionalizeNFT.TickerToken, fractionalizeNFT.Shares)
+}
+
+func (n *NftHandler) RedeemNft(ctx contractapi.TransactionContextInterface, redeemNFT nft2.RedeemNFT) error {
+	glogger.GetInstance().Info(ctx, "-----------NFT Handler - RedeemNft-----------")
+
+	// checking dto validate
+	if err := redeemNFT.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NFT Handler - RedeemNft Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return n.nftService.RedeemNft(ctx, redeemNFT.NftTokenId, redeemNFT.RedeemerWalletId)
+}
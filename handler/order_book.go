@@ -0,0 +1,99 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/order_book"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type OrderBookHandler struct {
+	orderBookService services.OrderBook
+}
+
+func NewOrderBookHandler() OrderBookHandler {
+	return OrderBookHandler{order_book.NewOrderBookService()}
+}
+
+func (o *OrderBookHandler) PlaceLimitOrder(ctx contractapi.TransactionContextInterface, placeOrder exchangeDto.PlaceLimitOrder) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Handler - PlaceLimitOrder-----------")
+
+	// checking dto validate
+	if err := placeOrder.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "OrderBook Handler - PlaceLimitOrder Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return o.orderBookService.PlaceLimitOrder(ctx, placeOrder.WalletId, placeOrder.BaseTokenId, placeOrder.QuoteTokenId,
+		placeOrder.Side, placeOrder.Amount, placeOrder.Price)
+}
+
+func (o *OrderBookHandler) CancelOrder(ctx contractapi.TransactionContextInterface, cancelOrder exchangeDto.CancelOrder) error {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Handler - CancelOrder-----------")
+
+	// checking dto validate
+	if err := cancelOrder.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "OrderBook Handler - CancelOrder Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return o.orderBookService.CancelOrder(ctx, cancelOrder.OrderId, cancelOrder.WalletId)
+}
+
+func (o *OrderBookHandler) MatchOrders(ctx contractapi.TransactionContextInterface, batchOrders exchangeDto.MatchBatchOrders) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Handler - MatchOrders-----------")
+
+	// checking dto validate
+	if err := batchOrders.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "OrderBook Handler - MatchOrders Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return o.orderBookService.MatchOrders(ctx, batchOrders.Requests)
+}
+
+func (o *OrderBookHandler) GetDepth(ctx contractapi.TransactionContextInterface, queryDepth exchangeDto.QueryDepth) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Handler - GetDepth-----------")
+
+	// checking dto validate
+	if err := queryDepth.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "OrderBook Handler - GetDepth Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return o.orderBookService.GetDepth(ctx, queryDepth.BaseTokenId, queryDepth.QuoteTokenId)
+}
+
+func (o *OrderBookHandler) GetOrderHistory(ctx contractapi.TransactionContextInterface, queryHistory exchangeDto.QueryOrderHistory) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Handler - GetOrderHistory-----------")
+
+	// checking dto validate
+	if err := queryHistory.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "OrderBook Handler - GetOrderHistory Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return o.orderBookService.GetOrderHistory(ctx, queryHistory.WalletId)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/pool"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type PoolHandler struct {
+	poolService services.Pool
+}
+
+func NewPoolHandler() PoolHandler {
+	return PoolHandler{pool.NewPoolService()}
+}
+
+func (p *PoolHandler) CreatePool(ctx contractapi.TransactionContextInterface, createPool exchangeDto.CreatePool) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Handler - CreatePool-----------")
+
+	// checking dto validate
+	if err := createPool.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Handler - CreatePool Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.poolService.CreatePool(ctx, createPool.TokenA, createPool.TokenB, createPool.FeeBps)
+}
+
+func (p *PoolHandler) AddLiquidity(ctx contractapi.TransactionContextInterface, addLiquidity exchangeDto.AddLiquidity) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Handler - AddLiquidity-----------")
+
+	// checking dto validate
+	if err := addLiquidity.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Handler - AddLiquidity Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.poolService.AddLiquidity(ctx, addLiquidity.PoolId, addLiquidity.WalletId, addLiquidity.AmountA, addLiquidity.AmountB)
+}
+
+func (p *PoolHandler) RemoveLiquidity(ctx contractapi.TransactionContextInterface, removeLiquidity exchangeDto.RemoveLiquidity) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Handler - RemoveLiquidity-----------")
+
+	// checking dto validate
+	if err := removeLiquidity.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Handler - RemoveLiquidity Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.poolService.RemoveLiquidity(ctx, removeLiquidity.PoolId, removeLiquidity.WalletId, removeLiquidity.Liquidity)
+}
+
+func (p *PoolHandler) SwapExactIn(ctx contractapi.TransactionContextInterface, swapExactIn exchangeDto.SwapExactIn) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Handler - SwapExactIn-----------")
+
+	// checking dto validate
+	if err := swapExactIn.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Handler - SwapExactIn Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.poolService.SwapExactIn(ctx, swapExactIn.PoolId, swapExactIn.WalletId, swapExactIn.FromTokenId,
+		swapExactIn.AmountIn, swapExactIn.MinAmountOut)
+}
+
+func (p *PoolHandler) GetPoolState(ctx contractapi.TransactionContextInterface, queryPool exchangeDto.QueryPool) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Handler - GetPoolState-----------")
+
+	// checking dto validate
+	if err := queryPool.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Handler - GetPoolState Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.poolService.GetPoolState(ctx, queryPool.PoolId, queryPool.FromTokenId, queryPool.AmountIn)
+}
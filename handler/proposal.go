@@ -0,0 +1,267 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	"encoding/json"
+	iaoDto "github.com/Akachain/gringotts/dto/iao"
+	proposalDto "github.com/Akachain/gringotts/dto/proposal"
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	glossaryProposal "github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/proposal"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type ProposalHandler struct {
+	proposalService services.Proposal
+	tokenHandler    *TokenHandler
+	iaoHandler      IaoHandler
+}
+
+func NewProposalHandler() *ProposalHandler {
+	return &ProposalHandler{
+		proposalService: proposal.NewProposalService(),
+		tokenHandler:    NewTokenHandler(),
+		iaoHandler:      NewIaoHandler(),
+	}
+}
+
+// SetApprovalPolicy to set approvers and threshold of an operation.
+func (p *ProposalHandler) SetApprovalPolicy(ctx contractapi.TransactionContextInterface, policy proposalDto.ApprovalPolicy) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - SetApprovalPolicy-----------")
+
+	// checking dto validate
+	if err := policy.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - SetApprovalPolicy Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.proposalService.SetApprovalPolicy(ctx, policy.Operation, policy.TokenId, policy.Approvers, policy.Threshold, policy.Ttl)
+}
+
+// GetApprovalPolicy return the approval policy of an operation.
+func (p *ProposalHandler) GetApprovalPolicy(ctx contractapi.TransactionContextInterface, query proposalDto.QueryApprovalPolicy) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - GetApprovalPolicy-----------")
+
+	// checking dto validate
+	if err := query.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - GetApprovalPolicy Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.proposalService.GetApprovalPolicy(ctx, query.Operation, query.TokenId)
+}
+
+// CheckApproval return error when an operation of a token can not be called without a proposal.
+func (p *ProposalHandler) CheckApproval(ctx contractapi.TransactionContextInterface, operation glossaryProposal.Operation, tokenId string) error {
+	return p.proposalService.CheckApproval(ctx, operation, tokenId)
+}
+
+// CreateProposal to propose an operation, its payload is validated the same as the operation.
+func (p *ProposalHandler) CreateProposal(ctx contractapi.TransactionContextInterface, createProposal proposalDto.CreateProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - CreateProposal-----------")
+
+	// checking dto validate
+	if err := createProposal.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - CreateProposal Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	policyOperation, tokenId, err := policyOfPayload(createProposal.Operation, createProposal.Payload)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - CreateProposal Payload invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.proposalService.CreateProposal(ctx, createProposal.Operation, policyOperation, tokenId, createProposal.Payload)
+}
+
+// ApproveProposal to approve a proposal, the operation is executed when the threshold is reached.
+// It returns the result of the operation once it is executed.
+func (p *ProposalHandler) ApproveProposal(ctx contractapi.TransactionContextInterface, vote proposalDto.VoteProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - ApproveProposal-----------")
+
+	// checking dto validate
+	if err := vote.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - ApproveProposal Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	proposalEntity, err := p.proposalService.ApproveProposal(ctx, vote.ProposalId)
+	if err != nil {
+		return "", err
+	}
+
+	if proposalEntity.Status != glossaryProposal.Approved {
+		return "", nil
+	}
+
+	// the approval is not kept when the operation fails, the proposal stays pending
+	result, err := p.execute(ctx, proposalEntity.Operation, proposalEntity.Payload)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - Execute proposal (%s) failed with error (%v)", proposalEntity.Id, err)
+		return "", err
+	}
+
+	if err := p.proposalService.CompleteProposal(ctx, proposalEntity, result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// RejectProposal to reject a proposal.
+func (p *ProposalHandler) RejectProposal(ctx contractapi.TransactionContextInterface, vote proposalDto.VoteProposal) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - RejectProposal-----------")
+
+	// checking dto validate
+	if err := vote.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - RejectProposal Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.proposalService.RejectProposal(ctx, vote.ProposalId)
+}
+
+// GetProposal return the proposal and its status.
+func (p *ProposalHandler) GetProposal(ctx contractapi.TransactionContextInterface, query proposalDto.VoteProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Handler - GetProposal-----------")
+
+	// checking dto validate
+	if err := query.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposalHandler - GetProposal Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return p.proposalService.GetProposal(ctx, query.ProposalId)
+}
+
+// execute run the operation of an approved proposal through its handler
+func (p *ProposalHandler) execute(ctx contractapi.TransactionContextInterface, operation glossaryProposal.Operation, payload string) (string, error) {
+	switch operation {
+	case glossaryProposal.Mint:
+		var mintDto tokenDto.MintToken
+		if err := json.Unmarshal([]byte(payload), &mintDto); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return "", p.tokenHandler.Mint(ctx, mintDto)
+	case glossaryProposal.MintBatch:
+		var mintBatch tokenDto.MintBatch
+		if err := json.Unmarshal([]byte(payload), &mintBatch); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return p.tokenHandler.MintBatch(ctx, mintBatch)
+	case glossaryProposal.Burn:
+		var burnDto tokenDto.BurnToken
+		if err := json.Unmarshal([]byte(payload), &burnDto); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return "", p.tokenHandler.Burn(ctx, burnDto)
+	case glossaryProposal.CreateTokenType:
+		var createTokenTypeDto tokenDto.CreateTokenType
+		if err := json.Unmarshal([]byte(payload), &createTokenTypeDto); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return p.tokenHandler.CreateTokenType(ctx, createTokenTypeDto)
+	case glossaryProposal.UpdateStatusIao:
+		var updateIao iaoDto.UpdateIao
+		if err := json.Unmarshal([]byte(payload), &updateIao); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return "", p.iaoHandler.UpdateStatusIao(ctx, updateIao)
+	case glossaryProposal.FinalizeIao:
+		var finishIao iaoDto.FinishIao
+		if err := json.Unmarshal([]byte(payload), &finishIao); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return "", p.iaoHandler.FinalizeIao(ctx, finishIao)
+	case glossaryProposal.CancelIao:
+		var finishIao iaoDto.FinishIao
+		if err := json.Unmarshal([]byte(payload), &finishIao); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return "", p.iaoHandler.CancelIao(ctx, finishIao)
+	case glossaryProposal.AllocateIao:
+		var allocateIao iaoDto.AllocateIao
+		if err := json.Unmarshal([]byte(payload), &allocateIao); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		return p.iaoHandler.AllocateIao(ctx, allocateIao)
+	case glossaryProposal.SetApprovalPolicy:
+		var policy proposalDto.ApprovalPolicy
+		if err := json.Unmarshal([]byte(payload), &policy); err != nil {
+			return "", helper.RespError(errorcode.BizUnableParse)
+		}
+		if err := policy.IsValid(); err != nil {
+			return "", helper.RespError(errorcode.InvalidParam)
+		}
+		return "", p.proposalService.ApplyApprovalPolicy(ctx, policy.Operation, policy.TokenId, policy.Approvers, policy.Threshold, policy.Ttl)
+	}
+	return "", helper.RespError(errorcode.InvalidParam)
+}
+
+// policyOfPayload validate the payload of an operation and return the operation and token of the approval policy
+// the proposal is approved under. Creating a token type and the iao operations are not bound to a token,
+// a batch mint is approved under the mint policy and a change of approval policy under the policy it changes
+func policyOfPayload(operation glossaryProposal.Operation, payload string) (glossaryProposal.Operation, string, error) {
+	var dto interface{ IsValid() error }
+	tokenId := func() string { return "" }
+	policyOperation := func() glossaryProposal.Operation { return operation }
+
+	switch operation {
+	case glossaryProposal.Mint:
+		mintDto := new(tokenDto.MintToken)
+		dto, tokenId = mintDto, func() string { return mintDto.TokenId }
+	case glossaryProposal.MintBatch:
+		mintBatch := new(tokenDto.MintBatch)
+		dto, tokenId = mintBatch, func() string { return mintBatch.TokenId }
+		policyOperation = func() glossaryProposal.Operation { return glossaryProposal.Mint }
+	case glossaryProposal.Burn:
+		burnDto := new(tokenDto.BurnToken)
+		dto, tokenId = burnDto, func() string { return burnDto.TokenId }
+	case glossaryProposal.CreateTokenType:
+		dto = new(tokenDto.CreateTokenType)
+	case glossaryProposal.UpdateStatusIao:
+		dto = new(iaoDto.UpdateIao)
+	case glossaryProposal.FinalizeIao, glossaryProposal.CancelIao:
+		dto = new(iaoDto.FinishIao)
+	case glossaryProposal.AllocateIao:
+		dto = new(iaoDto.AllocateIao)
+	case glossaryProposal.SetApprovalPolicy:
+		policy := new(proposalDto.ApprovalPolicy)
+		dto, tokenId = policy, func() string { return policy.TokenId }
+		policyOperation = func() glossaryProposal.Operation { return policy.Operation }
+	default:
+		return "", "", errors.New("operation is invalid")
+	}
+
+	if err := json.Unmarshal([]byte(payload), dto); err != nil {
+		return "", "", errors.WithMessage(err, "payload is invalid")
+	}
+
+	if err := dto.IsValid(); err != nil {
+		return "", "", err
+	}
+	return policyOperation(), tokenId(), nil
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/schedule"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type ScheduleHandler struct {
+	scheduleService services.Schedule
+}
+
+func NewScheduleHandler() *ScheduleHandler {
+	return &ScheduleHandler{scheduleService: schedule.NewScheduleService()}
+}
+
+// CreateSchedule to create a scheduled or recurring transfer.
+func (s *ScheduleHandler) CreateSchedule(ctx contractapi.TransactionContextInterface, createSchedule tokenDto.CreateSchedule) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - CreateSchedule-----------")
+
+	// checking dto validate
+	if err := createSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScheduleHandler - CreateSchedule Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.scheduleService.CreateSchedule(ctx, createSchedule.FromWalletId, createSchedule.ToWalletId, createSchedule.TokenId,
+		createSchedule.Amount, createSchedule.StartTime, createSchedule.Interval, createSchedule.EndTime, createSchedule.Count)
+}
+
+// PauseSchedule to pause an active schedule.
+func (s *ScheduleHandler) PauseSchedule(ctx contractapi.TransactionContextInterface, updateSchedule tokenDto.UpdateSchedule) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - PauseSchedule-----------")
+
+	// checking dto validate
+	if err := updateSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScheduleHandler - PauseSchedule Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.scheduleService.PauseSchedule(ctx, updateSchedule.WalletId, updateSchedule.ScheduleId)
+}
+
+// ResumeSchedule to resume a paused schedule.
+func (s *ScheduleHandler) ResumeSchedule(ctx contractapi.TransactionContextInterface, updateSchedule tokenDto.UpdateSchedule) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - ResumeSchedule-----------")
+
+	// checking dto validate
+	if err := updateSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScheduleHandler - ResumeSchedule Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.scheduleService.ResumeSchedule(ctx, updateSchedule.WalletId, updateSchedule.ScheduleId)
+}
+
+// CancelSchedule to cancel an active or paused schedule.
+func (s *ScheduleHandler) CancelSchedule(ctx contractapi.TransactionContextInterface, updateSchedule tokenDto.UpdateSchedule) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - CancelSchedule-----------")
+
+	// checking dto validate
+	if err := updateSchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScheduleHandler - CancelSchedule Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.scheduleService.CancelSchedule(ctx, updateSchedule.WalletId, updateSchedule.ScheduleId)
+}
+
+// GetSchedules return the schedules from a wallet.
+func (s *ScheduleHandler) GetSchedules(ctx contractapi.TransactionContextInterface, querySchedule tokenDto.QuerySchedule) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - GetSchedules-----------")
+
+	// checking dto validate
+	if err := querySchedule.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScheduleHandler - GetSchedules Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.scheduleService.GetSchedules(ctx, querySchedule.WalletId)
+}
+
+// ExecuteDueSchedules to write the pending transfers of due schedules.
+func (s *ScheduleHandler) ExecuteDueSchedules(ctx contractapi.TransactionContextInterface) (int, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Handler - ExecuteDueSchedules-----------")
+	return s.scheduleService.ExecuteDueSchedules(ctx)
+}
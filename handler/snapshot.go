@@ -0,0 +1,117 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/snapshot"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type SnapshotHandler struct {
+	snapshotService services.Snapshot
+}
+
+func NewSnapshotHandler() *SnapshotHandler {
+	return &SnapshotHandler{snapshotService: snapshot.NewSnapshotService()}
+}
+
+// CreateSnapshot to start a snapshot of the holder balances of a token.
+func (s *SnapshotHandler) CreateSnapshot(ctx contractapi.TransactionContextInterface, createSnapshot tokenDto.CreateSnapshot) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - CreateSnapshot-----------")
+
+	// checking dto validate
+	if err := createSnapshot.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - CreateSnapshot Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.CreateSnapshot(ctx, createSnapshot.TokenId)
+}
+
+// ScanSnapshot to scan the next page of holders of a snapshot.
+func (s *SnapshotHandler) ScanSnapshot(ctx contractapi.TransactionContextInterface, scanSnapshot tokenDto.ScanSnapshot) error {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - ScanSnapshot-----------")
+
+	// checking dto validate
+	if err := scanSnapshot.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - ScanSnapshot Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.ScanSnapshot(ctx, scanSnapshot.SnapshotId)
+}
+
+// GetSnapshot return the snapshot.
+func (s *SnapshotHandler) GetSnapshot(ctx contractapi.TransactionContextInterface, querySnapshot tokenDto.QuerySnapshot) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - GetSnapshot-----------")
+
+	// checking dto validate
+	if err := querySnapshot.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - GetSnapshot Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.GetSnapshot(ctx, querySnapshot.SnapshotId)
+}
+
+// DistributeProRata to distribute an amount to the holders of a snapshot.
+func (s *SnapshotHandler) DistributeProRata(ctx contractapi.TransactionContextInterface, distributeDto tokenDto.DistributeProRata) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - DistributeProRata-----------")
+
+	// checking dto validate
+	if err := distributeDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - DistributeProRata Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.DistributeProRata(ctx, distributeDto.SnapshotId, distributeDto.FromWalletId, distributeDto.PayoutTokenId,
+		distributeDto.TotalAmount, distributeDto.RemainderWalletId)
+}
+
+// ContinueDistribution to pay the next page of holders of a distribution.
+func (s *SnapshotHandler) ContinueDistribution(ctx contractapi.TransactionContextInterface, continueDto tokenDto.ContinueDistribution) error {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - ContinueDistribution-----------")
+
+	// checking dto validate
+	if err := continueDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - ContinueDistribution Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.ContinueDistribution(ctx, continueDto.DistributionId)
+}
+
+// GetDistribution return the distribution.
+func (s *SnapshotHandler) GetDistribution(ctx contractapi.TransactionContextInterface, queryDistribution tokenDto.QueryDistribution) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Handler - GetDistribution-----------")
+
+	// checking dto validate
+	if err := queryDistribution.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SnapshotHandler - GetDistribution Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.snapshotService.GetDistribution(ctx, queryDistribution.DistributionId)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/swap"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type SwapHandler struct {
+	swapService services.Swap
+}
+
+func NewSwapHandler() *SwapHandler {
+	swapService := swap.NewSwapService()
+	return &SwapHandler{swapService: swapService}
+}
+
+// ProposeSwap to propose a swap between two wallets.
+func (s *SwapHandler) ProposeSwap(ctx contractapi.TransactionContextInterface, proposeSwap tokenDto.ProposeSwap) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Handler - ProposeSwap-----------")
+
+	// checking dto validate
+	if err := proposeSwap.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SwapHandler - ProposeSwap Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.swapService.ProposeSwap(ctx, proposeSwap.MakerWalletId, proposeSwap.TakerWalletId, proposeSwap.MakerTokenId,
+		proposeSwap.TakerTokenId, proposeSwap.MakerAmount, proposeSwap.TakerAmount, proposeSwap.Expiry)
+}
+
+// AcceptSwap to accept a swap by the taker.
+func (s *SwapHandler) AcceptSwap(ctx contractapi.TransactionContextInterface, acceptSwap tokenDto.AcceptSwap) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Handler - AcceptSwap-----------")
+
+	// checking dto validate
+	if err := acceptSwap.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SwapHandler - AcceptSwap Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.swapService.AcceptSwap(ctx, acceptSwap.SwapId, acceptSwap.TakerWalletId)
+}
+
+// CancelSwap to cancel an open swap.
+func (s *SwapHandler) CancelSwap(ctx contractapi.TransactionContextInterface, cancelSwap tokenDto.CancelSwap) error {
+	glogger.GetInstance().Info(ctx, "-----------Swap Handler - CancelSwap-----------")
+
+	// checking dto validate
+	if err := cancelSwap.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SwapHandler - CancelSwap Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.swapService.CancelSwap(ctx, cancelSwap.SwapId, cancelSwap.WalletId)
+}
+
+// GetSwap return the swap and its status.
+func (s *SwapHandler) GetSwap(ctx contractapi.TransactionContextInterface, querySwap tokenDto.QuerySwap) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Handler - GetSwap-----------")
+
+	// checking dto validate
+	if err := querySwap.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SwapHandler - GetSwap Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return s.swapService.GetSwap(ctx, querySwap.SwapId)
+}
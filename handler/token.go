@@ -48,7 +48,7 @@ func (t *TokenHandler) Transfer(ctx contractapi.TransactionContextInterface, tra
 		return helper.RespError(errorcode.InvalidParam)
 	}
 
-	if _, err := t.tokenService.Transfer(ctx, transferDto.FromWalletId, transferDto.ToWalletId, transferDto.TokenId, transferDto.Amount); err != nil {
+	if _, err := t.tokenService.Transfer(ctx, transferDto.FromWalletId, transferDto.ToWalletId, transferDto.TokenId, transferDto.Amount, transferDto.Expiry); err != nil {
 		return err
 	}
 
@@ -68,6 +68,32 @@ func (t *TokenHandler) Mint(ctx contractapi.TransactionContextInterface, mintDto
 	return t.tokenService.Mint(ctx, mintDto.WalletId, mintDto.TokenId, mintDto.Amount)
 }
 
+// TransferBatch to transfer token from a wallet to every item of the batch.
+func (t *TokenHandler) TransferBatch(ctx contractapi.TransactionContextInterface, transferBatch tokenDto.TransferBatch) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - TransferBatch-----------")
+
+	// checking dto validate
+	if err := transferBatch.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - TransferBatch Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.TransferBatch(ctx, transferBatch.FromWalletId, transferBatch.TokenId, transferBatch.Items)
+}
+
+// MintBatch generate new token for every item of the batch.
+func (t *TokenHandler) MintBatch(ctx contractapi.TransactionContextInterface, mintBatch tokenDto.MintBatch) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - MintBatch-----------")
+
+	// checking dto validate
+	if err := mintBatch.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - MintBatch Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.MintBatch(ctx, mintBatch.TokenId, mintBatch.Items)
+}
+
 // Burn to burn token existed in the system.
 func (t *TokenHandler) Burn(ctx contractapi.TransactionContextInterface, burnDto tokenDto.BurnToken) error {
 	glogger.GetInstance().Info(ctx, "-----------Token Handler - Burn-----------")
@@ -105,7 +131,7 @@ func (t *TokenHandler) Exchange(ctx contractapi.TransactionContextInterface, exc
 	}
 
 	return t.tokenService.Exchange(ctx, exchangeToken.FromWalletId, exchangeToken.ToWalletId,
-		exchangeToken.FromTokenId, exchangeToken.ToTokenId, exchangeToken.FromTokenAmount, exchangeToken.ToTokenAmount)
+		exchangeToken.FromTokenId, exchangeToken.ToTokenId, exchangeToken.FromTokenAmount, exchangeToken.ToTokenAmount, exchangeToken.Expiry)
 }
 
 // Issue to issue new token type form stable token.
@@ -133,3 +159,94 @@ func (t *TokenHandler) TransferSideChain(ctx contractapi.TransactionContextInter
 
 	return t.tokenService.TransferSideChain(ctx, transferChain.WalletId, transferChain.TokenId, transferChain.FromChain, transferChain.ToChain, transferChain.Amount)
 }
+
+// CancelTransaction to cancel a pending transaction of the spender wallet.
+func (t *TokenHandler) CancelTransaction(ctx contractapi.TransactionContextInterface, cancelTransaction tokenDto.CancelTransaction) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - CancelTransaction-----------")
+
+	// checking dto validate
+	if err := cancelTransaction.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - CancelTransaction Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.CancelTransaction(ctx, cancelTransaction.WalletId, cancelTransaction.TxId)
+}
+
+// Reverse to pay back a confirmed transfer from its receiver to its sender.
+func (t *TokenHandler) Reverse(ctx contractapi.TransactionContextInterface, reverseDto tokenDto.ReverseTransaction) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - Reverse-----------")
+
+	// checking dto validate
+	if err := reverseDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - Reverse Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.Reverse(ctx, reverseDto.TxId, reverseDto.WalletId, reverseDto.Amount)
+}
+
+// PauseToken to block all transactions of a token.
+func (t *TokenHandler) PauseToken(ctx contractapi.TransactionContextInterface, tokenControl tokenDto.TokenControl) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - PauseToken-----------")
+
+	// checking dto validate
+	if err := tokenControl.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - PauseToken Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.PauseToken(ctx, tokenControl.TokenId)
+}
+
+// UnpauseToken to allow transactions of a paused token again.
+func (t *TokenHandler) UnpauseToken(ctx contractapi.TransactionContextInterface, tokenControl tokenDto.TokenControl) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - UnpauseToken-----------")
+
+	// checking dto validate
+	if err := tokenControl.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - UnpauseToken Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.UnpauseToken(ctx, tokenControl.TokenId)
+}
+
+// DeactivateToken to block all transactions of a token for good.
+func (t *TokenHandler) DeactivateToken(ctx contractapi.TransactionContextInterface, tokenControl tokenDto.TokenControl) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - DeactivateToken-----------")
+
+	// checking dto validate
+	if err := tokenControl.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - DeactivateToken Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.DeactivateToken(ctx, tokenControl.TokenId)
+}
+
+// FreezeBalance to block transactions of a token of a wallet.
+func (t *TokenHandler) FreezeBalance(ctx contractapi.TransactionContextInterface, freezeBalance tokenDto.FreezeBalance) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - FreezeBalance-----------")
+
+	// checking dto validate
+	if err := freezeBalance.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - FreezeBalance Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.FreezeBalance(ctx, freezeBalance.WalletId, freezeBalance.TokenId, freezeBalance.Reason)
+}
+
+// UnfreezeBalance to lift the freeze of balance of a wallet.
+func (t *TokenHandler) UnfreezeBalance(ctx contractapi.TransactionContextInterface, unfreezeBalance tokenDto.UnfreezeBalance) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Handler - UnfreezeBalance-----------")
+
+	// checking dto validate
+	if err := unfreezeBalance.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TokenHandler - UnfreezeBalance Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	return t.tokenService.UnfreezeBalance(ctx, unfreezeBalance.WalletId, unfreezeBalance.TokenId)
+}
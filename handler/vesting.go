@@ -0,0 +1,65 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package handler
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/vesting"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type VestingHandler struct {
+	vestingService services.Vesting
+}
+
+func NewVestingHandler() *VestingHandler {
+	return &VestingHandler{vestingService: vesting.NewVestingService()}
+}
+
+// RevokeVesting to take the unvested amount of a grant back to its issuer.
+func (v *VestingHandler) RevokeVesting(ctx contractapi.TransactionContextInterface, revokeVesting tokenDto.RevokeVesting) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Vesting Handler - RevokeVesting-----------")
+
+	// checking dto validate
+	if err := revokeVesting.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "VestingHandler - RevokeVesting Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return v.vestingService.RevokeVesting(ctx, revokeVesting.IssuerWalletId, revokeVesting.WalletId,
+		revokeVesting.TokenId, revokeVesting.VestingId)
+}
+
+// GetVestingStatus return the vested and unvested balance of token in a wallet.
+func (v *VestingHandler) GetVestingStatus(ctx contractapi.TransactionContextInterface, queryVesting tokenDto.QueryVesting) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Vesting Handler - GetVestingStatus-----------")
+
+	// checking dto validate
+	if err := queryVesting.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "VestingHandler - GetVestingStatus Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	return v.vestingService.GetVestingStatus(ctx, queryVesting.WalletId, queryVesting.TokenId)
+}
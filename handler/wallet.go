@@ -48,6 +48,11 @@ func (w *WalletHandler) CreateWallet(ctx contractapi.TransactionContextInterface
 		glogger.GetInstance().Errorf(ctx, "Wallet Handler - Create Wallet Input invalidate %v", err)
 		return "", helper.RespError(errorcode.InvalidParam)
 	}
+
+	if createWalletDto.Threshold > 0 {
+		return w.walletService.CreateMultiSig(ctx, createWalletDto.TokenId, createWalletDto.Status,
+			createWalletDto.Owners, createWalletDto.Threshold)
+	}
 	return w.walletService.Create(ctx, createWalletDto.TokenId, createWalletDto.Status)
 }
 
@@ -86,3 +91,39 @@ func (w *WalletHandler) EnrollToken(ctx contractapi.TransactionContextInterface,
 	}
 	return w.walletService.EnrollToken(ctx, enrollmentDto.TokenId, enrollmentDto.FromWalletId, enrollmentDto.ToWalletId)
 }
+
+// RemoveEnrollment to remove wallets from enrollment policy for token
+func (w *WalletHandler) RemoveEnrollment(ctx contractapi.TransactionContextInterface, enrollmentDto token.Enrollment) error {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Handler - RemoveEnrollment-----------")
+
+	// checking dto validate
+	if err := enrollmentDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Wallet Handler - RemoveEnrollment Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+	return w.walletService.RemoveEnrollment(ctx, enrollmentDto.TokenId, enrollmentDto.FromWalletId, enrollmentDto.ToWalletId)
+}
+
+// SetIssuanceQuota to set issuance quota of wallet in enrollment policy for token
+func (w *WalletHandler) SetIssuanceQuota(ctx contractapi.TransactionContextInterface, quotaDto token.IssuanceQuota) error {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Handler - SetIssuanceQuota-----------")
+
+	// checking dto validate
+	if err := quotaDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Wallet Handler - SetIssuanceQuota Input invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+	return w.walletService.SetIssuanceQuota(ctx, quotaDto.TokenId, quotaDto.WalletId, quotaDto.Quota)
+}
+
+// GetEnrollment to return enrollment policy for token
+func (w *WalletHandler) GetEnrollment(ctx contractapi.TransactionContextInterface, queryDto token.QueryEnrollment) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Handler - GetEnrollment-----------")
+
+	// checking dto validate
+	if err := queryDto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Wallet Handler - GetEnrollment Input invalidate %v", err)
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+	return w.walletService.GetEnrollment(ctx, queryDto.TokenId)
+}
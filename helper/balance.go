@@ -22,8 +22,10 @@
 package helper
 
 import (
+	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/pkg/unit"
 	"github.com/pkg/errors"
+	"math/big"
 )
 
 // AddBalance add amount into current balance
@@ -81,6 +83,72 @@ func MulBalance(currentBalance string, amount int64) (string, error) {
 	return curBalanceUnit.String(), nil
 }
 
+// PriceBalance return the cost of current balance at a rational (3/7) or decimal (0.5) price.
+// The cost is rounded up to the base unit.
+func PriceBalance(currentBalance string, price string) (string, error) {
+	priceUnit, err := unit.NewPriceFromString(price)
+	if err != nil {
+		return "", err
+	}
+
+	cost, err := priceUnit.Cost(unit.NewBalanceUnitFromString(currentBalance))
+	if err != nil {
+		return "", err
+	}
+	return cost.String(), nil
+}
+
+// BasisPointBalance return the part of balance defined by a rate in basis points.
+// The result is rounded down to the base unit.
+func BasisPointBalance(currentBalance string, bps int64) (string, error) {
+	if bps < 0 || bps > glossary.BasisPointBase {
+		return "", errors.New("invalidate basis point rate")
+	}
+
+	// convert balance to akc unit
+	curBalanceUnit := unit.NewBalanceUnitFromString(currentBalance)
+	if curBalanceUnit.Sign() < 0 {
+		return "", errors.New("Unable to calculate rate of negative amount number")
+	}
+
+	curBalanceUnit.Mul(curBalanceUnit.Int, big.NewInt(bps))
+	curBalanceUnit.Quo(curBalanceUnit.Int, big.NewInt(glossary.BasisPointBase))
+	return curBalanceUnit.String(), nil
+}
+
+// QuoteBalance return the amount of quote token for amount of base token at price.
+// Price is the amount of quote token in base unit for one token of base, the result is rounded down to the base unit.
+func QuoteBalance(amount string, price string) (string, error) {
+	amountUnit := unit.NewBalanceUnitFromString(amount)
+	priceUnit := unit.NewBalanceUnitFromString(price)
+	if amountUnit.Sign() < 0 || priceUnit.Sign() < 0 {
+		return "", errors.New("Unable to calculate quote of negative amount number")
+	}
+
+	amountUnit.Mul(amountUnit.Int, priceUnit.Int)
+	amountUnit.Quo(amountUnit.Int, big.NewInt(glossary.AkcBase))
+	return amountUnit.String(), nil
+}
+
+// ProRataBalance return the share of total for part of whole, total * part / whole.
+// The result is rounded down to the base unit so the shares of all parts never exceed total.
+func ProRataBalance(total string, part string, whole string) (string, error) {
+	totalUnit := unit.NewBalanceUnitFromString(total)
+	partUnit := unit.NewBalanceUnitFromString(part)
+	wholeUnit := unit.NewBalanceUnitFromString(whole)
+	if totalUnit.Sign() < 0 || partUnit.Sign() < 0 || wholeUnit.Sign() <= 0 {
+		return "", errors.New("Unable to calculate share of negative or empty amount number")
+	}
+
+	if partUnit.Cmp(wholeUnit.Int) > 0 {
+		return "", errors.New("part is greater than whole")
+	}
+
+	totalUnit.Mul(totalUnit.Int, partUnit.Int)
+	totalUnit.Quo(totalUnit.Int, wholeUnit.Int)
+	return totalUnit.String(), nil
+}
+
 // CompareStringBalance to compare between current balance and amount.
 // Amount is string type.
 // Return 1 if current balance greater than amount. Otherwise return -1
@@ -61,3 +61,66 @@ func TestCompareStringBalance(t *testing.T) {
 	res = CompareStringBalance("1000000000", "100000000")
 	assert.Equal(t, res, 1)
 }
+
+func TestBasisPointBalance(t *testing.T) {
+	res, err := BasisPointBalance("100000000", 250)
+	assert.NilError(t, err, "Fail to calculate basis point")
+	assert.Equal(t, res, "2500000")
+
+	res, err = BasisPointBalance("999", 250)
+	assert.NilError(t, err, "Fail to calculate basis point")
+	assert.Equal(t, res, "24")
+
+	res, err = BasisPointBalance("999", 0)
+	assert.NilError(t, err, "Fail to calculate basis point")
+	assert.Equal(t, res, "0")
+
+	_, err = BasisPointBalance("999", 10001)
+	assert.ErrorContains(t, err, "basis point")
+}
+
+func TestQuoteBalance(t *testing.T) {
+	res, err := QuoteBalance("250000000", "150000000")
+	assert.NilError(t, err, "Fail to calculate quote")
+	assert.Equal(t, res, "375000000")
+
+	res, err = QuoteBalance("3", "50000000")
+	assert.NilError(t, err, "Fail to calculate quote")
+	assert.Equal(t, res, "1")
+
+	_, err = QuoteBalance("-1", "100000000")
+	assert.ErrorContains(t, err, "negative")
+}
+
+func TestProRataBalance(t *testing.T) {
+	res, err := ProRataBalance("1000", "1", "3")
+	assert.NilError(t, err, "Fail to calculate pro rata share")
+	assert.Equal(t, res, "333")
+
+	res, err = ProRataBalance("1000", "3", "3")
+	assert.NilError(t, err, "Fail to calculate pro rata share")
+	assert.Equal(t, res, "1000")
+
+	res, err = ProRataBalance("1000", "0", "3")
+	assert.NilError(t, err, "Fail to calculate pro rata share")
+	assert.Equal(t, res, "0")
+
+	_, err = ProRataBalance("1000", "1", "0")
+	assert.ErrorContains(t, err, "empty")
+
+	_, err = ProRataBalance("1000", "4", "3")
+	assert.ErrorContains(t, err, "greater")
+}
+
+func TestPriceBalance(t *testing.T) {
+	res, err := PriceBalance("1000", "3/7")
+	assert.NilError(t, err, "Fail to calculate cost at price")
+	assert.Equal(t, res, "429")
+
+	res, err = PriceBalance("1000", "0.25")
+	assert.NilError(t, err, "Fail to calculate cost at price")
+	assert.Equal(t, res, "250")
+
+	_, err = PriceBalance("1000", "0")
+	assert.ErrorContains(t, err, "greater than zero")
+}
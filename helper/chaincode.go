@@ -0,0 +1,54 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package helper
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/pkg/errors"
+)
+
+// GetChaincodeName return the name of the chaincode invoked by the proposal of the transaction
+func GetChaincodeName(ctx contractapi.TransactionContextInterface) (string, error) {
+	signedProposal, err := ctx.GetStub().GetSignedProposal()
+	if err != nil {
+		return "", errors.WithMessage(err, "Get signed proposal failed")
+	}
+	if signedProposal == nil {
+		return "", errors.New("signed proposal is not available")
+	}
+
+	proposal := new(peer.Proposal)
+	if err := proto.Unmarshal(signedProposal.ProposalBytes, proposal); err != nil {
+		return "", errors.WithMessage(err, "Unmarshal proposal failed")
+	}
+
+	payload := new(peer.ChaincodeProposalPayload)
+	if err := proto.Unmarshal(proposal.Payload, payload); err != nil {
+		return "", errors.WithMessage(err, "Unmarshal proposal payload failed")
+	}
+
+	invocation := new(peer.ChaincodeInvocationSpec)
+	if err := proto.Unmarshal(payload.Input, invocation); err != nil {
+		return "", errors.WithMessage(err, "Unmarshal chaincode invocation failed")
+	}
+	return invocation.GetChaincodeSpec().GetChaincodeId().GetName(), nil
+}
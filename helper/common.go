@@ -20,11 +20,14 @@
 package helper
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"github.com/Akachain/gringotts/glossary"
 	"golang.org/x/crypto/sha3"
 	"sort"
+	"strings"
 )
 
 // GenerateID return id of docs base on document prefix name and Fabric transaction ID
@@ -37,18 +40,56 @@ func GenerateID(docPrefix string, txID string) string {
 	return shaString[len(shaString)-glossary.IdLength:]
 }
 
-// ArrayContains return true if array contain search string, otherwise return fail
+// GenerateBatchID return id of docs created by one item of a batch in a Fabric transaction.
+// Documents of the same prefix created in the same transaction need a different index to not collide.
+func GenerateBatchID(docPrefix string, txID string, index int) string {
+	return GenerateID(docPrefix, fmt.Sprintf("%s_%d", txID, index))
+}
+
+// ArrayContains return true if array contain search string, otherwise return fail.
+// The array must be sorted
 func ArrayContains(s []string, searchString string) bool {
 	i := sort.SearchStrings(s, searchString)
 	return i < len(s) && s[i] == searchString
 }
 
+// ArrayInsert return the sorted array with the string inserted, a string already in the array is not repeated
+func ArrayInsert(s []string, insertString string) []string {
+	i := sort.SearchStrings(s, insertString)
+	if i < len(s) && s[i] == insertString {
+		return s
+	}
+	s = append(s, "")
+	copy(s[i+1:], s[i:])
+	s[i] = insertString
+	return s
+}
+
+// ArrayRemove return the sorted array without the string
+func ArrayRemove(s []string, removeString string) []string {
+	i := sort.SearchStrings(s, removeString)
+	if i >= len(s) || s[i] != removeString {
+		return s
+	}
+	return append(s[:i], s[i+1:]...)
+}
+
 // MarshalStruct return string marshall of struct
 func MarshalStruct(data interface{}) string {
 	dataByte, _ := json.Marshal(data)
 	return string(dataByte)
 }
 
+// MatchHashLock return true when the sha256 of the hex preimage is the hex hash lock
+func MatchHashLock(hashLock string, preimage string) bool {
+	secret, err := hex.DecodeString(preimage)
+	if err != nil {
+		return false
+	}
+	hash := sha256.Sum256(secret)
+	return strings.EqualFold(hex.EncodeToString(hash[:]), hashLock)
+}
+
 // CalculateHash return hash 256 of string input
 func CalculateHash(input string) string {
 	h := sha3.New256()
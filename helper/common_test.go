@@ -19,9 +19,45 @@
 
 package helper
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestGenerateID(t *testing.T) {
 	id := GenerateID("test", "a11")
 	t.Log(id)
 }
+
+func TestArrayInsertRemove(t *testing.T) {
+	var s []string
+	for _, item := range []string{"b", "ab", "a", "b"} {
+		s = ArrayInsert(s, item)
+	}
+	if len(s) != 3 || s[0] != "a" || s[1] != "ab" || s[2] != "b" {
+		t.Fatalf("unexpected sorted set %v", s)
+	}
+
+	// a substring of an item is not contained
+	if !ArrayContains(s, "ab") || ArrayContains(s, "ba") {
+		t.Fatalf("unexpected contains of %v", s)
+	}
+
+	s = ArrayRemove(s, "ab")
+	s = ArrayRemove(s, "c")
+	if len(s) != 2 || s[0] != "a" || s[1] != "b" {
+		t.Fatalf("unexpected set after remove %v", s)
+	}
+}
+
+func TestMatchHashLock(t *testing.T) {
+	// sha256 of the bytes "secret"
+	hashLock := "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b"
+	if !MatchHashLock(hashLock, "736563726574") || !MatchHashLock(strings.ToUpper(hashLock), "736563726574") {
+		t.Fatal("preimage must match hash lock")
+	}
+
+	if MatchHashLock(hashLock, "736563726575") || MatchHashLock(hashLock, "secret") {
+		t.Fatal("wrong preimage must not match hash lock")
+	}
+}
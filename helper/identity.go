@@ -0,0 +1,44 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package helper
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// IsAdmin return true if the certificate of the caller has the admin attribute of the system
+func IsAdmin(ctx contractapi.TransactionContextInterface) bool {
+	identity := ctx.GetClientIdentity()
+	if identity == nil {
+		return false
+	}
+	return identity.AssertAttributeValue(glossary.AdminAttribute, "true") == nil
+}
+
+// GetCallerId return the unique id of the client identity of the caller
+func GetCallerId(ctx contractapi.TransactionContextInterface) (string, error) {
+	identity := ctx.GetClientIdentity()
+	if identity == nil {
+		return "", errors.New("client identity is not available")
+	}
+	return identity.GetID()
+}
@@ -68,6 +68,23 @@ func IaoKey(iaoId string) []string {
 	return []string{iaoId}
 }
 
+// IaoCommitmentKey return list key of commitment of wallet in iao will be compose in couch db key.
+// Commitments of an iao are read together by the partial key of iaoId.
+func IaoCommitmentKey(iaoId, walletId, stableTokenId string) []string {
+	return []string{iaoId, walletId, stableTokenId}
+}
+
+// IaoInvestorKey return list key of the total bought by wallet in iao will be compose in couch db key
+func IaoInvestorKey(iaoId, walletId string) []string {
+	return []string{iaoId, walletId}
+}
+
+// IaoEligibilityKey return list key of the eligibility of wallet in iao will be compose in couch db key.
+// The eligibility list of an iao is read by the partial key of iaoId.
+func IaoEligibilityKey(iaoId, walletId string) []string {
+	return []string{iaoId, walletId}
+}
+
 // InvestorBookKey return list key of Investor Book will be compose in couch db key
 func InvestorBookKey(iaoId string, txId string) []string {
 	return []string{iaoId, txId}
@@ -77,3 +94,104 @@ func InvestorBookKey(iaoId string, txId string) []string {
 func ResultCacheKey(cacheId string) []string {
 	return []string{cacheId}
 }
+
+// ExchangeKey return list key of nft listing will be compose in couch db key
+func ExchangeKey(listingId string) []string {
+	return []string{listingId}
+}
+
+// OfferKey return list key of nft offer will be compose in couch db key
+func OfferKey(offerId string) []string {
+	return []string{offerId}
+}
+
+// VaultKey return list key of nft vault will be compose in couch db key
+func VaultKey(vaultId string) []string {
+	return []string{vaultId}
+}
+
+// SwapKey return list key of token swap will be compose in couch db key
+func SwapKey(swapId string) []string {
+	return []string{swapId}
+}
+
+// OrderKey return list key of limit order will be compose in couch db key
+func OrderKey(orderId string) []string {
+	return []string{orderId}
+}
+
+// OrderSequenceKey return list key of order sequence of token pair will be compose in couch db key
+func OrderSequenceKey(baseTokenId, quoteTokenId string) []string {
+	return []string{baseTokenId, quoteTokenId}
+}
+
+// TradeKey return list key of trade will be compose in couch db key
+func TradeKey(tradeId string) []string {
+	return []string{tradeId}
+}
+
+// PoolKey return list key of liquidity pool will be compose in couch db key
+func PoolKey(poolId string) []string {
+	return []string{poolId}
+}
+
+// FeeScheduleKey return list key of fee schedule will be compose in couch db key
+func FeeScheduleKey(tokenId string, txType string) []string {
+	return []string{tokenId, txType}
+}
+
+// FreezeKey return list key of balance freeze will be compose in couch db key
+func FreezeKey(walletId string, tokenId string) []string {
+	return []string{walletId, tokenId}
+}
+
+// ApprovalPolicyKey return list key of approval policy will be compose in couch db key
+func ApprovalPolicyKey(operation string, tokenId string) []string {
+	return []string{operation, tokenId}
+}
+
+// ProposalKey return list key of proposal will be compose in couch db key
+func ProposalKey(proposalId string) []string {
+	return []string{proposalId}
+}
+
+// IntentKey return list key of multi-signature intent will be compose in couch db key
+func IntentKey(intentId string) []string {
+	return []string{intentId}
+}
+
+// IdempotencyKey return list key of idempotency record will be compose in couch db key
+func IdempotencyKey(requestId string) []string {
+	return []string{requestId}
+}
+
+// HashLockKey return list key of hashed time-locked transfer will be compose in couch db key
+func HashLockKey(lockId string) []string {
+	return []string{lockId}
+}
+
+// ScheduleKey return list key of scheduled transfer will be compose in couch db key
+func ScheduleKey(scheduleId string) []string {
+	return []string{scheduleId}
+}
+
+// SnapshotKey return list key of balance snapshot will be compose in couch db key
+func SnapshotKey(snapshotId string) []string {
+	return []string{snapshotId}
+}
+
+// SnapshotBalanceKey return list key of the balance of a holder in a snapshot will be compose in couch db key
+func SnapshotBalanceKey(snapshotId, walletId string) []string {
+	return []string{snapshotId, walletId}
+}
+
+// DistributionKey return list key of pro-rata distribution will be compose in couch db key
+func DistributionKey(distributionId string) []string {
+	return []string{distributionId}
+}
+
+// VestingKey return list key of vesting grant will be compose in couch db key.
+// Grants of a wallet and token are read together by the partial key of walletId and tokenId.
+func VestingKey(walletId, tokenId, vestingId string) []string {
+	return []string{walletId, tokenId, vestingId}
+}
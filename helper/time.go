@@ -20,10 +20,34 @@
 package helper
 
 import (
+	"errors"
+	"strconv"
 	"time"
 )
 
+// dateLayout is the layout of a calendar date, it starts at midnight UTC
+const dateLayout = "2006-01-02"
+
 // TimestampISO convert time unix to time ISO 8601
 func TimestampISO(timeUnix int64) string {
 	return time.Unix(timeUnix, 0).Format(time.RFC3339)
 }
+
+// IsExpired return true if the expiry (time unix) is set and has passed at the time unix now
+func IsExpired(expiry int64, now int64) bool {
+	return expiry > 0 && now >= expiry
+}
+
+// ParseTimestamp convert a time unix, a time ISO 8601 (RFC 3339) or a calendar date (YYYY-MM-DD) to time unix
+func ParseTimestamp(date string) (int64, error) {
+	if timeUnix, err := strconv.ParseInt(date, 10, 64); err == nil {
+		return timeUnix, nil
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil {
+		return t.Unix(), nil
+	}
+	if t, err := time.Parse(dateLayout, date); err == nil {
+		return t.Unix(), nil
+	}
+	return 0, errors.New("time must be unix, ISO 8601 or YYYY-MM-DD")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package helper
+
+import (
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	timestamp, err := ParseTimestamp("2021-06-01T07:00:00+07:00")
+	assert.NilError(t, err, "Fail to parse time ISO 8601")
+	assert.Equal(t, timestamp, int64(1622505600))
+
+	timestamp, err = ParseTimestamp("2021-06-01")
+	assert.NilError(t, err, "Fail to parse date")
+	assert.Equal(t, timestamp, int64(1622505600))
+
+	timestamp, err = ParseTimestamp("1622505600")
+	assert.NilError(t, err, "Fail to parse time unix")
+	assert.Equal(t, timestamp, int64(1622505600))
+
+	_, err = ParseTimestamp("01/06/2021")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package allocation contains the allocation of an oversubscribed sale between its commitments.
+// All amounts are strings in base unit. Fills are rounded down and the units left by rounding go one by one
+// to the commitments with the largest remainders, the earlier commitment first on ties, so the result is deterministic.
+package allocation
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/pkg/unit"
+	"math/big"
+	"sort"
+)
+
+// ProRata return the fill of each commitment out of supply. Every commitment is filled when supply covers them all,
+// otherwise each commitment gets its share of supply in proportion to its amount.
+func ProRata(supply string, commitments []string) ([]string, error) {
+	s, amounts, err := parse(supply, commitments)
+	if err != nil {
+		return nil, err
+	}
+	return format(proRata(s, amounts)), nil
+}
+
+// Tiered return the fill of each commitment out of supply. Each commitment is first filled up to tier,
+// pro-rata when supply does not cover these base fills, and the rest of supply is shared pro-rata by the amounts over tier.
+func Tiered(supply, tier string, commitments []string) ([]string, error) {
+	s, amounts, err := parse(supply, commitments)
+	if err != nil {
+		return nil, err
+	}
+	t := toInt(tier)
+	if t.Sign() < 0 {
+		return nil, errors.New("invalidate tier")
+	}
+
+	base := make([]*big.Int, len(amounts))
+	over := make([]*big.Int, len(amounts))
+	baseTotal := new(big.Int)
+	for i, amount := range amounts {
+		base[i] = minInt(amount, t)
+		over[i] = new(big.Int).Sub(amount, base[i])
+		baseTotal.Add(baseTotal, base[i])
+	}
+	if baseTotal.Cmp(s) >= 0 {
+		return format(proRata(s, base)), nil
+	}
+
+	fills := proRata(new(big.Int).Sub(s, baseTotal), over)
+	for i := range fills {
+		fills[i].Add(fills[i], base[i])
+	}
+	return format(fills), nil
+}
+
+func proRata(supply *big.Int, amounts []*big.Int) []*big.Int {
+	total := new(big.Int)
+	for _, amount := range amounts {
+		total.Add(total, amount)
+	}
+
+	fills := make([]*big.Int, len(amounts))
+	if total.Cmp(supply) <= 0 {
+		for i, amount := range amounts {
+			fills[i] = new(big.Int).Set(amount)
+		}
+		return fills
+	}
+
+	remainders := make([]*big.Int, len(amounts))
+	left := new(big.Int).Set(supply)
+	for i, amount := range amounts {
+		fills[i], remainders[i] = new(big.Int).QuoRem(new(big.Int).Mul(supply, amount), total, new(big.Int))
+		left.Sub(left, fills[i])
+	}
+
+	// the units left are fewer than the commitments with a remainder, none of them is filled over its amount
+	order := make([]int, len(amounts))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for _, i := range order {
+		if left.Sign() <= 0 {
+			break
+		}
+		fills[i].Add(fills[i], big.NewInt(1))
+		left.Sub(left, big.NewInt(1))
+	}
+	return fills
+}
+
+func parse(supply string, commitments []string) (*big.Int, []*big.Int, error) {
+	s := toInt(supply)
+	if s.Sign() < 0 {
+		return nil, nil, errors.New("invalidate supply")
+	}
+
+	amounts := make([]*big.Int, len(commitments))
+	for i, commitment := range commitments {
+		amounts[i] = toInt(commitment)
+		if amounts[i].Sign() < 0 {
+			return nil, nil, errors.New("invalidate commitment")
+		}
+	}
+	return s, amounts, nil
+}
+
+func format(fills []*big.Int) []string {
+	result := make([]string, len(fills))
+	for i, fill := range fills {
+		result[i] = fill.String()
+	}
+	return result
+}
+
+func minInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return new(big.Int).Set(a)
+	}
+	return new(big.Int).Set(b)
+}
+
+func toInt(amount string) *big.Int {
+	return unit.NewBalanceUnitFromString(amount).Int
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package allocation
+
+import (
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestProRataUndersubscribed(t *testing.T) {
+	fills, err := ProRata("1000", []string{"300", "200"})
+	assert.NilError(t, err, "Fail to allocate")
+	assert.DeepEqual(t, fills, []string{"300", "200"})
+}
+
+func TestProRataOversubscribed(t *testing.T) {
+	// 1000 * 100 / 3000 = 33.3, 1000 * 900 / 3000 = 300, 1000 * 2000 / 3000 = 666.6
+	fills, err := ProRata("1000", []string{"100", "900", "2000"})
+	assert.NilError(t, err, "Fail to allocate")
+	assert.DeepEqual(t, fills, []string{"33", "300", "667"})
+}
+
+func TestProRataTieGoesToEarlierCommitment(t *testing.T) {
+	fills, err := ProRata("10", []string{"5", "5", "5"})
+	assert.NilError(t, err, "Fail to allocate")
+	assert.DeepEqual(t, fills, []string{"4", "3", "3"})
+}
+
+func TestTiered(t *testing.T) {
+	// every commitment gets up to 100 first, the remaining 750 is shared by 0, 400 and 1000 over the tier
+	fills, err := Tiered("1000", "100", []string{"50", "500", "1100"})
+	assert.NilError(t, err, "Fail to allocate")
+	assert.DeepEqual(t, fills, []string{"50", "314", "636"})
+
+	// the base fills are over supply
+	fills, err = Tiered("150", "100", []string{"50", "500", "1100"})
+	assert.NilError(t, err, "Fail to allocate")
+	assert.DeepEqual(t, fills, []string{"30", "60", "60"})
+}
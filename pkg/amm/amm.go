@@ -0,0 +1,117 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package amm contains the math of constant-product liquidity pools.
+// All amounts are strings in base unit and results are rounded down so the pool never pays out more than its reserves.
+package amm
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/pkg/unit"
+	"math/big"
+)
+
+// SwapOut return the amount of token out received for amountIn of token in,
+// after fee in basis points is taken from amountIn:
+//
+//	amountOut = amountIn * (1 - fee) * reserveOut / (reserveIn + amountIn * (1 - fee))
+func SwapOut(reserveIn, reserveOut, amountIn string, feeBps int64) (string, error) {
+	if feeBps < 0 || feeBps >= glossary.BasisPointBase {
+		return "", errors.New("invalidate fee rate")
+	}
+
+	rIn, rOut, aIn := toInt(reserveIn), toInt(reserveOut), toInt(amountIn)
+	if rIn.Sign() <= 0 || rOut.Sign() <= 0 {
+		return "", errors.New("pool has no liquidity")
+	}
+	if aIn.Sign() <= 0 {
+		return "", errors.New("invalidate amount in")
+	}
+
+	amountInWithFee := new(big.Int).Mul(aIn, big.NewInt(glossary.BasisPointBase-feeBps))
+	numerator := new(big.Int).Mul(amountInWithFee, rOut)
+	denominator := new(big.Int).Mul(rIn, big.NewInt(glossary.BasisPointBase))
+	denominator.Add(denominator, amountInWithFee)
+
+	return numerator.Quo(numerator, denominator).String(), nil
+}
+
+// AddLiquidity return the amount of token A and B taken from the deposit and the liquidity minted for it.
+// The first deposit sets the price of the pool and mints sqrt(amountA * amountB),
+// later deposits are taken at the price of the pool and the part over the price is left to the provider.
+func AddLiquidity(reserveA, reserveB, totalLiquidity, amountA, amountB string) (usedA, usedB, liquidity string, err error) {
+	rA, rB, supply := toInt(reserveA), toInt(reserveB), toInt(totalLiquidity)
+	aA, aB := toInt(amountA), toInt(amountB)
+	if aA.Sign() <= 0 || aB.Sign() <= 0 {
+		return "", "", "", errors.New("invalidate deposit amount")
+	}
+
+	var minted *big.Int
+	if supply.Sign() == 0 {
+		minted = new(big.Int).Sqrt(new(big.Int).Mul(aA, aB))
+	} else {
+		if rA.Sign() <= 0 || rB.Sign() <= 0 {
+			return "", "", "", errors.New("pool has no liquidity")
+		}
+
+		// amount of B matching amountA at the price of the pool
+		optimalB := new(big.Int).Mul(aA, rB)
+		optimalB.Quo(optimalB, rA)
+		if optimalB.Cmp(aB) <= 0 {
+			aB = optimalB
+		} else {
+			optimalA := new(big.Int).Mul(aB, rA)
+			aA = optimalA.Quo(optimalA, rB)
+		}
+
+		liquidityA := new(big.Int).Mul(aA, supply)
+		liquidityA.Quo(liquidityA, rA)
+		liquidityB := new(big.Int).Mul(aB, supply)
+		liquidityB.Quo(liquidityB, rB)
+		minted = liquidityA
+		if liquidityB.Cmp(liquidityA) < 0 {
+			minted = liquidityB
+		}
+	}
+
+	if minted.Sign() <= 0 {
+		return "", "", "", errors.New("deposit is too small to mint liquidity")
+	}
+	return aA.String(), aB.String(), minted.String(), nil
+}
+
+// RemoveLiquidity return the amount of token A and B paid for burning liquidity
+func RemoveLiquidity(reserveA, reserveB, totalLiquidity, liquidity string) (amountA, amountB string, err error) {
+	rA, rB, supply, burnt := toInt(reserveA), toInt(reserveB), toInt(totalLiquidity), toInt(liquidity)
+	if burnt.Sign() <= 0 || burnt.Cmp(supply) > 0 {
+		return "", "", errors.New("invalidate liquidity amount")
+	}
+
+	outA := new(big.Int).Mul(burnt, rA)
+	outA.Quo(outA, supply)
+	outB := new(big.Int).Mul(burnt, rB)
+	outB.Quo(outB, supply)
+
+	return outA.String(), outB.String(), nil
+}
+
+func toInt(amount string) *big.Int {
+	return unit.NewBalanceUnitFromString(amount).Int
+}
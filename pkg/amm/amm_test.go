@@ -0,0 +1,78 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package amm
+
+import (
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestSwapOut(t *testing.T) {
+	// no fee: 1000 * 1000 / (1000 + 1000)
+	out, err := SwapOut("1000", "1000", "1000", 0)
+	assert.NilError(t, err, "Fail to calculate swap out")
+	assert.Equal(t, out, "500")
+
+	// 0.3% fee: 997000 * 2000 / (1000 * 10000 + 997000)
+	out, err = SwapOut("1000", "2000", "100", 30)
+	assert.NilError(t, err, "Fail to calculate swap out")
+	assert.Equal(t, out, "181")
+
+	_, err = SwapOut("0", "2000", "100", 30)
+	assert.ErrorContains(t, err, "no liquidity")
+
+	_, err = SwapOut("1000", "2000", "100", 10000)
+	assert.ErrorContains(t, err, "fee rate")
+}
+
+func TestAddLiquidity(t *testing.T) {
+	usedA, usedB, liquidity, err := AddLiquidity("0", "0", "0", "400", "100")
+	assert.NilError(t, err, "Fail to add first liquidity")
+	assert.Equal(t, usedA, "400")
+	assert.Equal(t, usedB, "100")
+	assert.Equal(t, liquidity, "200")
+
+	// deposit over the price of the pool keeps only the matching amount of B
+	usedA, usedB, liquidity, err = AddLiquidity("400", "100", "200", "200", "80")
+	assert.NilError(t, err, "Fail to add liquidity")
+	assert.Equal(t, usedA, "200")
+	assert.Equal(t, usedB, "50")
+	assert.Equal(t, liquidity, "100")
+
+	// deposit over the price of the pool keeps only the matching amount of A
+	usedA, usedB, liquidity, err = AddLiquidity("400", "100", "200", "800", "50")
+	assert.NilError(t, err, "Fail to add liquidity")
+	assert.Equal(t, usedA, "200")
+	assert.Equal(t, usedB, "50")
+	assert.Equal(t, liquidity, "100")
+
+	_, _, _, err = AddLiquidity("0", "0", "0", "1", "0")
+	assert.ErrorContains(t, err, "deposit amount")
+}
+
+func TestRemoveLiquidity(t *testing.T) {
+	amountA, amountB, err := RemoveLiquidity("600", "150", "300", "100")
+	assert.NilError(t, err, "Fail to remove liquidity")
+	assert.Equal(t, amountA, "200")
+	assert.Equal(t, amountB, "50")
+
+	_, _, err = RemoveLiquidity("600", "150", "300", "301")
+	assert.ErrorContains(t, err, "liquidity amount")
+}
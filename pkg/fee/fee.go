@@ -0,0 +1,95 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package fee contains the calculation of transaction fees from a fee schedule.
+// All amounts are strings in base unit and rates are rounded down to the base unit.
+package fee
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/fee"
+	"github.com/Akachain/gringotts/helper"
+)
+
+// Calculate return the fee of amount under the schedule, bounded by MinFee and MaxFee of the schedule.
+//
+// Flat charges FlatAmount, BasisPoint charges RateBps of amount and Tiered charges
+// FlatAmount plus RateBps of amount of the tier with the highest MinAmount not over amount.
+func Calculate(schedule *entity.FeeSchedule, amount string) (string, error) {
+	if helper.CompareStringBalance(amount, "0") < 0 {
+		return "", errors.New("invalidate amount")
+	}
+
+	var feeAmount string
+	var err error
+	switch schedule.FeeType {
+	case fee.Flat:
+		feeAmount = orZero(schedule.FlatAmount)
+	case fee.BasisPoint:
+		if feeAmount, err = helper.BasisPointBalance(amount, schedule.RateBps); err != nil {
+			return "", err
+		}
+	case fee.Tiered:
+		if feeAmount, err = tieredFee(schedule.Tiers, amount); err != nil {
+			return "", err
+		}
+	default:
+		return "", errors.New("invalidate fee type")
+	}
+
+	if schedule.MinFee != "" && helper.CompareStringBalance(feeAmount, schedule.MinFee) < 0 {
+		feeAmount = schedule.MinFee
+	}
+	if schedule.MaxFee != "" && helper.CompareStringBalance(feeAmount, schedule.MaxFee) > 0 {
+		feeAmount = schedule.MaxFee
+	}
+
+	return feeAmount, nil
+}
+
+func tieredFee(tiers []entity.FeeTier, amount string) (string, error) {
+	var tier *entity.FeeTier
+	for i := range tiers {
+		if helper.CompareStringBalance(amount, orZero(tiers[i].MinAmount)) < 0 {
+			continue
+		}
+		if tier == nil || helper.CompareStringBalance(orZero(tiers[i].MinAmount), orZero(tier.MinAmount)) > 0 {
+			tier = &tiers[i]
+		}
+	}
+
+	// amount is under the first tier
+	if tier == nil {
+		return "0", nil
+	}
+
+	rateFee, err := helper.BasisPointBalance(amount, tier.RateBps)
+	if err != nil {
+		return "", err
+	}
+	return helper.AddBalance(rateFee, orZero(tier.FlatAmount))
+}
+
+func orZero(amount string) string {
+	if amount == "" {
+		return "0"
+	}
+	return amount
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package fee
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/fee"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestCalculateFlat(t *testing.T) {
+	feeAmount, err := Calculate(&entity.FeeSchedule{FeeType: fee.Flat, FlatAmount: "500"}, "100000")
+	assert.NilError(t, err, "Fail to calculate flat fee")
+	assert.Equal(t, feeAmount, "500")
+}
+
+func TestCalculateBasisPoint(t *testing.T) {
+	schedule := &entity.FeeSchedule{FeeType: fee.BasisPoint, RateBps: 30, MinFee: "10", MaxFee: "1000"}
+
+	feeAmount, err := Calculate(schedule, "100000")
+	assert.NilError(t, err, "Fail to calculate basis point fee")
+	assert.Equal(t, feeAmount, "300")
+
+	// 0.3% of 1000 is under the minimum fee
+	feeAmount, err = Calculate(schedule, "1000")
+	assert.NilError(t, err, "Fail to calculate basis point fee")
+	assert.Equal(t, feeAmount, "10")
+
+	// 0.3% of 1000000 is over the maximum fee
+	feeAmount, err = Calculate(schedule, "1000000")
+	assert.NilError(t, err, "Fail to calculate basis point fee")
+	assert.Equal(t, feeAmount, "1000")
+}
+
+func TestCalculateTiered(t *testing.T) {
+	schedule := &entity.FeeSchedule{
+		FeeType: fee.Tiered,
+		Tiers: []entity.FeeTier{
+			{MinAmount: "10000", RateBps: 10},
+			{MinAmount: "100", FlatAmount: "5", RateBps: 50},
+		},
+	}
+
+	feeAmount, err := Calculate(schedule, "50")
+	assert.NilError(t, err, "Fail to calculate tiered fee")
+	assert.Equal(t, feeAmount, "0")
+
+	// 5 + 0.5% of 1000
+	feeAmount, err = Calculate(schedule, "1000")
+	assert.NilError(t, err, "Fail to calculate tiered fee")
+	assert.Equal(t, feeAmount, "10")
+
+	// 0.1% of 20000
+	feeAmount, err = Calculate(schedule, "20000")
+	assert.NilError(t, err, "Fail to calculate tiered fee")
+	assert.Equal(t, feeAmount, "20")
+}
+
+func TestCalculateInvalid(t *testing.T) {
+	_, err := Calculate(&entity.FeeSchedule{FeeType: "Unknown"}, "100")
+	assert.ErrorContains(t, err, "fee type")
+
+	_, err = Calculate(&entity.FeeSchedule{FeeType: fee.Flat}, "-1")
+	assert.ErrorContains(t, err, "amount")
+}
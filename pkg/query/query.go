@@ -62,3 +62,217 @@ func GetTransactionByBlockchainId(blockchainId string) string {
 			"use_index":["indexBlockchainTxDoc","indexBlockchainTx"]
 		}`, blockchainId)
 }
+
+// GetListingQueryString return query string to get nft listings by field (NftTokenId or OwnerWalletId)
+func GetListingQueryString(field, value string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"%s": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Exchange",
+					"$lt": "\u0000Exchange\uFFFF"}			
+			},
+			"use_index":["indexListingDoc","indexListing%s"]
+		}`, field, value, field)
+}
+
+// GetOfferByNftQueryString return query string to get all offers on an nft token
+func GetOfferByNftQueryString(nftTokenId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"NftTokenId": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Offer",
+					"$lt": "\u0000Offer\uFFFF"}			
+			},
+			"use_index":["indexOfferDoc","indexOfferNftTokenId"]
+		}`, nftTokenId)
+}
+
+// GetOpenOrderByPairQueryString return query string to get all open orders of a token pair
+func GetOpenOrderByPairQueryString(baseTokenId, quoteTokenId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"BaseTokenId": 
+					{ "$eq": "%s" },
+				"QuoteTokenId": 
+					{ "$eq": "%s" },
+				"Status": 
+					{ "$in": ["Open", "PartiallyFilled"] },
+				"_id": 
+					{"$gt": "\u0000Order",
+					"$lt": "\u0000Order\uFFFF"}			
+			},
+			"use_index":["indexOrderDoc","indexOrderBaseTokenId"]
+		}`, baseTokenId, quoteTokenId)
+}
+
+// GetOrderByWalletQueryString return query string to get all orders of a wallet
+func GetOrderByWalletQueryString(walletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"WalletId": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Order",
+					"$lt": "\u0000Order\uFFFF"}			
+			},
+			"use_index":["indexOrderDoc","indexOrderWalletId"]
+		}`, walletId)
+}
+
+// GetTradeQueryString return query string to get trades by field (BuyerWalletId or SellerWalletId)
+func GetTradeQueryString(field, value string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"%s": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Trade",
+					"$lt": "\u0000Trade\uFFFF"}			
+			},
+			"use_index":["indexTradeDoc","indexTrade%s"]
+		}`, field, value, field)
+}
+
+// GetPoolByPairQueryString return query string to get the liquidity pool of a token pair
+func GetPoolByPairQueryString(tokenA, tokenB string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"TokenA": 
+					{ "$eq": "%s" },
+				"TokenB": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Pool",
+					"$lt": "\u0000Pool\uFFFF"}			
+			},
+			"use_index":["indexPoolDoc","indexPoolTokenA"]
+		}`, tokenA, tokenB)
+}
+
+// GetOpenIntentByWalletQueryString return query string to get open intents of a multi-signature wallet
+func GetOpenIntentByWalletQueryString(walletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"WalletId": 
+					{ "$eq": "%s" },
+				"Status": 
+					{ "$eq": "Open" },
+				"_id": 
+					{"$gt": "\u0000Intent",
+					"$lt": "\u0000Intent\uFFFF"}			
+			},
+			"use_index":["indexIntentDoc","indexIntentWalletId"]
+		}`, walletId)
+}
+
+// GetExpiredIdempotencyQueryString return query string to get idempotency records expired before now
+func GetExpiredIdempotencyQueryString(now int64) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"Expiry": 
+					{ "$lt": %d },
+				"_id": 
+					{"$gt": "\u0000Idempotency",
+					"$lt": "\u0000Idempotency\uFFFF"}			
+			},
+			"use_index":["indexIdempotencyDoc","indexIdempotencyExpiry"]
+		}`, now)
+}
+
+// GetScheduleByWalletQueryString return the scheduled transfers from a wallet
+func GetScheduleByWalletQueryString(walletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"FromWallet": 
+					{ "$eq": "%s" },
+				"_id": 
+					{"$gt": "\u0000Schedule",
+					"$lt": "\u0000Schedule\uFFFF"}			
+			},
+			"use_index":["indexScheduleDoc","indexScheduleFromWallet"]
+		}`, walletId)
+}
+
+// GetDueScheduleQueryString return the active scheduled transfers with a run due at now
+func GetDueScheduleQueryString(now int64) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"NextTime": 
+					{ "$lte": %d },
+				"Status": 
+					{ "$eq": "Active" },
+				"_id": 
+					{"$gt": "\u0000Schedule",
+					"$lt": "\u0000Schedule\uFFFF"}			
+			},
+			"use_index":["indexScheduleDoc","indexScheduleNextTime"]
+		}`, now)
+}
+
+// GetHolderBalanceQueryString return the spot balances of token after wallet id in order of wallet id
+func GetHolderBalanceQueryString(tokenId, afterWalletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"TokenId": 
+					{ "$eq": "%s" },
+				"WalletId": 
+					{ "$gt": "%s" },
+				"_id": 
+					{"$gt": "\u0000SpotBalances",
+					"$lt": "\u0000SpotBalances\uFFFF"}			
+			},
+			"sort": [{"TokenId": "asc"}, {"WalletId": "asc"}],
+			"use_index":["indexSpotBalancesDoc","indexSpotBalancesTokenWallet"]
+		}`, tokenId, afterWalletId)
+}
+
+// GetSnapshotBalanceQueryString return the balances of a snapshot after wallet id in order of wallet id
+func GetSnapshotBalanceQueryString(snapshotId, afterWalletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"SnapshotId": 
+					{ "$eq": "%s" },
+				"WalletId": 
+					{ "$gt": "%s" },
+				"_id": 
+					{"$gt": "\u0000SnapshotBalance",
+					"$lt": "\u0000SnapshotBalance\uFFFF"}			
+			},
+			"sort": [{"SnapshotId": "asc"}, {"WalletId": "asc"}],
+			"use_index":["indexSnapshotBalanceDoc","indexSnapshotBalanceWallet"]
+		}`, snapshotId, afterWalletId)
+}
+
+// GetIaoEligibilityQueryString return the eligibility list of an iao after wallet id in order of wallet id
+func GetIaoEligibilityQueryString(iaoId, afterWalletId string) string {
+	return fmt.Sprintf(`
+		{ "selector": 
+			{ 	
+				"IaoId": 
+					{ "$eq": "%s" },
+				"WalletId": 
+					{ "$gt": "%s" },
+				"_id": 
+					{"$gt": "\u0000IaoEligibility",
+					"$lt": "\u0000IaoEligibility\uFFFF"}			
+			},
+			"sort": [{"IaoId": "asc"}, {"WalletId": "asc"}],
+			"use_index":["indexIaoEligibilityDoc","indexIaoEligibilityWallet"]
+		}`, iaoId, afterWalletId)
+}
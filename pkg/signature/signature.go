@@ -0,0 +1,78 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package signature contains the verification of transfers signed off-chain by the ECDSA key of a wallet.
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Message return the canonical message signed by the wallet key: the channel id, chaincode name, action, nonce,
+// expiry and payload joined by new lines. The channel and chaincode keep a signature from being submitted to another
+// deployment, the payload is signed exactly as it is submitted.
+func Message(channelId, chaincodeName, action string, nonce, expiry int64, payload string) []byte {
+	return []byte(strings.Join([]string{channelId, chaincodeName, action, strconv.FormatInt(nonce, 10),
+		strconv.FormatInt(expiry, 10), payload}, "\n"))
+}
+
+// ParsePublicKey return the ECDSA public key of a PEM encoded PKIX public key
+func ParsePublicKey(publicKeyPem string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(publicKeyPem))
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an ECDSA key")
+	}
+	return publicKey, nil
+}
+
+// Verify check the base64 ASN.1 signature of the SHA-256 hash of message against the PEM public key
+func Verify(publicKeyPem string, message []byte, signature string) error {
+	publicKey, err := ParsePublicKey(publicKeyPem)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errors.New("signature is not base64 encoded")
+	}
+
+	hash := sha256.Sum256(message)
+	if !ecdsa.VerifyASN1(publicKey, hash[:], sig) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err, "Fail to generate key")
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NilError(t, err, "Fail to marshal public key")
+	return privateKey, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func sign(t *testing.T, privateKey *ecdsa.PrivateKey, message []byte) string {
+	hash := sha256.Sum256(message)
+	sig, err := ecdsa.SignASN1(rand.Reader, privateKey, hash[:])
+	assert.NilError(t, err, "Fail to sign message")
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestVerify(t *testing.T) {
+	privateKey, publicKeyPem := newKey(t)
+	message := Message("channel", "gringotts", "Transfer", 1, 1700000000, `{"fromWalletId":"a","toWalletId":"b","tokenId":"t","amount":"100"}`)
+
+	assert.NilError(t, Verify(publicKeyPem, message, sign(t, privateKey, message)), "Fail to verify signature")
+}
+
+func TestVerifyTampered(t *testing.T) {
+	privateKey, publicKeyPem := newKey(t)
+	signature := sign(t, privateKey, Message("channel", "gringotts", "Transfer", 1, 0, `{"amount":"100"}`))
+
+	assert.Assert(t, Verify(publicKeyPem, Message("channel", "gringotts", "Transfer", 2, 0, `{"amount":"100"}`), signature) != nil, "Replayed nonce must not verify")
+	assert.Assert(t, Verify(publicKeyPem, Message("channel", "gringotts", "Transfer", 1, 0, `{"amount":"900"}`), signature) != nil, "Changed payload must not verify")
+
+	assert.Assert(t, Verify(publicKeyPem, Message("other", "gringotts", "Transfer", 1, 0, `{"amount":"100"}`), signature) != nil, "Other channel must not verify")
+	assert.Assert(t, Verify(publicKeyPem, Message("channel", "other", "Transfer", 1, 0, `{"amount":"100"}`), signature) != nil, "Other chaincode must not verify")
+
+	_, otherKeyPem := newKey(t)
+	assert.Assert(t, Verify(otherKeyPem, Message("channel", "gringotts", "Transfer", 1, 0, `{"amount":"100"}`), signature) != nil, "Other key must not verify")
+}
+
+func TestParsePublicKeyInvalid(t *testing.T) {
+	_, err := ParsePublicKey("not a key")
+	assert.Assert(t, err != nil, "Invalid key must not parse")
+}
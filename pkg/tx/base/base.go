@@ -47,7 +47,7 @@ func (b TxBase) TxHandlerTransfer(ctx contractapi.TransactionContextInterface, m
 		return tx, errors.New("From/To wallet id invalidate")
 	}
 
-	if err := b.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+	if err := b.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
 		glogger.GetInstance().Errorf(ctx, "TxHandler - Base - Transaction (%s): Unable to sub temp amount of From wallet", tx.Id)
 		tx.Status = transaction.Rejected
 		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
@@ -0,0 +1,79 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tx
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Flusher is implemented by the handlers caching documents other than balances across the transactions of a batch.
+// Flush write the cached documents once at the end of the batch.
+type Flusher interface {
+	Flush(ctx contractapi.TransactionContextInterface) error
+}
+
+// Batch keeps one handler per transaction type for the transactions settled or released together, so a document
+// updated by several transactions of the batch (like the original transfer of several reversals) is read once
+// from the state database and written once by Flush.
+type Batch struct {
+	handlers map[transaction.Type]Handler
+}
+
+func NewBatch() *Batch {
+	return &Batch{make(map[transaction.Type]Handler)}
+}
+
+// GetTxHandler return the handler of a transaction type for the batch
+func (b *Batch) GetTxHandler(txType transaction.Type) Handler {
+	handler, ok := b.handlers[txType]
+	if !ok {
+		handler = GetTxHandler(txType)
+		if handler == nil {
+			return nil
+		}
+		b.handlers[txType] = handler
+	}
+	return handler
+}
+
+// ReleaseTx release the held amount of a pending transaction of the batch which will not be settled
+func (b *Batch) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	return releaseTx(ctx, b.GetTxHandler(tx.TxType), tx, mapBalanceToken)
+}
+
+// Flush write the documents cached by the handlers of the batch
+func (b *Batch) Flush(ctx contractapi.TransactionContextInterface) error {
+	for _, handler := range b.handlers {
+		if err := flush(ctx, handler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flush(ctx contractapi.TransactionContextInterface, handler Handler) error {
+	flusher, ok := handler.(Flusher)
+	if !ok {
+		return nil
+	}
+	return flusher.Flush(ctx)
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tx
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+// txControl rejects a transaction of a paused or deactivated token, or of a frozen balance, before it is settled.
+// The held amount of a rejected transaction is released.
+type txControl struct {
+	*base.TxBase
+	handler Handler
+}
+
+func withControl(handler Handler) Handler {
+	return &txControl{base.NewTxBase(), handler}
+}
+
+func (t *txControl) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	if err := t.ValidateTokenControl(ctx, tx.FromWallet, tx.FromTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Control - Transaction (%s): From token/wallet is not allowed", tx.Id)
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "From token/wallet is not allowed"))
+	}
+
+	if err := t.ValidateTokenControl(ctx, tx.ToWallet, tx.ToTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Control - Transaction (%s): To token/wallet is not allowed", tx.Id)
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "To token/wallet is not allowed"))
+	}
+
+	return t.handler.AccountingTx(ctx, tx, mapBalanceToken)
+}
+
+// ReleaseTx release the held amount of the transaction by its handler
+func (t *txControl) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	return releaseTx(ctx, t.handler, tx, mapBalanceToken)
+}
+
+// Flush write the documents cached by the handler of the transaction
+func (t *txControl) Flush(ctx contractapi.TransactionContextInterface) error {
+	return flush(ctx, t.handler)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package distribution
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txProRata struct {
+	*base.TxBase
+}
+
+// NewTxProRata handle a payout of a pro-rata distribution to a holder of a snapshot.
+// The payout is paid from the amount held from the distributing wallet when the distribution was created.
+func NewTxProRata() *txProRata {
+	return &txProRata{base.NewTxBase()}
+}
+
+func (t *txProRata) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - ProRata - Transaction (%s): Unable to sub held amount of From wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub held balance of from wallet failed")
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - ProRata - Transaction (%s): Unable to add amount of To wallet", tx.Id)
+		// the payout is not settled, its held amount goes back to the distributing wallet
+		if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - ProRata - Return held amount of (%s) failed with error (%v)", tx.Id, err)
+		}
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Add balance of to wallet failed")
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// ReleaseTx return the held payout to the distributing wallet when the payout is canceled or expired
+func (t *txProRata) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	return t.ReleaseHold(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount)
+}
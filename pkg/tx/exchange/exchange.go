@@ -46,7 +46,12 @@ func (t *txExchange) AccountingTx(ctx contractapi.TransactionContextInterface, t
 		return tx, errors.New("From/To wallet id invalidate")
 	}
 
-	if err := t.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+	// the exchange of an accepted swap is settled from the held amount of both wallets
+	if tx.Note != "" {
+		return t.settleSwap(ctx, tx, mapBalanceToken)
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
 		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to sub temp amount of From wallet", tx.Id)
 		tx.Status = transaction.Rejected
 		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
@@ -58,7 +63,7 @@ func (t *txExchange) AccountingTx(ctx contractapi.TransactionContextInterface, t
 		return tx, errors.WithMessage(err, "Add balance of to wallet failed")
 	}
 
-	if err := t.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
 		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to sub temp amount of To wallet", tx.Id)
 		tx.Status = transaction.Rejected
 		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
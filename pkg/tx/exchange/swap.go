@@ -0,0 +1,129 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/swap"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+// settleSwap move the held amount of the maker to the taker and the held amount of the taker to the maker.
+// When the settlement fails the held amount is released back to each wallet.
+func (t *txExchange) settleSwap(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	swapEntity, err := t.GetSwap(ctx, tx.Note)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to get swap", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if swapEntity.Status != swap.Accepted || swapEntity.TxId != tx.Id {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): swap (%s) has status (%s)", tx.Id, swapEntity.Id, swapEntity.Status)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizSwapInvalidStatus)
+	}
+
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to sub held amount of From wallet", tx.Id)
+		return t.rejectSwap(ctx, tx, mapBalanceToken, swapEntity, errors.WithMessage(err, "Sub balance of from wallet failed"))
+	}
+
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to sub held amount of To wallet", tx.Id)
+		if err := t.AddAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Rollback held amount of transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		return t.rejectSwap(ctx, tx, mapBalanceToken, swapEntity, errors.WithMessage(err, "Sub balance of to wallet failed"))
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to add temp amount of To wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Add balance of to wallet failed")
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Transaction (%s): Unable to add temp amount of From wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Add balance of from wallet failed")
+	}
+
+	if err := t.updateSwap(ctx, swapEntity, swap.Settled); err != nil {
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// rejectSwap release the held amount of both wallets and reject the transaction
+func (t *txExchange) rejectSwap(ctx contractapi.TransactionContextInterface, tx *entity.Transaction,
+	mapBalanceToken map[string]*entity.BalanceCache, swapEntity *entity.Swap, err error) (*entity.Transaction, error) {
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Release hold of From wallet (%s) failed with error (%v)", tx.FromWallet, err)
+	}
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Release hold of To wallet (%s) failed with error (%v)", tx.ToWallet, err)
+	}
+	if err := t.updateSwap(ctx, swapEntity, swap.Rejected); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Update swap of transaction (%s) failed with error (%v)", tx.Id, err)
+	}
+	tx.Status = transaction.Rejected
+	return tx, err
+}
+
+func (t *txExchange) updateSwap(ctx contractapi.TransactionContextInterface, swapEntity *entity.Swap, status swap.Status) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	swapEntity.Status = status
+	swapEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, swapEntity, doc.Swap, helper.SwapKey(swapEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Exchange - Update swap (%s) failed with error (%v)", swapEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateSwap)
+	}
+	return nil
+}
+
+// ReleaseTx release the held amount of both wallets of an accepted swap when its transaction is canceled or expired
+func (t *txExchange) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	if tx.Note == "" {
+		return nil
+	}
+
+	swapEntity, err := t.GetSwap(ctx, tx.Note)
+	if err != nil {
+		return err
+	}
+
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		return err
+	}
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		return err
+	}
+	return t.updateSwap(ctx, swapEntity, swap.Canceled)
+}
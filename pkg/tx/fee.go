@@ -0,0 +1,126 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tx
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/fee"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+// txFee charges the fee schedule of the token and type of a transaction around its handler.
+// The fee is taken from the from wallet before the transaction is settled and given back when the settlement fails,
+// so a transaction is either settled with its fee or not at all. Transactions from the system wallet have no fee.
+// The fee is always taken from the spot balance, also for a transaction settled from held amount such as a marketplace
+// settlement or a swap: the held amount only covers the price. A transaction rejected for its fee has its held amount
+// released.
+type txFee struct {
+	*base.TxBase
+	handler Handler
+}
+
+func withFee(handler Handler) Handler {
+	return &txFee{base.NewTxBase(), handler}
+}
+
+func (t *txFee) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	if tx.FromWallet == glossary.SystemWallet {
+		return t.accounting(ctx, tx, mapBalanceToken)
+	}
+
+	schedule, isExisted, err := t.GetAndCheckExistFeeSchedule(ctx, tx.FromTokenId, tx.TxType)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Transaction (%s): Unable to get fee schedule", tx.Id)
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "Get fee schedule failed"))
+	}
+
+	if !isExisted || schedule.Status != glossary.Active {
+		return t.accounting(ctx, tx, mapBalanceToken)
+	}
+
+	feeAmount, err := fee.Calculate(schedule, tx.FromTokenAmount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Transaction (%s): Unable to calculate fee", tx.Id)
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "Calculate fee failed"))
+	}
+
+	if helper.CompareStringBalance(feeAmount, "0") <= 0 {
+		return t.accounting(ctx, tx, mapBalanceToken)
+	}
+
+	feeTokenId := schedule.FeeTokenId
+	if feeTokenId == "" {
+		feeTokenId = tx.FromTokenId
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, feeTokenId, feeAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Transaction (%s): Unable to sub fee of From wallet", tx.Id)
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "Sub fee of from wallet failed"))
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, schedule.FeeWallet, feeTokenId, feeAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Transaction (%s): Unable to add fee to fee wallet", tx.Id)
+		if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, feeTokenId, feeAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Rollback fee of transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		return rejectTx(ctx, t.handler, tx, mapBalanceToken, errors.WithMessage(err, "Add fee to fee wallet failed"))
+	}
+
+	txUpdate, err := t.accounting(ctx, tx, mapBalanceToken)
+	if err != nil {
+		if err := t.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, schedule.FeeWallet, feeTokenId, feeAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Rollback fee of transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, feeTokenId, feeAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Fee - Rollback fee of transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		return txUpdate, err
+	}
+
+	txUpdate.FeeAmount = feeAmount
+	txUpdate.FeeTokenId = feeTokenId
+	txUpdate.FeeWallet = schedule.FeeWallet
+	return txUpdate, nil
+}
+
+// accounting settle the transaction by its handler, a handler returning no transaction keeps the one given
+func (t *txFee) accounting(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	txUpdate, err := t.handler.AccountingTx(ctx, tx, mapBalanceToken)
+	if txUpdate == nil {
+		txUpdate = tx
+	}
+	return txUpdate, err
+}
+
+// ReleaseTx release the held amount of the transaction by its handler
+func (t *txFee) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	return releaseTx(ctx, t.handler, tx, mapBalanceToken)
+}
+
+// Flush write the documents cached by the handler of the transaction
+func (t *txFee) Flush(ctx contractapi.TransactionContextInterface) error {
+	return flush(ctx, t.handler)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package htlc
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/htlc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txHtlc struct {
+	*base.TxBase
+}
+
+// ReleaseTx put the hashed time-locked transfer back to locked when the claim or refund is not settled.
+// The amount stays held by the lock. A revealed preimage is kept, so the lock can only be claimed again.
+func (t *txHtlc) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, _ map[string]*entity.BalanceCache) error {
+	lockEntity, err := t.GetHashLock(ctx, tx.Note)
+	if err != nil {
+		return err
+	}
+
+	if lockEntity.TxId != tx.Id {
+		return nil
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	lockEntity.Status = htlc.Locked
+	lockEntity.TxId = ""
+	lockEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, lockEntity, doc.HashLock, helper.HashLockKey(lockEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Htlc - Update hash lock (%s) failed with error (%v)", lockEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateHashLock)
+	}
+	return nil
+}
+
+// reject put the lock back to locked and reject the transaction
+func (t *txHtlc) reject(ctx contractapi.TransactionContextInterface, tx *entity.Transaction,
+	mapBalanceToken map[string]*entity.BalanceCache, err error) (*entity.Transaction, error) {
+	if err := t.ReleaseTx(ctx, tx, mapBalanceToken); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Htlc - Release hash lock (%s) failed with error (%v)", tx.Note, err)
+	}
+	tx.Status = transaction.Rejected
+	return tx, err
+}
+
+type txHtlcClaim struct {
+	txHtlc
+}
+
+// NewTxHtlcClaim handle the claim of a hashed time-locked transfer. The held amount of the sender is paid
+// to the spot balance of the receiver.
+func NewTxHtlcClaim() *txHtlcClaim {
+	return &txHtlcClaim{txHtlc{base.NewTxBase()}}
+}
+
+func (t *txHtlcClaim) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - HtlcClaim - Transaction (%s): Unable to sub held amount of From wallet", tx.Id)
+		return t.reject(ctx, tx, mapBalanceToken, errors.WithMessage(err, "Sub held balance of from wallet failed"))
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - HtlcClaim - Transaction (%s): Unable to add amount of To wallet", tx.Id)
+		if err := t.AddAmount(ctx, mapBalanceToken, doc.EscrowBalances, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - HtlcClaim - Rollback held amount of (%s) failed with error (%v)", tx.Id, err)
+		}
+		return t.reject(ctx, tx, mapBalanceToken, errors.WithMessage(err, "Add balance of to wallet failed"))
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+type txHtlcRefund struct {
+	txHtlc
+}
+
+// NewTxHtlcRefund handle the refund of a hashed time-locked transfer. The held amount is returned
+// to the spot balance of the sender.
+func NewTxHtlcRefund() *txHtlcRefund {
+	return &txHtlcRefund{txHtlc{base.NewTxBase()}}
+}
+
+func (t *txHtlcRefund) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - HtlcRefund - Transaction (%s): Unable to release held amount of From wallet", tx.Id)
+		return t.reject(ctx, tx, mapBalanceToken, errors.WithMessage(err, "Release held balance of from wallet failed"))
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
@@ -21,8 +21,10 @@ package iao
 
 import (
 	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
 	"github.com/Akachain/gringotts/glossary/doc"
 	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
 	"github.com/Akachain/gringotts/pkg/tx/base"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
@@ -45,7 +47,54 @@ func (t *txDistribution) AccountingTx(ctx contractapi.TransactionContextInterfac
 		tx.Status = transaction.Rejected
 		return tx, errors.WithMessage(err, "Add balance of to wallet failed")
 	}
+
+	if err := t.lockVesting(ctx, tx, mapBalanceToken); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxDistribution - Transaction (%s): Unable to lock vesting of To wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Lock vesting of to wallet failed")
+	}
 	tx.Status = transaction.Confirmed
 
 	return tx, nil
 }
+
+// lockVesting locks the distributed amount when the Iao of transaction (Note) has a vesting plan.
+// The grant starts at the settlement time.
+func (t *txDistribution) lockVesting(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	if tx.Note == "" {
+		return nil
+	}
+
+	iaoEntity, err := t.GetIao(ctx, tx.Note)
+	if err != nil {
+		return err
+	}
+	if iaoEntity.Vesting.IsEmpty() {
+		return nil
+	}
+
+	assetEntity, err := t.GetAsset(ctx, iaoEntity.AssetId)
+	if err != nil {
+		return err
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.LockedBalances, tx.ToWallet, tx.ToTokenId, tx.ToTokenAmount); err != nil {
+		return err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	vestingEntity := entity.NewVesting(ctx)
+	vestingEntity.Id = helper.GenerateID(doc.Vesting, tx.Id)
+	vestingEntity.WalletId = tx.ToWallet
+	vestingEntity.TokenId = tx.ToTokenId
+	vestingEntity.Total = tx.ToTokenAmount
+	vestingEntity.StartTime = txTime.Seconds
+	vestingEntity.Plan = iaoEntity.Vesting
+	vestingEntity.IssuerWallet = assetEntity.OwnerWallet
+	vestingEntity.IaoId = iaoEntity.Id
+	if err := t.Repo.Create(ctx, vestingEntity, doc.Vesting, helper.VestingKey(vestingEntity.WalletId, vestingEntity.TokenId, vestingEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxDistribution - Create vesting failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableCreateVesting)
+	}
+	return nil
+}
@@ -22,12 +22,14 @@ package nft_transfer
 import (
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/glossary/doc"
 	"github.com/Akachain/gringotts/glossary/transaction"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
 	"github.com/Akachain/gringotts/pkg/tx/base"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
 )
 
 type txNftTransfer struct {
@@ -39,34 +41,46 @@ func NewTxNftTransfer() *txNftTransfer {
 }
 
 func (t *txNftTransfer) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
-	// TODO: handle rollback map current balance when sub/add failed
-	txUpdate, err := t.TxHandlerTransfer(ctx, mapBalanceToken, tx)
-	if err != nil {
-		glogger.GetInstance().Errorf(ctx, "NftTransfer - Handler token transfer failed with err (%s)", err.Error())
-		return txUpdate, err
+	if tx.FromWallet == glossary.SystemWallet || tx.ToWallet == glossary.SystemWallet {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - Transaction (%s) has from/to wallet Id is system type", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.New("From/To wallet id invalidate")
 	}
 
 	// handler owner of nft
 	nftToken, err := t.GetNFT(ctx, tx.ToTokenId)
 	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "NftTransfer - Get NftToken failed with error (%s)", err.Error())
-		txUpdate.Status = transaction.Rejected
-		return txUpdate, err
+		tx.Status = transaction.Rejected
+		return tx, err
 	}
 
 	if nftToken.OwnerId != tx.ToWallet {
 		glogger.GetInstance().Error(ctx, "NftTransfer - To wallet not match owner of nft token")
-		txUpdate.Status = transaction.Rejected
-		return txUpdate, helper.RespError(errorcode.BizNftNotPermission)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	if nftToken.LockedBy != "" {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - NftToken is locked by (%s)", nftToken.LockedBy)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizNftLocked)
+	}
+
+	// the buyer pays the seller and the royalty wallet of nft token
+	if err := payWithRoyalty(ctx, t.TxBase, mapBalanceToken, tx, nftToken, doc.SpotBalances); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - Handler payment failed with err (%s)", err.Error())
+		tx.Status = transaction.Rejected
+		return tx, err
 	}
 
 	nftToken.OwnerId = tx.FromWallet
 	if err := t.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
 		glogger.GetInstance().Errorf(ctx, "TransferFrom - Update NftToken failed with error (%v)", err)
-		txUpdate.Status = transaction.Rejected
-		return txUpdate, helper.RespError(errorcode.BizUnableUpdateNFT)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizUnableUpdateNFT)
 	}
 
-	txUpdate.Status = transaction.Confirmed
-	return txUpdate, nil
+	tx.Status = transaction.Confirmed
+	return tx, nil
 }
@@ -0,0 +1,83 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package nft_transfer
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+// payWithRoyalty moves the price of nft token from the buyer balance in domain to the seller spot balance.
+// The royalty part of the price is credited to the royalty wallet of nft token in the same settlement,
+// the royalty wallet must be active so the royalty is not credited to a wallet nobody can spend from.
+func payWithRoyalty(ctx contractapi.TransactionContextInterface, txBase *base.TxBase, mapBalanceToken map[string]*entity.BalanceCache,
+	tx *entity.Transaction, nftToken *entity.NFT, domain string) error {
+	royaltyAmount, err := helper.BasisPointBalance(tx.FromTokenAmount, nftToken.RoyaltyBps)
+	if err != nil {
+		return errors.WithMessage(err, "Calculate royalty failed")
+	}
+
+	sellerAmount, err := helper.SubBalance(tx.FromTokenAmount, royaltyAmount)
+	if err != nil {
+		return errors.WithMessage(err, "Calculate seller amount failed")
+	}
+
+	if helper.CompareStringBalance(royaltyAmount, "0") > 0 {
+		if _, err := txBase.GetActiveWallet(ctx, nftToken.RoyaltyWalletId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "NftTransfer - Transaction (%s): Royalty wallet (%s) is not active", tx.Id, nftToken.RoyaltyWalletId)
+			return errors.WithMessage(err, "Royalty wallet is not active")
+		}
+	}
+
+	if err := txBase.SubWalletAmount(ctx, mapBalanceToken, domain, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - Transaction (%s): Unable to sub amount of buyer wallet", tx.Id)
+		return errors.WithMessage(err, "Sub balance of from wallet failed")
+	}
+
+	if err := txBase.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.FromTokenId, sellerAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - Transaction (%s): Unable to add amount of seller wallet", tx.Id)
+		if err := txBase.AddAmount(ctx, mapBalanceToken, domain, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "NftTransfer - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		return errors.WithMessage(err, "Add balance of to wallet failed")
+	}
+
+	if helper.CompareStringBalance(royaltyAmount, "0") <= 0 {
+		return nil
+	}
+
+	if err := txBase.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, nftToken.RoyaltyWalletId, tx.FromTokenId, royaltyAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftTransfer - Transaction (%s): Unable to add royalty amount of royalty wallet", tx.Id)
+		if err := txBase.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.ToWallet, tx.FromTokenId, sellerAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "NftTransfer - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		if err := txBase.AddAmount(ctx, mapBalanceToken, domain, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+			glogger.GetInstance().Errorf(ctx, "NftTransfer - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		return errors.WithMessage(err, "Add balance of royalty wallet failed")
+	}
+
+	return nil
+}
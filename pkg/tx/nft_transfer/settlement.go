@@ -0,0 +1,110 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package nft_transfer
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	serviceBase "github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type txNftSettlement struct {
+	*base.TxBase
+}
+
+// NewTxNftSettlement handle the settlement of a filled listing or offer in the marketplace.
+// The buyer pays from the escrow balance and the nft token is released from the listing or offer.
+func NewTxNftSettlement() *txNftSettlement {
+	return &txNftSettlement{base.NewTxBase()}
+}
+
+func (t *txNftSettlement) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	nftToken, err := t.GetNFT(ctx, tx.ToTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - Get NftToken failed with error (%s)", err.Error())
+		return t.reject(ctx, tx, mapBalanceToken, err)
+	}
+
+	if nftToken.OwnerId != tx.ToWallet || nftToken.LockedBy != tx.Note {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - NftToken (%s) is not held by (%s)", nftToken.Id, tx.Note)
+		return t.reject(ctx, tx, mapBalanceToken, helper.RespError(errorcode.BizNftNotPermission))
+	}
+
+	// the buyer pays from the escrow balance held when the listing or offer was filled,
+	// a failed payment leaves no partial credit of the seller or royalty wallet before the price is released
+	balances := serviceBase.SnapshotBalances(mapBalanceToken)
+	if err := payWithRoyalty(ctx, t.TxBase, mapBalanceToken, tx, nftToken, doc.EscrowBalances); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - Handler payment failed with err (%s)", err.Error())
+		serviceBase.RestoreBalances(mapBalanceToken, balances)
+		return t.reject(ctx, tx, mapBalanceToken, err)
+	}
+
+	nftToken.OwnerId = tx.FromWallet
+	nftToken.LockedBy = ""
+	if err := t.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - Update NftToken failed with error (%v)", err)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizUnableUpdateNFT)
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// reject return the held price to the spot balance of the buyer, unlock the nft token and reject the transaction
+func (t *txNftSettlement) reject(ctx contractapi.TransactionContextInterface, tx *entity.Transaction,
+	mapBalanceToken map[string]*entity.BalanceCache, err error) (*entity.Transaction, error) {
+	if err := t.ReleaseTx(ctx, tx, mapBalanceToken); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - Release transaction (%s) failed with error (%v)", tx.Id, err)
+	}
+	tx.Status = transaction.Rejected
+	return tx, err
+}
+
+// ReleaseTx return the held price to the buyer and unlock the nft token when the settlement is canceled or expired
+func (t *txNftSettlement) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	if err := t.ReleaseHold(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		return err
+	}
+
+	nftToken, err := t.GetNFT(ctx, tx.ToTokenId)
+	if err != nil {
+		return err
+	}
+
+	if nftToken.LockedBy != tx.Note {
+		return nil
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	nftToken.LockedBy = ""
+	nftToken.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "NftSettlement - Unlock NftToken failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateNFT)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pool
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/amm"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txAddLiquidity struct {
+	*base.TxBase
+}
+
+// NewTxAddLiquidity handle the deposit of FromTokenAmount of token A and ToTokenAmount of token B into the pool.
+// The amounts are updated to the part taken at the price of the pool and lp token is minted to the provider.
+func NewTxAddLiquidity() *txAddLiquidity {
+	return &txAddLiquidity{base.NewTxBase()}
+}
+
+func (t *txAddLiquidity) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	poolEntity, err := getActivePool(ctx, t.TxBase, tx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): Unable to get pool", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if tx.FromTokenId != poolEntity.TokenA || tx.ToTokenId != poolEntity.TokenB || poolEntity.LpTokenId == "" {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): tokens are not in pool (%s)", tx.Id, poolEntity.Id)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizPoolInvalidToken)
+	}
+
+	reserveA, reserveB, totalLiquidity, err := reserves(ctx, t.TxBase, mapBalanceToken, poolEntity)
+	if err != nil {
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	usedA, usedB, liquidity, err := amm.AddLiquidity(reserveA, reserveB, totalLiquidity, tx.FromTokenAmount, tx.ToTokenAmount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): calculate liquidity failed (%v)", tx.Id, err)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizUnableCalculatePool)
+	}
+	tx.FromTokenAmount = usedA
+	tx.ToTokenAmount = usedB
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, usedA); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): Unable to sub token A of From wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.ToTokenId, usedB); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): Unable to sub token B of From wallet", tx.Id)
+		if err := t.RollbackTxHandler(ctx, tx, mapBalanceToken, transaction.SubFromWallet); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
+	}
+
+	if err := t.deposit(ctx, mapBalanceToken, tx, poolEntity, liquidity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxAddLiquidity - Transaction (%s): deposit into pool failed (%v)", tx.Id, err)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// deposit add the tokens to the reserves of the pool and mint liquidity to the provider
+func (t *txAddLiquidity) deposit(ctx contractapi.TransactionContextInterface, mapBalanceToken map[string]*entity.BalanceCache,
+	tx *entity.Transaction, poolEntity *entity.Pool, liquidity string) error {
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenA, tx.FromTokenAmount); err != nil {
+		return err
+	}
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenB, tx.ToTokenAmount); err != nil {
+		return err
+	}
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.LpTokenId, liquidity); err != nil {
+		return err
+	}
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, poolEntity.LpTokenId, liquidity); err != nil {
+		return err
+	}
+	return updateLpSupply(ctx, t.TxBase, mapBalanceToken, poolEntity)
+}
+
+type txRemoveLiquidity struct {
+	*base.TxBase
+}
+
+// NewTxRemoveLiquidity handle the burn of FromTokenAmount of lp token for the share of the reserves of the pool
+func NewTxRemoveLiquidity() *txRemoveLiquidity {
+	return &txRemoveLiquidity{base.NewTxBase()}
+}
+
+func (t *txRemoveLiquidity) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	poolEntity, err := getActivePool(ctx, t.TxBase, tx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Transaction (%s): Unable to get pool", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if tx.FromTokenId != poolEntity.LpTokenId || poolEntity.LpTokenId == "" {
+		glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Transaction (%s): token is not lp token of pool (%s)", tx.Id, poolEntity.Id)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizPoolInvalidToken)
+	}
+
+	reserveA, reserveB, totalLiquidity, err := reserves(ctx, t.TxBase, mapBalanceToken, poolEntity)
+	if err != nil {
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	amountA, amountB, err := amm.RemoveLiquidity(reserveA, reserveB, totalLiquidity, tx.FromTokenAmount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Transaction (%s): calculate withdrawal failed (%v)", tx.Id, err)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizUnableCalculatePool)
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Transaction (%s): Unable to sub lp token of From wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
+	}
+
+	if err := t.withdraw(ctx, mapBalanceToken, tx, poolEntity, amountA, amountB); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Transaction (%s): withdraw from pool failed (%v)", tx.Id, err)
+		if err := t.RollbackTxHandler(ctx, tx, mapBalanceToken, transaction.SubFromWallet); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxRemoveLiquidity - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// withdraw burn liquidity of the pool and pay the share of the reserves to the provider
+func (t *txRemoveLiquidity) withdraw(ctx contractapi.TransactionContextInterface, mapBalanceToken map[string]*entity.BalanceCache,
+	tx *entity.Transaction, poolEntity *entity.Pool, amountA, amountB string) error {
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.LpTokenId, tx.FromTokenAmount); err != nil {
+		return err
+	}
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenA, amountA); err != nil {
+		return err
+	}
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenB, amountB); err != nil {
+		return err
+	}
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, poolEntity.TokenA, amountA); err != nil {
+		return err
+	}
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, poolEntity.TokenB, amountB); err != nil {
+		return err
+	}
+	return updateLpSupply(ctx, t.TxBase, mapBalanceToken, poolEntity)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pool
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+// getActivePool return the pool of the transaction, the pool id is kept in the note of the transaction
+func getActivePool(ctx contractapi.TransactionContextInterface, txBase *base.TxBase, tx *entity.Transaction) (*entity.Pool, error) {
+	poolEntity, err := txBase.GetPool(ctx, tx.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if poolEntity.Status != glossary.Active {
+		glogger.GetInstance().Errorf(ctx, "TxPool - Transaction (%s): pool (%s) is not active", tx.Id, poolEntity.Id)
+		return nil, helper.RespError(errorcode.BizUnableGetPool)
+	}
+	return poolEntity, nil
+}
+
+// reserves return the current reserves of the pool and its outstanding liquidity
+func reserves(ctx contractapi.TransactionContextInterface, txBase *base.TxBase, mapBalanceToken map[string]*entity.BalanceCache,
+	poolEntity *entity.Pool) (reserveA, reserveB, totalLiquidity string, err error) {
+	if reserveA, err = txBase.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenA); err != nil {
+		return "", "", "", err
+	}
+	if reserveB, err = txBase.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.TokenB); err != nil {
+		return "", "", "", err
+	}
+	if totalLiquidity, err = txBase.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.LpTokenId); err != nil {
+		return "", "", "", err
+	}
+	return reserveA, reserveB, totalLiquidity, nil
+}
+
+// updateLpSupply set the total supply of the lp token to the outstanding liquidity of the pool
+func updateLpSupply(ctx contractapi.TransactionContextInterface, txBase *base.TxBase, mapBalanceToken map[string]*entity.BalanceCache,
+	poolEntity *entity.Pool) error {
+	totalLiquidity, err := txBase.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, poolEntity.LpTokenId)
+	if err != nil {
+		return err
+	}
+
+	tokenType, err := txBase.GetTokenType(ctx, poolEntity.LpTokenId)
+	if err != nil {
+		return err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	tokenType.TotalSupply = totalLiquidity
+	tokenType.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := txBase.Repo.Update(ctx, tokenType, doc.Tokens, helper.TokenKey(tokenType.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxPool - Update total supply of lp token (%s) failed with error (%v)", tokenType.Id, err)
+		return errors.New("Unable to update total supply of lp token on the blockchain")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pool
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/amm"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txPoolSwap struct {
+	*base.TxBase
+}
+
+// NewTxPoolSwap handle the swap of an exact amount of FromTokenId for ToTokenId of the pool.
+// ToTokenAmount is the minimum amount out when the transaction is created and the amount received once it is confirmed.
+func NewTxPoolSwap() *txPoolSwap {
+	return &txPoolSwap{base.NewTxBase()}
+}
+
+func (t *txPoolSwap) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	poolEntity, err := getActivePool(ctx, t.TxBase, tx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): Unable to get pool", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if !(tx.FromTokenId == poolEntity.TokenA && tx.ToTokenId == poolEntity.TokenB) &&
+		!(tx.FromTokenId == poolEntity.TokenB && tx.ToTokenId == poolEntity.TokenA) {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): tokens are not in pool (%s)", tx.Id, poolEntity.Id)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizPoolInvalidToken)
+	}
+
+	reserveIn, err := t.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, tx.FromTokenId)
+	if err != nil {
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+	reserveOut, err := t.CurrentAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, tx.ToTokenId)
+	if err != nil {
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	amountOut, err := amm.SwapOut(reserveIn, reserveOut, tx.FromTokenAmount, poolEntity.FeeBps)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): calculate amount out failed (%v)", tx.Id, err)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizUnableCalculatePool)
+	}
+
+	if helper.CompareStringBalance(amountOut, "0") <= 0 || helper.CompareStringBalance(amountOut, tx.ToTokenAmount) < 0 {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): amount out (%s) lower than minimum (%s)", tx.Id, amountOut, tx.ToTokenAmount)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizPoolSlippage)
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): Unable to sub amount in of From wallet", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
+	}
+
+	if err := t.settle(ctx, mapBalanceToken, tx, poolEntity, amountOut); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Transaction (%s): settle swap failed (%v)", tx.Id, err)
+		if err := t.RollbackTxHandler(ctx, tx, mapBalanceToken, transaction.SubFromWallet); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxPoolSwap - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+		}
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	tx.ToTokenAmount = amountOut
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+// settle move amount in into the reserve of the pool and amount out of the reserve to the wallet
+func (t *txPoolSwap) settle(ctx contractapi.TransactionContextInterface, mapBalanceToken map[string]*entity.BalanceCache,
+	tx *entity.Transaction, poolEntity *entity.Pool, amountOut string) error {
+	if err := t.SubAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, tx.ToTokenId, amountOut); err != nil {
+		return err
+	}
+
+	if err := t.AddAmount(ctx, mapBalanceToken, doc.PoolBalances, poolEntity.Id, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		return err
+	}
+
+	return t.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, tx.FromWallet, tx.ToTokenId, amountOut)
+}
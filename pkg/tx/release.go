@@ -0,0 +1,67 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package tx
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper/glogger"
+	serviceBase "github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Releaser is implemented by the handlers of transactions settled from held amount.
+// ReleaseTx return the held amount to the wallets when the pending transaction is canceled or expired.
+type Releaser interface {
+	ReleaseTx(ctx contractapi.TransactionContextInterface, transaction *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error
+}
+
+// ReleaseTx release the held amount of a single pending transaction which will not be settled.
+// Transaction types without held amount have nothing to release.
+func ReleaseTx(ctx contractapi.TransactionContextInterface, transaction *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	batch := NewBatch()
+	if err := batch.ReleaseTx(ctx, transaction, mapBalanceToken); err != nil {
+		return err
+	}
+	return batch.Flush(ctx)
+}
+
+func releaseTx(ctx contractapi.TransactionContextInterface, handler Handler, transaction *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	releaser, ok := handler.(Releaser)
+	if !ok {
+		return nil
+	}
+	return releaser.ReleaseTx(ctx, transaction, mapBalanceToken)
+}
+
+// rejectTx reject a transaction with err before its handler settled it, its held amount is released like for a
+// canceled transaction. When the release fails the balances are restored and the transaction stays pending,
+// so the held amount is released again by the next accounting.
+func rejectTx(ctx contractapi.TransactionContextInterface, handler Handler, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache, err error) (*entity.Transaction, error) {
+	balances := serviceBase.SnapshotBalances(mapBalanceToken)
+	if releaseErr := releaseTx(ctx, handler, tx, mapBalanceToken); releaseErr != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Release rejected transaction (%s) failed with error (%v)", tx.Id, releaseErr)
+		serviceBase.RestoreBalances(mapBalanceToken, balances)
+		return tx, err
+	}
+
+	tx.Status = transaction.Rejected
+	return tx, err
+}
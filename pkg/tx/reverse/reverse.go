@@ -0,0 +1,91 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package reverse
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txReverse struct {
+	*base.TxBase
+	// originals of the reversals released in the batch, written by Flush
+	originals map[string]*entity.Transaction
+}
+
+// NewTxReverse handle the reversal of a confirmed transfer. The receiver of the transfer pays back the sender.
+// The reversed amount of the original transfer is reserved when the reversal is created and given back when
+// the reversal is rejected, canceled or expired.
+func NewTxReverse() *txReverse {
+	return &txReverse{base.NewTxBase(), make(map[string]*entity.Transaction)}
+}
+
+func (t *txReverse) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	txUpdate, err := t.TxHandlerTransfer(ctx, mapBalanceToken, tx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxHandler - Reverse - Transaction (%s): Unable to pay back the sender", tx.Id)
+		if err := t.ReleaseTx(ctx, tx, mapBalanceToken); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Reverse - Release reversed amount of (%s) failed with error (%v)", tx.Note, err)
+		}
+		return txUpdate, err
+	}
+	return txUpdate, nil
+}
+
+// ReleaseTx give the reserved amount back to the original transfer when the reversal is not settled.
+// The original is cached so several reversals of the same transfer in a batch release from the same amount.
+func (t *txReverse) ReleaseTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) error {
+	original, ok := t.originals[tx.Note]
+	if !ok {
+		var err error
+		if original, err = t.GetTransaction(ctx, tx.Note); err != nil {
+			return err
+		}
+	}
+
+	reversedAmount, err := helper.SubBalance(original.ReversedAmount, tx.FromTokenAmount)
+	if err != nil {
+		return errors.WithMessage(err, "Release reversed amount failed")
+	}
+
+	original.ReversedAmount = reversedAmount
+	t.originals[original.Id] = original
+	return nil
+}
+
+// Flush write the originals released in the batch
+func (t *txReverse) Flush(ctx contractapi.TransactionContextInterface) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	for _, original := range t.originals {
+		original.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+		if err := t.Repo.Update(ctx, original, doc.Transactions, helper.TransactionKey(original.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TxHandler - Reverse - Update transaction (%s) failed with error (%v)", original.Id, err)
+			return helper.RespError(errorcode.BizUnableUpdateTX)
+		}
+	}
+	t.originals = make(map[string]*entity.Transaction)
+	return nil
+}
@@ -50,7 +50,7 @@ func (t *txSideChainTransfer) AccountingTx(ctx contractapi.TransactionContextInt
 	}
 
 	lstNameChain := strings.Split(tx.Note, "_")
-	if err := t.SubAmount(ctx, mapBalanceToken, t.getDomain(lstNameChain[0]), tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+	if err := t.SubWalletAmount(ctx, mapBalanceToken, t.getDomain(lstNameChain[0]), tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
 		glogger.GetInstance().Errorf(ctx, "TxSideChainTransfer - Transaction (%s): Unable to sub temp amount of From wallet", tx.Id)
 		tx.Status = transaction.Rejected
 		return tx, errors.WithMessage(err, "Sub balance of from wallet failed")
@@ -22,16 +22,31 @@ package tx
 import (
 	"github.com/Akachain/gringotts/glossary/transaction"
 	"github.com/Akachain/gringotts/pkg/tx/burn"
+	"github.com/Akachain/gringotts/pkg/tx/distribution"
 	"github.com/Akachain/gringotts/pkg/tx/exchange"
+	"github.com/Akachain/gringotts/pkg/tx/htlc"
 	"github.com/Akachain/gringotts/pkg/tx/iao"
 	"github.com/Akachain/gringotts/pkg/tx/issue"
 	"github.com/Akachain/gringotts/pkg/tx/mint"
 	"github.com/Akachain/gringotts/pkg/tx/nft_transfer"
+	"github.com/Akachain/gringotts/pkg/tx/pool"
+	"github.com/Akachain/gringotts/pkg/tx/reverse"
 	"github.com/Akachain/gringotts/pkg/tx/sidechain_transfer"
 	"github.com/Akachain/gringotts/pkg/tx/transfer"
+	"github.com/Akachain/gringotts/pkg/tx/vault"
 )
 
+// GetTxHandler return the handler of a transaction type. The token control is checked before the transaction
+// and the fee schedule of the transaction is charged around it
 func GetTxHandler(txType transaction.Type) Handler {
+	handler := getHandler(txType)
+	if handler == nil {
+		return nil
+	}
+	return withControl(withFee(handler))
+}
+
+func getHandler(txType transaction.Type) Handler {
 	switch txType {
 	case transaction.Transfer:
 		return transfer.NewTxTransfer()
@@ -53,6 +68,24 @@ func GetTxHandler(txType transaction.Type) Handler {
 		return iao.NewTxDistribution()
 	case transaction.ReturnST:
 		return iao.NewTxReturn()
+	case transaction.NftSettlement:
+		return nft_transfer.NewTxNftSettlement()
+	case transaction.RedeemNft:
+		return vault.NewTxRedeem()
+	case transaction.PoolSwap:
+		return pool.NewTxPoolSwap()
+	case transaction.PoolAddLiquidity:
+		return pool.NewTxAddLiquidity()
+	case transaction.PoolRemoveLiquidity:
+		return pool.NewTxRemoveLiquidity()
+	case transaction.Reverse:
+		return reverse.NewTxReverse()
+	case transaction.HtlcClaim:
+		return htlc.NewTxHtlcClaim()
+	case transaction.HtlcRefund:
+		return htlc.NewTxHtlcRefund()
+	case transaction.ProRataDistribution:
+		return distribution.NewTxProRata()
 	default:
 		return nil
 	}
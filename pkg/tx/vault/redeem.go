@@ -0,0 +1,126 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package vault
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/glossary/vault"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/tx/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/pkg/errors"
+)
+
+type txRedeem struct {
+	*base.TxBase
+}
+
+func NewTxRedeem() *txRedeem {
+	return &txRedeem{base.NewTxBase()}
+}
+
+// AccountingTx burns the outstanding supply of the shares of the vault from the redeemer and releases the nft token to the redeemer
+func (t *txRedeem) AccountingTx(ctx contractapi.TransactionContextInterface, tx *entity.Transaction, mapBalanceToken map[string]*entity.BalanceCache) (*entity.Transaction, error) {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	vaultEntity, err := t.GetVault(ctx, tx.Note)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to get vault", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	// decrease total supply of share token on the blockchain, the redeemer burns all of it
+	tokenType, err := t.GetTokenType(ctx, tx.FromTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to get token type", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if vaultEntity.Status != vault.Locked || vaultEntity.ShareTokenId != tx.FromTokenId ||
+		helper.CompareStringBalance(tokenType.TotalSupply, tx.FromTokenAmount) != 0 {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): vault (%s) is not redeemable", tx.Id, vaultEntity.Id)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizVaultInvalidStatus)
+	}
+
+	nftToken, err := t.GetNFT(ctx, vaultEntity.NftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to get nft token", tx.Id)
+		tx.Status = transaction.Rejected
+		return tx, err
+	}
+
+	if nftToken.OwnerId != vaultEntity.WalletId || nftToken.LockedBy != vaultEntity.Id {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): nft token is not held by vault (%s)", tx.Id, vaultEntity.Id)
+		tx.Status = transaction.Rejected
+		return tx, helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	if err := t.SubSpendableAmount(ctx, mapBalanceToken, tx.FromWallet, tx.FromTokenId, tx.FromTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): sub shares failed (%s)", tx.Id, err.Error())
+		tx.Status = transaction.Rejected
+		return tx, errors.WithMessage(err, "Sub shares of redeemer wallet failed")
+	}
+
+	totalSupplyUpdated, err := helper.SubBalance(tokenType.TotalSupply, tx.FromTokenAmount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to decrease total supply of token", tx.Id)
+		return t.rollback(ctx, tx, mapBalanceToken, err)
+	}
+	tokenType.TotalSupply = totalSupplyUpdated
+	tokenType.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, tokenType, doc.Tokens, helper.TokenKey(tokenType.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to update token type (%s)", tx.Id, err.Error())
+		return t.rollback(ctx, tx, mapBalanceToken, errors.New("Unable to decrease total of token on the blockchain"))
+	}
+
+	nftToken.OwnerId = tx.FromWallet
+	nftToken.LockedBy = ""
+	nftToken.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to update nft token (%v)", tx.Id, err)
+		return t.rollback(ctx, tx, mapBalanceToken, helper.RespError(errorcode.BizUnableUpdateNFT))
+	}
+
+	vaultEntity.RedeemerWalletId = tx.FromWallet
+	vaultEntity.Status = vault.Redeemed
+	vaultEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, vaultEntity, doc.Vault, helper.VaultKey(vaultEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Transaction (%s): Unable to update vault (%v)", tx.Id, err)
+		return t.rollback(ctx, tx, mapBalanceToken, helper.RespError(errorcode.BizUnableUpdateVault))
+	}
+
+	tx.Status = transaction.Confirmed
+	return tx, nil
+}
+
+func (t *txRedeem) rollback(ctx contractapi.TransactionContextInterface, tx *entity.Transaction,
+	mapBalanceToken map[string]*entity.BalanceCache, err error) (*entity.Transaction, error) {
+	if err := t.RollbackTxHandler(ctx, tx, mapBalanceToken, transaction.SubFromWallet); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TxRedeem - Rollback handle transaction (%s) failed with error (%v)", tx.Id, err)
+	}
+	tx.Status = transaction.Rejected
+	return tx, err
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package unit
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Price is an exact price of one base unit of a token in base units of another token.
+// It is parsed from a rational "numerator/denominator" (3/7) or a decimal (0.5) string.
+type Price struct {
+	*big.Rat
+}
+
+// NewPriceFromString return the price of a rational or decimal string, the price must be greater than zero
+func NewPriceFromString(priceS string) (*Price, error) {
+	rat, ok := new(big.Rat).SetString(priceS)
+	if !ok {
+		return nil, errors.New("price must be a rational numerator/denominator or a decimal")
+	}
+	if rat.Sign() <= 0 {
+		return nil, errors.New("price must be greater than zero")
+	}
+	return &Price{rat}, nil
+}
+
+// Cost return the cost of amount at the price. The cost is rounded up to the base unit,
+// so the buyer never pays less than the exact price and rounding never favors the buyer.
+func (p *Price) Cost(amount *BalanceUnit) (*BalanceUnit, error) {
+	if amount == nil || amount.Sign() < 0 {
+		return nil, errors.New("invalidate amount unit")
+	}
+
+	numerator := new(big.Int).Mul(amount.Int, p.Num())
+	cost, remainder := new(big.Int).QuoRem(numerator, p.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		cost.Add(cost, big.NewInt(1))
+	}
+	return &BalanceUnit{cost}, nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package unit
+
+import (
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestNewPriceFromString(t *testing.T) {
+	price, err := NewPriceFromString("0.5")
+	assert.NilError(t, err, "Fail to parse decimal price")
+	assert.Equal(t, price.String(), "1/2")
+
+	price, err = NewPriceFromString("6/14")
+	assert.NilError(t, err, "Fail to parse rational price")
+	assert.Equal(t, price.String(), "3/7")
+
+	_, err = NewPriceFromString("0")
+	assert.Assert(t, err != nil)
+
+	_, err = NewPriceFromString("-1/2")
+	assert.Assert(t, err != nil)
+
+	_, err = NewPriceFromString("half")
+	assert.Assert(t, err != nil)
+}
+
+func TestPriceCost(t *testing.T) {
+	price, _ := NewPriceFromString("3/7")
+
+	// 7 * 3 / 7 is exact
+	cost, err := price.Cost(NewBalanceUnitFromString("7"))
+	assert.NilError(t, err, "Fail to calculate cost")
+	assert.Equal(t, cost.String(), "3")
+
+	// 10 * 3 / 7 = 4.28 is rounded up
+	cost, err = price.Cost(NewBalanceUnitFromString("10"))
+	assert.NilError(t, err, "Fail to calculate cost")
+	assert.Equal(t, cost.String(), "5")
+
+	price, _ = NewPriceFromString("0.5")
+	cost, err = price.Cost(NewBalanceUnitFromString("3"))
+	assert.NilError(t, err, "Fail to calculate cost")
+	assert.Equal(t, cost.String(), "2")
+}
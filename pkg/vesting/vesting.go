@@ -0,0 +1,113 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package vesting contains the calculation of vested amounts of vesting grants.
+// All amounts are strings in base unit, times are unix seconds and vested amounts are rounded down to the base unit.
+package vesting
+
+import (
+	"errors"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/vesting"
+	"github.com/Akachain/gringotts/helper"
+	"strconv"
+)
+
+// Validate return an error if the plan can not unlock its total
+func Validate(plan entity.VestingPlan) error {
+	if !plan.VestingType.IsValidate() {
+		return errors.New("invalidate vesting type")
+	}
+	if plan.Cliff < 0 || plan.Duration < 0 || plan.Step < 0 {
+		return errors.New("Cliff/Duration/Step must not be negative")
+	}
+
+	switch plan.VestingType {
+	case vesting.Linear:
+		if plan.Duration <= 0 || plan.Cliff > plan.Duration {
+			return errors.New("Duration must be greater than zero and not less than Cliff")
+		}
+	case vesting.Step:
+		if plan.Duration <= 0 || plan.Cliff > plan.Duration {
+			return errors.New("Duration must be greater than zero and not less than Cliff")
+		}
+		if plan.Step <= 0 || plan.Step > plan.Duration {
+			return errors.New("Step must be greater than zero and not greater than Duration")
+		}
+	}
+	return nil
+}
+
+// Vested return the amount of total unlocked at now for a grant starting at start.
+func Vested(plan entity.VestingPlan, total string, start, now int64) (string, error) {
+	if helper.CompareStringBalance(total, "0") < 0 {
+		return "", errors.New("invalidate amount")
+	}
+
+	elapsed := now - start
+	if elapsed < plan.Cliff {
+		return "0", nil
+	}
+
+	switch plan.VestingType {
+	case vesting.Cliff:
+		return total, nil
+	case vesting.Linear:
+		if elapsed >= plan.Duration {
+			return total, nil
+		}
+		return helper.ProRataBalance(total, strconv.FormatInt(elapsed, 10), strconv.FormatInt(plan.Duration, 10))
+	case vesting.Step:
+		if elapsed >= plan.Duration {
+			return total, nil
+		}
+		unlocked := elapsed / plan.Step * plan.Step
+		return helper.ProRataBalance(total, strconv.FormatInt(unlocked, 10), strconv.FormatInt(plan.Duration, 10))
+	}
+	return "", errors.New("invalidate vesting type")
+}
+
+// Unvested return the amount of the grant still locked at now, the amount revoked by the issuer is not locked anymore.
+func Unvested(grant *entity.Vesting, now int64) (string, error) {
+	at := now
+	if grant.RevokedAt > 0 && grant.RevokedAt < now {
+		at = grant.RevokedAt
+	}
+
+	vested, err := Vested(grant.Plan, grant.Total, grant.StartTime, at)
+	if err != nil {
+		return "", err
+	}
+
+	unvested, err := helper.SubBalance(grant.Total, vested)
+	if err != nil {
+		return "", err
+	}
+	if helper.CompareStringBalance(unvested, orZero(grant.Revoked)) <= 0 {
+		return "0", nil
+	}
+	return helper.SubBalance(unvested, orZero(grant.Revoked))
+}
+
+func orZero(amount string) string {
+	if amount == "" {
+		return "0"
+	}
+	return amount
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package vesting
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/vesting"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NilError(t, Validate(entity.VestingPlan{VestingType: vesting.Cliff, Cliff: 100}))
+	assert.NilError(t, Validate(entity.VestingPlan{VestingType: vesting.Linear, Cliff: 100, Duration: 1000}))
+	assert.NilError(t, Validate(entity.VestingPlan{VestingType: vesting.Step, Duration: 1000, Step: 250}))
+
+	assert.Assert(t, Validate(entity.VestingPlan{VestingType: "Monthly"}) != nil)
+	assert.Assert(t, Validate(entity.VestingPlan{VestingType: vesting.Linear, Cliff: 2000, Duration: 1000}) != nil)
+	assert.Assert(t, Validate(entity.VestingPlan{VestingType: vesting.Step, Duration: 1000}) != nil)
+}
+
+func TestVestedCliff(t *testing.T) {
+	plan := entity.VestingPlan{VestingType: vesting.Cliff, Cliff: 100}
+
+	vested, err := Vested(plan, "1000", 500, 599)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "0")
+
+	vested, err = Vested(plan, "1000", 500, 600)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "1000")
+}
+
+func TestVestedLinear(t *testing.T) {
+	plan := entity.VestingPlan{VestingType: vesting.Linear, Cliff: 100, Duration: 1000}
+
+	// nothing is unlocked before the cliff
+	vested, err := Vested(plan, "3000", 0, 99)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "0")
+
+	// the amount accrued during the cliff is unlocked at the cliff
+	vested, err = Vested(plan, "3000", 0, 100)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "300")
+
+	vested, err = Vested(plan, "1000", 0, 333)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "333")
+
+	vested, err = Vested(plan, "3000", 0, 5000)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "3000")
+}
+
+func TestVestedStep(t *testing.T) {
+	plan := entity.VestingPlan{VestingType: vesting.Step, Duration: 1000, Step: 250}
+
+	vested, err := Vested(plan, "1000", 0, 249)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "0")
+
+	vested, err = Vested(plan, "1000", 0, 740)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "500")
+
+	vested, err = Vested(plan, "1000", 0, 1000)
+	assert.NilError(t, err, "Fail to calculate vested amount")
+	assert.Equal(t, vested, "1000")
+}
+
+func TestUnvestedRevoked(t *testing.T) {
+	grant := &entity.Vesting{
+		Total:     "1000",
+		Plan:      entity.VestingPlan{VestingType: vesting.Linear, Duration: 1000},
+		StartTime: 0,
+	}
+
+	unvested, err := Unvested(grant, 400)
+	assert.NilError(t, err, "Fail to calculate unvested amount")
+	assert.Equal(t, unvested, "600")
+
+	// the unvested amount was taken back by the issuer at 400, nothing is locked afterwards
+	grant.Revoked = "600"
+	grant.RevokedAt = 400
+	unvested, err = Unvested(grant, 800)
+	assert.NilError(t, err, "Fail to calculate unvested amount")
+	assert.Equal(t, unvested, "0")
+}
@@ -104,3 +104,13 @@ func (r *repo) GetAndCheckExist(ctx contractapi.TransactionContextInterface, doc
 
 	return true, dataStruct, nil
 }
+
+func (r *repo) Delete(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) error {
+	_, err := util.DeleteTableRow(ctx.GetStub(), docPrefix, keys, nil, util.DONT_FAIL_IF_MISSING)
+	return err
+}
+
+// GetByPartialKey return the documents of docPrefix whose keys start with keys
+func (r *repo) GetByPartialKey(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	return ctx.GetStub().GetStateByPartialCompositeKey(docPrefix, keys)
+}
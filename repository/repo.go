@@ -27,9 +27,8 @@ import (
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
-// We currently don't support delete function and getAll document
-// There will not be any deletion. GetAll is quite dangerous as we never know
-// what it can break.
+// Delete is only used to clean up expired records, we currently don't support getAll document.
+// GetAll is quite dangerous as we never know what it can break.
 type Repo interface {
 	Create(ctx contractapi.TransactionContextInterface, entity interface{}, docPrefix string, keys []string) error
 	Update(ctx contractapi.TransactionContextInterface, entity interface{}, docPrefix string, keys []string) error
@@ -38,4 +37,6 @@ type Repo interface {
 	IsExist(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) (bool, error)
 	GetQueryString(ctx contractapi.TransactionContextInterface, queryString string) (shim.StateQueryIteratorInterface, error)
 	GetAndCheckExist(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) (bool, interface{}, error)
+	Delete(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) error
+	GetByPartialKey(ctx contractapi.TransactionContextInterface, docPrefix string, keys []string) (shim.StateQueryIteratorInterface, error)
 }
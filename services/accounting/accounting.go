@@ -81,9 +81,11 @@ func (a *accountingService) GetTx(ctx contractapi.TransactionContextInterface) (
 
 func (a *accountingService) CalculateBalance(ctx contractapi.TransactionContextInterface, accountingDto token.AccountingBalance) error {
 	glogger.GetInstance().Infof(ctx, "CalculateBalance - List transaction: (%s)", strings.Join(accountingDto.TxId, ","))
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
 	// map temp balance
 	mapCurrentBalance := make(map[string]*entity.BalanceCache, len(accountingDto.TxId)*2)
 	lstTx := make([]*entity.Transaction, 0, len(accountingDto.TxId))
+	batch := txHandler.NewBatch()
 
 	for _, id := range accountingDto.TxId {
 		tx, err := a.GetTransaction(ctx, id)
@@ -99,7 +101,21 @@ func (a *accountingService) CalculateBalance(ctx contractapi.TransactionContextI
 			continue
 		}
 
-		handler := txHandler.GetTxHandler(tx.TxType)
+		// a stale transaction is expired instead of settled, it stays pending to be released again when the release fails
+		if helper.IsExpired(tx.Expiry, txTime.Seconds) {
+			glogger.GetInstance().Infof(ctx, "CalculateBalance - Transaction (%s) is expired", id)
+			balances := base.SnapshotBalances(mapCurrentBalance)
+			if err := batch.ReleaseTx(ctx, tx, mapCurrentBalance); err != nil {
+				glogger.GetInstance().Errorf(ctx, "CalculateBalance - Release transaction (%s) failed with error (%v)", id, err)
+				base.RestoreBalances(mapCurrentBalance, balances)
+				continue
+			}
+			tx.Status = transaction.Expired
+			lstTx = append(lstTx, tx)
+			continue
+		}
+
+		handler := batch.GetTxHandler(tx.TxType)
 		if handler == nil {
 			glogger.GetInstance().Errorf(ctx, "CalculateBalance -  Unable to get tx handler with transaction type (%s)", tx.TxType)
 			continue
@@ -118,6 +134,11 @@ func (a *accountingService) CalculateBalance(ctx contractapi.TransactionContextI
 		return err
 	}
 
+	// Update documents cached by the handlers of the batch
+	if err := batch.Flush(ctx); err != nil {
+		return err
+	}
+
 	// Update balance of wallet after calculate total balance update of wallet
 	if err := a.UpdateBalance(ctx, mapCurrentBalance); err != nil {
 		return err
@@ -20,19 +20,24 @@
 package base
 
 import (
+	"encoding/json"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/errorcode"
 	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/intent"
+	"github.com/Akachain/gringotts/glossary/proposal"
 	"github.com/Akachain/gringotts/glossary/sidechain"
 	"github.com/Akachain/gringotts/glossary/transaction"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/vesting"
 	"github.com/Akachain/gringotts/repository"
 	"github.com/Akachain/gringotts/repository/base"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
+	"strings"
 )
 
 type Base struct {
@@ -134,6 +139,31 @@ func (b *Base) GetTokenType(ctx contractapi.TransactionContextInterface, tokenId
 	return token, nil
 }
 
+// GetActiveToken return the token if it accepts transactions, a paused or deactivated token is rejected
+func (b *Base) GetActiveToken(ctx contractapi.TransactionContextInterface, tokenId string) (*entity.Token, error) {
+	token, err := b.GetTokenType(ctx, tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTokenStatus(token); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Token (%s) has status (%s)", tokenId, token.Status)
+		return nil, err
+	}
+	return token, nil
+}
+
+func checkTokenStatus(token *entity.Token) error {
+	switch token.Status {
+	case glossary.Active:
+		return nil
+	case glossary.Paused:
+		return helper.RespError(errorcode.BizTokenPaused)
+	default:
+		return helper.RespError(errorcode.BizTokenInActive)
+	}
+}
+
 func (b *Base) GetWallet(ctx contractapi.TransactionContextInterface, walletId string) (*entity.Wallet, error) {
 	walletData, err := b.Repo.Get(ctx, doc.Wallets, helper.WalletKey(walletId))
 	if err != nil {
@@ -161,6 +191,109 @@ func (b *Base) GetActiveWallet(ctx contractapi.TransactionContextInterface, wall
 	return wallet, nil
 }
 
+// GetCachedActiveWallet return the active wallet like GetActiveWallet, wallets read are kept in walletMap
+// so a wallet used by several items of a batch is read once
+func (b *Base) GetCachedActiveWallet(ctx contractapi.TransactionContextInterface, walletMap map[string]*entity.Wallet,
+	walletId string) (*entity.Wallet, error) {
+	wallet, ok := walletMap[walletId]
+	if !ok {
+		var err error
+		if wallet, err = b.GetWallet(ctx, walletId); err != nil {
+			return nil, err
+		}
+		walletMap[walletId] = wallet
+	}
+
+	if wallet.Status != glossary.Active {
+		return nil, helper.RespError(errorcode.InvalidWalletInActive)
+	}
+	return wallet, nil
+}
+
+func (b *Base) GetAndCheckExistFreeze(ctx contractapi.TransactionContextInterface, walletId, tokenId string) (*entity.Freeze, bool, error) {
+	isExisted, freezeData, err := b.Repo.GetAndCheckExist(ctx, doc.Freeze, helper.FreezeKey(walletId, tokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get freeze failed with error  (%s)", err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableGetFreeze)
+	}
+
+	if !isExisted {
+		return nil, isExisted, nil
+	}
+
+	freeze := entity.NewFreeze()
+	if err = mapstructure.Decode(freezeData, &freeze); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode freeze failed with error  (%s)", err.Error())
+		return nil, isExisted, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return freeze, isExisted, nil
+}
+
+// ValidateIssuable return error when the supply of the token is managed by a vault or pool
+func (b *Base) ValidateIssuable(ctx contractapi.TransactionContextInterface, tokenId string) error {
+	isExisted, tokenData, err := b.Repo.GetAndCheckExist(ctx, doc.Tokens, helper.TokenKey(tokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get token type failed with error  (%s)", err.Error())
+		return helper.RespError(errorcode.BizUnableGetTokenType)
+	}
+
+	if !isExisted {
+		return nil
+	}
+
+	token := new(entity.Token)
+	if err = mapstructure.Decode(tokenData, &token); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode token type failed with error  (%s)", err.Error())
+		return helper.RespError(errorcode.BizUnableMapDecode)
+	}
+
+	if token.Manager != "" {
+		glogger.GetInstance().Errorf(ctx, "Base - Supply of token (%s) is managed by (%s)", tokenId, token.Manager)
+		return helper.RespError(errorcode.BizTokenSupplyManaged)
+	}
+	return nil
+}
+
+// ValidateTokenControl return error when the token is paused or deactivated, or the balance of the token of the wallet is frozen.
+// An id that is not a token (nft, vault share...) has no control, the system wallet is never frozen
+func (b *Base) ValidateTokenControl(ctx contractapi.TransactionContextInterface, walletId, tokenId string) error {
+	isExisted, tokenData, err := b.Repo.GetAndCheckExist(ctx, doc.Tokens, helper.TokenKey(tokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get token type failed with error  (%s)", err.Error())
+		return helper.RespError(errorcode.BizUnableGetTokenType)
+	}
+
+	if !isExisted {
+		return nil
+	}
+
+	token := new(entity.Token)
+	if err = mapstructure.Decode(tokenData, &token); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode token type failed with error  (%s)", err.Error())
+		return helper.RespError(errorcode.BizUnableMapDecode)
+	}
+
+	if err := checkTokenStatus(token); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Token (%s) has status (%s)", tokenId, token.Status)
+		return err
+	}
+
+	if walletId == "" || walletId == glossary.SystemWallet {
+		return nil
+	}
+
+	freeze, isExisted, err := b.GetAndCheckExistFreeze(ctx, walletId, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if isExisted && freeze.Status == glossary.Active {
+		glogger.GetInstance().Errorf(ctx, "Base - Balance of token (%s) of wallet (%s) is frozen (%s)", tokenId, walletId, freeze.Reason)
+		return helper.RespError(errorcode.BizBalanceFrozen)
+	}
+	return nil
+}
+
 func (b *Base) GetEnrollment(ctx contractapi.TransactionContextInterface, tokenId string) (*entity.Enrollment, error) {
 	enrollmentData, err := b.Repo.Get(ctx, doc.Enrollments, helper.EnrollmentKey(tokenId))
 	if err != nil {
@@ -173,6 +306,7 @@ func (b *Base) GetEnrollment(ctx contractapi.TransactionContextInterface, tokenI
 		glogger.GetInstance().Errorf(ctx, "Base - Decode enrollment failed with error  (%s)", err.Error())
 		return nil, helper.RespError(errorcode.BizUnableMapDecode)
 	}
+	migrateEnrollment(enrollment)
 	return enrollment, nil
 }
 
@@ -192,9 +326,97 @@ func (b *Base) GetAndCheckExistEnrollment(ctx contractapi.TransactionContextInte
 		glogger.GetInstance().Errorf(ctx, "Base - Decode enrollment failed with error  (%s)", err.Error())
 		return nil, isExisted, helper.RespError(errorcode.BizUnableMapDecode)
 	}
+	migrateEnrollment(enrollment)
 	return enrollment, isExisted, nil
 }
 
+// migrateEnrollment move the comma-joined wallet lists of an old policy to the sets of issuers and recipients
+func migrateEnrollment(enrollment *entity.Enrollment) {
+	for _, walletId := range strings.Split(enrollment.FromWalletId, ",") {
+		if walletId != "" {
+			enrollment.Issuers = helper.ArrayInsert(enrollment.Issuers, walletId)
+		}
+	}
+	for _, walletId := range strings.Split(enrollment.ToWalletId, ",") {
+		if walletId != "" {
+			enrollment.Recipients = helper.ArrayInsert(enrollment.Recipients, walletId)
+		}
+	}
+	enrollment.FromWalletId = ""
+	enrollment.ToWalletId = ""
+	enrollment.IssuersRestricted = enrollment.IssuersRestricted || len(enrollment.Issuers) > 0
+	enrollment.RecipientsRestricted = enrollment.RecipientsRestricted || len(enrollment.Recipients) > 0
+}
+
+// ApplyIssuancePolicy check the issuance policy of token for amount issued by issuer to recipient and record the amount
+// against the quota of recipient. An empty issuer is not checked, a token without policy is not limited
+func (b *Base) ApplyIssuancePolicy(ctx contractapi.TransactionContextInterface, tokenId, issuerId, recipientId, amount string) error {
+	enrollment, isExisted, err := b.GetAndCheckExistEnrollment(ctx, tokenId)
+	if err != nil || !isExisted {
+		return err
+	}
+
+	isRecorded, err := b.CheckIssuancePolicy(ctx, enrollment, issuerId, recipientId, amount)
+	if err != nil || !isRecorded {
+		return err
+	}
+	return b.UpdateEnrollment(ctx, enrollment)
+}
+
+// CheckIssuancePolicy check the issuance policy of enrollment for amount issued by issuer to recipient and add the amount
+// to the issued amount of recipient in enrollment. It return true when the issued amount changed and the enrollment
+// has to be updated, so several issuances of a batch are checked against the same enrollment and written once
+func (b *Base) CheckIssuancePolicy(ctx contractapi.TransactionContextInterface, enrollment *entity.Enrollment, issuerId,
+	recipientId, amount string) (bool, error) {
+	tokenId := enrollment.TokenId
+	if issuerId != "" && enrollment.IssuersRestricted && !helper.ArrayContains(enrollment.Issuers, issuerId) {
+		glogger.GetInstance().Errorf(ctx, "Base - Wallet (%s) is not an issuer of token (%s)", issuerId, tokenId)
+		return false, helper.RespError(errorcode.BizIssueNotPermission)
+	}
+
+	if enrollment.RecipientsRestricted && !helper.ArrayContains(enrollment.Recipients, recipientId) {
+		glogger.GetInstance().Errorf(ctx, "Base - Wallet (%s) is not a recipient of token (%s)", recipientId, tokenId)
+		return false, helper.RespError(errorcode.BizIssueNotPermission)
+	}
+
+	quota, isLimited := enrollment.Quota[recipientId]
+	if !isLimited {
+		return false, nil
+	}
+
+	issued := enrollment.Issued[recipientId]
+	if issued == "" {
+		issued = "0"
+	}
+	issued, err := helper.AddBalance(issued, amount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Calculate issued amount of wallet (%s) failed with error (%v)", recipientId, err)
+		return false, helper.RespError(errorcode.BizUnableToIssue)
+	}
+
+	if helper.CompareStringBalance(issued, quota) > 0 {
+		glogger.GetInstance().Errorf(ctx, "Base - Wallet (%s) is over the issuance quota of token (%s)", recipientId, tokenId)
+		return false, helper.RespError(errorcode.BizIssueOverQuota)
+	}
+
+	if enrollment.Issued == nil {
+		enrollment.Issued = make(map[string]string)
+	}
+	enrollment.Issued[recipientId] = issued
+	return true, nil
+}
+
+// UpdateEnrollment write the enrollment of token
+func (b *Base) UpdateEnrollment(ctx contractapi.TransactionContextInterface, enrollment *entity.Enrollment) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	enrollment.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := b.Repo.Update(ctx, enrollment, doc.Enrollments, helper.EnrollmentKey(enrollment.TokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Update enrollment failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableCreateEnrollment)
+	}
+	return nil
+}
+
 func (b *Base) GetNFT(ctx contractapi.TransactionContextInterface, nftTokenId string) (*entity.NFT, error) {
 	nftData, err := b.Repo.Get(ctx, doc.NftToken, helper.NFTKey(nftTokenId))
 	if err != nil {
@@ -211,6 +433,376 @@ func (b *Base) GetNFT(ctx contractapi.TransactionContextInterface, nftTokenId st
 	return nftToken, nil
 }
 
+func (b *Base) GetExchange(ctx contractapi.TransactionContextInterface, listingId string) (*entity.Exchange, error) {
+	listingData, err := b.Repo.Get(ctx, doc.Exchange, helper.ExchangeKey(listingId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get listing (%s) failed with error (%s)", listingId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetExchange)
+	}
+
+	listingEntity := entity.NewExchange()
+	if err = mapstructure.Decode(listingData, &listingEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode listing failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return listingEntity, nil
+}
+
+func (b *Base) GetOffer(ctx contractapi.TransactionContextInterface, offerId string) (*entity.Offer, error) {
+	offerData, err := b.Repo.Get(ctx, doc.Offer, helper.OfferKey(offerId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get offer (%s) failed with error (%s)", offerId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetOffer)
+	}
+
+	offerEntity := entity.NewOffer()
+	if err = mapstructure.Decode(offerData, &offerEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode offer failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return offerEntity, nil
+}
+
+func (b *Base) GetVault(ctx contractapi.TransactionContextInterface, vaultId string) (*entity.Vault, error) {
+	vaultData, err := b.Repo.Get(ctx, doc.Vault, helper.VaultKey(vaultId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get vault (%s) failed with error (%s)", vaultId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetVault)
+	}
+
+	vaultEntity := entity.NewVault()
+	if err = mapstructure.Decode(vaultData, &vaultEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode vault failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return vaultEntity, nil
+}
+
+func (b *Base) GetSwap(ctx contractapi.TransactionContextInterface, swapId string) (*entity.Swap, error) {
+	swapData, err := b.Repo.Get(ctx, doc.Swap, helper.SwapKey(swapId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get swap (%s) failed with error (%s)", swapId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetSwap)
+	}
+
+	swapEntity := entity.NewSwap()
+	if err = mapstructure.Decode(swapData, &swapEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode swap failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return swapEntity, nil
+}
+
+func (b *Base) GetOrder(ctx contractapi.TransactionContextInterface, orderId string) (*entity.Order, error) {
+	orderData, err := b.Repo.Get(ctx, doc.Order, helper.OrderKey(orderId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get order (%s) failed with error (%s)", orderId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetOrder)
+	}
+
+	orderEntity := entity.NewOrder()
+	if err = mapstructure.Decode(orderData, &orderEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode order failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return orderEntity, nil
+}
+
+func (b *Base) GetPool(ctx contractapi.TransactionContextInterface, poolId string) (*entity.Pool, error) {
+	poolData, err := b.Repo.Get(ctx, doc.Pool, helper.PoolKey(poolId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get pool (%s) failed with error (%s)", poolId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetPool)
+	}
+
+	poolEntity := entity.NewPool()
+	if err = mapstructure.Decode(poolData, &poolEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode pool failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return poolEntity, nil
+}
+
+func (b *Base) GetAndCheckExistFeeSchedule(ctx contractapi.TransactionContextInterface, tokenId string, txType transaction.Type) (*entity.FeeSchedule, bool, error) {
+	isExisted, scheduleData, err := b.Repo.GetAndCheckExist(ctx, doc.FeeSchedule, helper.FeeScheduleKey(tokenId, string(txType)))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get fee schedule failed with error  (%s)", err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableGetFeeSchedule)
+	}
+
+	if !isExisted {
+		return nil, isExisted, nil
+	}
+
+	schedule := entity.NewFeeSchedule()
+	if err = mapstructure.Decode(scheduleData, &schedule); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode fee schedule failed with error  (%s)", err.Error())
+		return nil, isExisted, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return schedule, isExisted, nil
+}
+
+func (b *Base) GetAndCheckExistApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) (*entity.ApprovalPolicy, bool, error) {
+	isExisted, policyData, err := b.Repo.GetAndCheckExist(ctx, doc.ApprovalPolicy, helper.ApprovalPolicyKey(string(operation), tokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get approval policy failed with error  (%s)", err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableGetApprovalPolicy)
+	}
+
+	if !isExisted {
+		return nil, isExisted, nil
+	}
+
+	policy := entity.NewApprovalPolicy()
+	if err = mapstructure.Decode(policyData, &policy); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode approval policy failed with error  (%s)", err.Error())
+		return nil, isExisted, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return policy, isExisted, nil
+}
+
+func (b *Base) GetProposal(ctx contractapi.TransactionContextInterface, proposalId string) (*entity.Proposal, error) {
+	proposalData, err := b.Repo.Get(ctx, doc.Proposal, helper.ProposalKey(proposalId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get proposal (%s) failed with error (%s)", proposalId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetProposal)
+	}
+
+	proposalEntity := entity.NewProposal()
+	if err = mapstructure.Decode(proposalData, &proposalEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode proposal failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return proposalEntity, nil
+}
+
+// GetIntent return the intent of a multi-signature wallet
+func (b *Base) GetIntent(ctx contractapi.TransactionContextInterface, intentId string) (*entity.Intent, error) {
+	intentData, err := b.Repo.Get(ctx, doc.Intent, helper.IntentKey(intentId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get intent (%s) failed with error (%s)", intentId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetIntent)
+	}
+
+	intentEntity := entity.NewIntent()
+	if err = mapstructure.Decode(intentData, &intentEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode intent failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return intentEntity, nil
+}
+
+// RejectMultiSig return error when wallet is a multi-signature wallet. The entry points that hold or debit the wallet
+// directly write no intent, so they would bypass the threshold and the owners of the wallet.
+func (b *Base) RejectMultiSig(ctx contractapi.TransactionContextInterface, wallet *entity.Wallet) error {
+	if wallet.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "Base - Wallet (%s) is a multi-signature wallet", wallet.Id)
+		return helper.RespError(errorcode.BizWalletMultiSig)
+	}
+	return nil
+}
+
+// SubmitTransaction write the pending transaction and return its id. An outgoing transaction of a multi-signature
+// wallet is kept as an open intent signed by the caller instead, the id of the intent is returned.
+func (b *Base) SubmitTransaction(ctx contractapi.TransactionContextInterface, txEntity *entity.Transaction) (string, error) {
+	wallet, err := b.GetWallet(ctx, txEntity.FromWallet)
+	if err != nil {
+		return "", err
+	}
+
+	if wallet.Threshold <= 0 {
+		if err := b.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Create transaction failed with error (%v)", err)
+			return "", helper.RespError(errorcode.BizUnableCreateTX)
+		}
+		return txEntity.Id, nil
+	}
+
+	callerId, err := helper.GetCallerId(ctx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get caller identity failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableGetIdentity)
+	}
+
+	if !helper.ArrayContains(wallet.Owners, callerId) {
+		glogger.GetInstance().Errorf(ctx, "Base - Caller is not an owner of wallet (%s)", wallet.Id)
+		return "", helper.RespError(errorcode.BizIntentNotOwner)
+	}
+
+	intentEntity := entity.NewIntent(ctx)
+	intentEntity.WalletId = wallet.Id
+	intentEntity.FromWallet = txEntity.FromWallet
+	intentEntity.ToWallet = txEntity.ToWallet
+	intentEntity.FromTokenId = txEntity.FromTokenId
+	intentEntity.ToTokenId = txEntity.ToTokenId
+	intentEntity.FromTokenAmount = txEntity.FromTokenAmount
+	intentEntity.ToTokenAmount = txEntity.ToTokenAmount
+	intentEntity.TxType = txEntity.TxType
+	intentEntity.Note = txEntity.Note
+	intentEntity.Expiry = txEntity.Expiry
+	intentEntity.Owners = wallet.Owners
+	intentEntity.Threshold = wallet.Threshold
+	intentEntity.Signatures = []string{callerId}
+
+	if len(intentEntity.Signatures) >= intentEntity.Threshold {
+		if err := b.ExecuteIntent(ctx, intentEntity); err != nil {
+			return "", err
+		}
+	}
+
+	if err := b.Repo.Create(ctx, intentEntity, doc.Intent, helper.IntentKey(intentEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Create intent failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateIntent)
+	}
+	return intentEntity.Id, nil
+}
+
+// ExecuteIntent write the pending transaction of a fully signed intent and mark the intent executed.
+// The intent itself is not saved.
+func (b *Base) ExecuteIntent(ctx contractapi.TransactionContextInterface, intentEntity *entity.Intent) error {
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = intentEntity.FromWallet
+	txEntity.FromWallet = intentEntity.FromWallet
+	txEntity.ToWallet = intentEntity.ToWallet
+	txEntity.FromTokenId = intentEntity.FromTokenId
+	txEntity.ToTokenId = intentEntity.ToTokenId
+	txEntity.FromTokenAmount = intentEntity.FromTokenAmount
+	txEntity.ToTokenAmount = intentEntity.ToTokenAmount
+	txEntity.TxType = intentEntity.TxType
+	txEntity.Note = intentEntity.Note
+	txEntity.Expiry = intentEntity.Expiry
+
+	if err := b.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Create transaction of intent (%s) failed with error (%v)", intentEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableCreateTX)
+	}
+
+	intentEntity.TxId = txEntity.Id
+	intentEntity.Status = intent.Executed
+	return nil
+}
+
+// GetHashLock return the hashed time-locked transfer
+func (b *Base) GetHashLock(ctx contractapi.TransactionContextInterface, lockId string) (*entity.HashLock, error) {
+	lockData, err := b.Repo.Get(ctx, doc.HashLock, helper.HashLockKey(lockId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get hash lock (%s) failed with error (%s)", lockId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetHashLock)
+	}
+
+	lockEntity := entity.NewHashLock()
+	if err = mapstructure.Decode(lockData, &lockEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode hash lock failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return lockEntity, nil
+}
+
+func (b *Base) GetVesting(ctx contractapi.TransactionContextInterface, walletId, tokenId, vestingId string) (*entity.Vesting, error) {
+	vestingData, err := b.Repo.Get(ctx, doc.Vesting, helper.VestingKey(walletId, tokenId, vestingId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get vesting (%s) failed with error (%s)", vestingId, err.Error())
+		return nil, helper.RespError(errorcode.BizUnableGetVesting)
+	}
+
+	vestingEntity := entity.NewVesting()
+	if err = mapstructure.Decode(vestingData, &vestingEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode vesting failed with error  (%s)", err.Error())
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return vestingEntity, nil
+}
+
+func (b *Base) GetAndCheckIaoCommitment(ctx contractapi.TransactionContextInterface, iaoId, walletId, stableTokenId string) (*entity.IaoCommitment, bool, error) {
+	isExisted, commitmentData, err := b.Repo.GetAndCheckExist(ctx, doc.IaoCommitment, helper.IaoCommitmentKey(iaoId, walletId, stableTokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get commitment of wallet (%s) in iao (%s) failed with error (%s)", walletId, iaoId, err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableGetIaoCommitment)
+	}
+	if !isExisted {
+		return nil, false, nil
+	}
+
+	commitment := entity.NewIaoCommitment()
+	if err = mapstructure.Decode(commitmentData, &commitment); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode iao commitment failed with error  (%s)", err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return commitment, true, nil
+}
+
+func (b *Base) GetAndCheckIaoInvestor(ctx contractapi.TransactionContextInterface, iaoId, walletId string) (*entity.IaoInvestor, bool, error) {
+	isExisted, investorData, err := b.Repo.GetAndCheckExist(ctx, doc.IaoInvestor, helper.IaoInvestorKey(iaoId, walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get total of wallet (%s) in iao (%s) failed with error (%s)", walletId, iaoId, err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableGetIaoInvestor)
+	}
+	if !isExisted {
+		return nil, false, nil
+	}
+
+	iaoInvestor := entity.NewIaoInvestor()
+	if err = mapstructure.Decode(investorData, &iaoInvestor); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Decode iao investor failed with error  (%s)", err.Error())
+		return nil, false, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return iaoInvestor, true, nil
+}
+
+// GetIaoCommitments return the commitments of iao ordered by wallet and stable token
+func (b *Base) GetIaoCommitments(ctx contractapi.TransactionContextInterface, iaoId string) ([]*entity.IaoCommitment, error) {
+	resultsIterator, err := b.Repo.GetByPartialKey(ctx, doc.IaoCommitment, []string{iaoId})
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get commitments of iao (%s) failed with error (%v)", iaoId, err)
+		return nil, helper.RespError(errorcode.BizUnableGetIaoCommitment)
+	}
+	defer resultsIterator.Close()
+
+	commitments := make([]*entity.IaoCommitment, 0)
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Iterate iao commitment failed with error (%v)", err)
+			return nil, helper.RespError(errorcode.BizUnableGetIaoCommitment)
+		}
+
+		commitment := entity.NewIaoCommitment()
+		if err := json.Unmarshal(queryResponse.Value, commitment); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Unmarshal iao commitment failed with error (%v)", err)
+			return nil, helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		commitments = append(commitments, commitment)
+	}
+	return commitments, nil
+}
+
+// QueryDocuments return all documents match the query string
+func (b *Base) QueryDocuments(ctx contractapi.TransactionContextInterface, queryString string) ([]json.RawMessage, error) {
+	return b.QueryDocumentsLimit(ctx, queryString, 0)
+}
+
+// QueryDocumentsLimit return the first limit documents match the query string, all documents when limit is zero.
+// The limit of a rich query is not applied by the peer, so pages of a write transaction are cut while iterating
+func (b *Base) QueryDocumentsLimit(ctx contractapi.TransactionContextInterface, queryString string, limit int) ([]json.RawMessage, error) {
+	resultsIterator, err := b.Repo.GetQueryString(ctx, queryString)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get query string failed with error (%v)", err)
+		return nil, helper.RespError(errorcode.BizUnableQueryData)
+	}
+	defer resultsIterator.Close()
+
+	documents := make([]json.RawMessage, 0)
+	for resultsIterator.HasNext() && (limit <= 0 || len(documents) < limit) {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Iterate query result failed with error (%v)", err)
+			return nil, helper.RespError(errorcode.BizUnableQueryData)
+		}
+		documents = append(documents, queryResponse.Value)
+	}
+	return documents, nil
+}
+
 func (b *Base) GetBalanceOfToken(ctx contractapi.TransactionContextInterface, domain, walletId string, tokenId string) (*entity.Balance, error) {
 	balanceData, err := b.Repo.Get(ctx, domain, helper.BalanceKey(walletId, tokenId))
 	if err != nil {
@@ -268,7 +860,33 @@ func (b *Base) ValidatePairWallet(ctx contractapi.TransactionContextInterface, f
 func (b *Base) AddAmount(ctx contractapi.TransactionContextInterface,
 	mapCurrentBalance map[string]*entity.BalanceCache, domain, walletId string, tokenId string, amount string) error {
 	key := domain + "_" + walletId + "_" + tokenId
-	// Load current balance of wallet into memory
+	if err := b.loadBalance(ctx, mapCurrentBalance, domain, walletId, tokenId); err != nil {
+		return err
+	}
+
+	// update current balance
+	updateCurrentBalance, err := helper.AddBalance(mapCurrentBalance[key].BalanceEntity.Balances, amount)
+	if err != nil {
+		return err
+	}
+	mapCurrentBalance[key].BalanceEntity.Balances = updateCurrentBalance
+
+	return nil
+}
+
+// CurrentAmount return the balance of token in memory, the balance is zero if it does not exist yet
+func (b *Base) CurrentAmount(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, domain, walletId string, tokenId string) (string, error) {
+	if err := b.loadBalance(ctx, mapCurrentBalance, domain, walletId, tokenId); err != nil {
+		return "", err
+	}
+	return mapCurrentBalance[domain+"_"+walletId+"_"+tokenId].BalanceEntity.Balances, nil
+}
+
+// loadBalance load current balance of wallet into memory
+func (b *Base) loadBalance(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, domain, walletId string, tokenId string) error {
+	key := domain + "_" + walletId + "_" + tokenId
 	if _, ok := mapCurrentBalance[key]; !ok {
 		balanceToken, isExisted, err := b.GetAndCheckBalanceOfToken(ctx, domain, walletId, tokenId)
 		if err != nil {
@@ -292,13 +910,6 @@ func (b *Base) AddAmount(ctx contractapi.TransactionContextInterface,
 		}
 	}
 
-	// update current balance
-	updateCurrentBalance, err := helper.AddBalance(mapCurrentBalance[key].BalanceEntity.Balances, amount)
-	if err != nil {
-		return err
-	}
-	mapCurrentBalance[key].BalanceEntity.Balances = updateCurrentBalance
-
 	return nil
 }
 
@@ -335,6 +946,132 @@ func (b *Base) SubAmount(ctx contractapi.TransactionContextInterface,
 	return nil
 }
 
+// HoldAmount to move amount from spot balance of wallet into its escrow balance, unvested balance can not be held
+func (b *Base) HoldAmount(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, walletId string, tokenId string, amount string) error {
+	if err := b.SubSpendableAmount(ctx, mapCurrentBalance, walletId, tokenId, amount); err != nil {
+		return err
+	}
+	return b.AddAmount(ctx, mapCurrentBalance, doc.EscrowBalances, walletId, tokenId, amount)
+}
+
+// ReleaseHold to move amount from escrow balance of wallet back to its spot balance
+func (b *Base) ReleaseHold(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, walletId string, tokenId string, amount string) error {
+	if err := b.SubAmount(ctx, mapCurrentBalance, doc.EscrowBalances, walletId, tokenId, amount); err != nil {
+		return err
+	}
+	return b.AddAmount(ctx, mapCurrentBalance, doc.SpotBalances, walletId, tokenId, amount)
+}
+
+// GetVestings return the vesting grants of token in wallet
+func (b *Base) GetVestings(ctx contractapi.TransactionContextInterface, walletId, tokenId string) ([]*entity.Vesting, error) {
+	resultsIterator, err := b.Repo.GetByPartialKey(ctx, doc.Vesting, []string{walletId, tokenId})
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Base - Get vesting of wallet (%s) failed with error (%v)", walletId, err)
+		return nil, helper.RespError(errorcode.BizUnableGetVesting)
+	}
+	defer resultsIterator.Close()
+
+	vestings := make([]*entity.Vesting, 0)
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Iterate vesting failed with error (%v)", err)
+			return nil, helper.RespError(errorcode.BizUnableGetVesting)
+		}
+
+		vestingEntity := entity.NewVesting()
+		if err := json.Unmarshal(queryResponse.Value, vestingEntity); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Base - Unmarshal vesting failed with error (%v)", err)
+			return nil, helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		vestings = append(vestings, vestingEntity)
+	}
+	return vestings, nil
+}
+
+// UnvestedAmount return the locked balance of token in wallet that is not vested at the transaction time.
+// The locked balance is read from memory first, so grants settled earlier in the same transaction are fully unvested.
+func (b *Base) UnvestedAmount(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, walletId string, tokenId string) (string, error) {
+	locked := "0"
+	if balanceCache, ok := mapCurrentBalance[doc.LockedBalances+"_"+walletId+"_"+tokenId]; ok {
+		locked = balanceCache.BalanceEntity.Balances
+	} else {
+		// do not load the locked balance into memory, it would create an empty balance for every wallet
+		balanceToken, isExisted, err := b.GetAndCheckBalanceOfToken(ctx, doc.LockedBalances, walletId, tokenId)
+		if err != nil {
+			return "", err
+		}
+		if isExisted {
+			locked = balanceToken.Balances
+		}
+	}
+	if helper.CompareStringBalance(locked, "0") <= 0 {
+		return "0", nil
+	}
+
+	vestings, err := b.GetVestings(ctx, walletId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	unvested := locked
+	for _, vestingEntity := range vestings {
+		vestingUnvested, err := vesting.Unvested(vestingEntity, txTime.Seconds)
+		if err != nil {
+			return "", err
+		}
+		// the vested part of grant is not locked anymore
+		vested, err := helper.SubBalance(vestingEntity.Total, vestingUnvested)
+		if err != nil {
+			return "", err
+		}
+		if vested, err = helper.SubBalance(vested, vestingEntity.Revoked); err != nil {
+			return "", err
+		}
+		if helper.CompareStringBalance(unvested, vested) <= 0 {
+			return "0", nil
+		}
+		if unvested, err = helper.SubBalance(unvested, vested); err != nil {
+			return "", err
+		}
+	}
+	return unvested, nil
+}
+
+// SubSpendableAmount to sub amount from spot balance of wallet, the spot balance left must cover its unvested amount
+func (b *Base) SubSpendableAmount(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, walletId string, tokenId string, amount string) error {
+	if err := b.SubAmount(ctx, mapCurrentBalance, doc.SpotBalances, walletId, tokenId, amount); err != nil {
+		return err
+	}
+
+	unvested, err := b.UnvestedAmount(ctx, mapCurrentBalance, walletId, tokenId)
+	if err != nil {
+		return err
+	}
+	key := doc.SpotBalances + "_" + walletId + "_" + tokenId
+	if helper.CompareStringBalance(mapCurrentBalance[key].BalanceEntity.Balances, unvested) < 0 {
+		if err := b.AddAmount(ctx, mapCurrentBalance, doc.SpotBalances, walletId, tokenId, amount); err != nil {
+			return err
+		}
+		return errors.Errorf("Wallet (%s) can not spend unvested balance (%s)", key, unvested)
+	}
+	return nil
+}
+
+// SubWalletAmount to sub amount the wallet spends from its balance of domain, the spot balance is spent by SubSpendableAmount
+func (b *Base) SubWalletAmount(ctx contractapi.TransactionContextInterface,
+	mapCurrentBalance map[string]*entity.BalanceCache, domain string, walletId string, tokenId string, amount string) error {
+	if domain == doc.SpotBalances {
+		return b.SubSpendableAmount(ctx, mapCurrentBalance, walletId, tokenId, amount)
+	}
+	return b.SubAmount(ctx, mapCurrentBalance, domain, walletId, tokenId, amount)
+}
+
 // RollbackTxHandler to rollback balance of wallet that was updated
 func (b *Base) RollbackTxHandler(ctx contractapi.TransactionContextInterface, tx *entity.Transaction,
 	mapCurrentBalance map[string]*entity.BalanceCache, step transaction.Step) error {
@@ -348,6 +1085,28 @@ func (b *Base) RollbackTxHandler(ctx contractapi.TransactionContextInterface, tx
 	return nil
 }
 
+// SnapshotBalances return the balances of the balance cache, so a failed handling can restore them with RestoreBalances
+func SnapshotBalances(mapCurrentBalance map[string]*entity.BalanceCache) map[string]string {
+	balances := make(map[string]string, len(mapCurrentBalance))
+	for key, balanceCache := range mapCurrentBalance {
+		balances[key] = balanceCache.BalanceEntity.Balances
+	}
+	return balances
+}
+
+// RestoreBalances set the balance cache back to the snapshot, balances loaded after the snapshot are dropped
+// and reloaded from the state database when needed
+func RestoreBalances(mapCurrentBalance map[string]*entity.BalanceCache, balances map[string]string) {
+	for key, balanceCache := range mapCurrentBalance {
+		balance, ok := balances[key]
+		if !ok {
+			delete(mapCurrentBalance, key)
+			continue
+		}
+		balanceCache.BalanceEntity.Balances = balance
+	}
+}
+
 // UpdateBalance to update balance of wallet after handle transaction
 func (b *Base) UpdateBalance(ctx contractapi.TransactionContextInterface, mapCurrentBalance map[string]*entity.BalanceCache) error {
 	txTime, _ := ctx.GetStub().GetTxTimestamp()
@@ -21,6 +21,33 @@ package services
 
 import "github.com/hyperledger/fabric-contract-api-go/contractapi"
 
+// Exchange is the marketplace of nft token.
+// Listed nft tokens and the price of offers are held in escrow, the sale is settled
+// by accounting job through NftSettlement transaction.
 type Exchange interface {
-	TransferNft(ctx contractapi.TransactionContextInterface, ownerWalletId string, toWalletId string, nftToken string, price float64) error
+	// ListNft to list nft token for sale, the nft token is locked until the listing is canceled or filled
+	ListNft(ctx contractapi.TransactionContextInterface, sellerWalletId, nftTokenId, priceTokenId, price string, expiry int64) (string, error)
+
+	// CancelListing to cancel an open listing and unlock the nft token
+	CancelListing(ctx contractapi.TransactionContextInterface, listingId, sellerWalletId string) error
+
+	// BuyListing to buy a listed nft token at the listing price. The price is held from the buyer balance,
+	// a fee schedule of the price token is charged to the spot balance of buyer when the settlement is accounted
+	BuyListing(ctx contractapi.TransactionContextInterface, listingId, buyerWalletId string) (string, error)
+
+	// MakeOffer to make an offer on nft token, the price is held from the buyer balance.
+	// A fee schedule of the price token is charged to the spot balance of buyer when the settlement is accounted
+	MakeOffer(ctx contractapi.TransactionContextInterface, buyerWalletId, nftTokenId, priceTokenId, price string, expiry int64) (string, error)
+
+	// CancelOffer to cancel an open offer and release the held price
+	CancelOffer(ctx contractapi.TransactionContextInterface, offerId, buyerWalletId string) error
+
+	// AcceptOffer the owner of nft token accepts an open offer
+	AcceptOffer(ctx contractapi.TransactionContextInterface, offerId, sellerWalletId string) (string, error)
+
+	// GetListings return listings of nft token or seller wallet
+	GetListings(ctx contractapi.TransactionContextInterface, nftTokenId, sellerWalletId string) (string, error)
+
+	// GetOffers return offers of nft token
+	GetOffers(ctx contractapi.TransactionContextInterface, nftTokenId string) (string, error)
 }
@@ -0,0 +1,485 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/listing"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type exchangeService struct {
+	*base.Base
+}
+
+func NewExchangeService() services.Exchange {
+	return &exchangeService{
+		base.NewBase(),
+	}
+}
+
+func (e *exchangeService) ListNft(ctx contractapi.TransactionContextInterface, sellerWalletId, nftTokenId, priceTokenId,
+	price string, expiry int64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - ListNft-----------")
+
+	sellerWallet, err := e.GetActiveWallet(ctx, sellerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ListNft - Get seller wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the nft of the listing is sold later without signatures of the owners of seller wallet
+	if err := e.RejectMultiSig(ctx, sellerWallet); err != nil {
+		return "", err
+	}
+
+	if _, err := e.GetTokenType(ctx, priceTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ListNft - Get price token failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := e.validateExpiry(ctx, expiry); err != nil {
+		return "", err
+	}
+
+	nftToken, err := e.GetNFT(ctx, nftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ListNft - Get NftToken failed with error (%v)", err)
+		return "", err
+	}
+
+	if nftToken.OwnerId != sellerWalletId {
+		glogger.GetInstance().Error(ctx, "ListNft - Seller wallet not match owner of nft token")
+		return "", helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	if nftToken.LockedBy != "" {
+		glogger.GetInstance().Errorf(ctx, "ListNft - NftToken is locked by (%s)", nftToken.LockedBy)
+		return "", helper.RespError(errorcode.BizNftLocked)
+	}
+
+	listingEntity := entity.NewExchange(ctx)
+	listingEntity.OwnerWalletId = sellerWalletId
+	listingEntity.NftTokenId = nftTokenId
+	listingEntity.PriceTokenId = priceTokenId
+	listingEntity.Price = price
+	listingEntity.Expiry = expiry
+
+	if err := e.Repo.Create(ctx, listingEntity, doc.Exchange, helper.ExchangeKey(listingEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ListNft - Create listing failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateExchange)
+	}
+
+	// lock nft token in escrow of the listing
+	if err := e.lockNft(ctx, nftToken, listingEntity.Id); err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - ListNft succeed (%s)-----------", listingEntity.Id)
+
+	return listingEntity.Id, nil
+}
+
+func (e *exchangeService) CancelListing(ctx contractapi.TransactionContextInterface, listingId, sellerWalletId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - CancelListing-----------")
+
+	listingEntity, err := e.getOpenListing(ctx, listingId)
+	if err != nil {
+		return err
+	}
+
+	if listingEntity.OwnerWalletId != sellerWalletId {
+		glogger.GetInstance().Error(ctx, "CancelListing - Seller wallet not match owner of listing")
+		return helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	nftToken, err := e.GetNFT(ctx, listingEntity.NftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelListing - Get NftToken failed with error (%v)", err)
+		return err
+	}
+
+	if nftToken.LockedBy == listingEntity.Id {
+		if err := e.lockNft(ctx, nftToken, ""); err != nil {
+			return err
+		}
+	}
+
+	listingEntity.Status = listing.Canceled
+	if err := e.updateListing(ctx, listingEntity); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - CancelListing succeed (%s)-----------", listingEntity.Id)
+
+	return nil
+}
+
+func (e *exchangeService) BuyListing(ctx contractapi.TransactionContextInterface, listingId, buyerWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - BuyListing-----------")
+
+	listingEntity, err := e.getOpenListing(ctx, listingId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := e.validateExpiry(ctx, listingEntity.Expiry); err != nil {
+		return "", err
+	}
+
+	if listingEntity.OwnerWalletId == buyerWalletId {
+		glogger.GetInstance().Error(ctx, "BuyListing - Buyer wallet is the owner of listing")
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	buyerWallet, err := e.GetActiveWallet(ctx, buyerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "BuyListing - Get buyer wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := e.RejectMultiSig(ctx, buyerWallet); err != nil {
+		return "", err
+	}
+
+	// hold the price from the buyer balance, it is paid to the seller when the settlement is accounted
+	if err := e.holdPrice(ctx, buyerWalletId, listingEntity.PriceTokenId, listingEntity.Price); err != nil {
+		return "", err
+	}
+
+	listingEntity.ToWalletId = buyerWalletId
+	listingEntity.Status = listing.Filled
+	if err := e.updateListing(ctx, listingEntity); err != nil {
+		return "", err
+	}
+
+	txId, err := e.createSettlement(ctx, buyerWalletId, listingEntity.OwnerWalletId, listingEntity.PriceTokenId,
+		listingEntity.NftTokenId, listingEntity.Price, listingEntity.Id)
+	if err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - BuyListing succeed (%s)-----------", txId)
+
+	return txId, nil
+}
+
+func (e *exchangeService) MakeOffer(ctx contractapi.TransactionContextInterface, buyerWalletId, nftTokenId, priceTokenId,
+	price string, expiry int64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - MakeOffer-----------")
+
+	buyerWallet, err := e.GetActiveWallet(ctx, buyerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "MakeOffer - Get buyer wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := e.RejectMultiSig(ctx, buyerWallet); err != nil {
+		return "", err
+	}
+
+	if _, err := e.GetTokenType(ctx, priceTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MakeOffer - Get price token failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := e.validateExpiry(ctx, expiry); err != nil {
+		return "", err
+	}
+
+	nftToken, err := e.GetNFT(ctx, nftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "MakeOffer - Get NftToken failed with error (%v)", err)
+		return "", err
+	}
+
+	if nftToken.OwnerId == buyerWalletId {
+		glogger.GetInstance().Error(ctx, "MakeOffer - Buyer wallet is the owner of nft token")
+		return "", helper.RespError(errorcode.InvalidParam)
+	}
+
+	if err := e.holdPrice(ctx, buyerWalletId, priceTokenId, price); err != nil {
+		return "", err
+	}
+
+	offerEntity := entity.NewOffer(ctx)
+	offerEntity.BuyerWalletId = buyerWalletId
+	offerEntity.NftTokenId = nftTokenId
+	offerEntity.PriceTokenId = priceTokenId
+	offerEntity.Price = price
+	offerEntity.Expiry = expiry
+
+	if err := e.Repo.Create(ctx, offerEntity, doc.Offer, helper.OfferKey(offerEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MakeOffer - Create offer failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateOffer)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - MakeOffer succeed (%s)-----------", offerEntity.Id)
+
+	return offerEntity.Id, nil
+}
+
+func (e *exchangeService) CancelOffer(ctx contractapi.TransactionContextInterface, offerId, buyerWalletId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - CancelOffer-----------")
+
+	offerEntity, err := e.getOpenOffer(ctx, offerId)
+	if err != nil {
+		return err
+	}
+
+	if offerEntity.BuyerWalletId != buyerWalletId {
+		glogger.GetInstance().Error(ctx, "CancelOffer - Buyer wallet not match owner of offer")
+		return helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	// release the held price to the buyer
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := e.ReleaseHold(ctx, balanceMap, offerEntity.BuyerWalletId, offerEntity.PriceTokenId, offerEntity.Price); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelOffer - Release hold of offer failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableReleaseBalance)
+	}
+	if err := e.UpdateBalance(ctx, balanceMap); err != nil {
+		return err
+	}
+
+	offerEntity.Status = listing.Canceled
+	if err := e.updateOffer(ctx, offerEntity); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - CancelOffer succeed (%s)-----------", offerEntity.Id)
+
+	return nil
+}
+
+func (e *exchangeService) AcceptOffer(ctx contractapi.TransactionContextInterface, offerId, sellerWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - AcceptOffer-----------")
+
+	offerEntity, err := e.getOpenOffer(ctx, offerId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := e.validateExpiry(ctx, offerEntity.Expiry); err != nil {
+		return "", err
+	}
+
+	nftToken, err := e.GetNFT(ctx, offerEntity.NftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "AcceptOffer - Get NftToken failed with error (%v)", err)
+		return "", err
+	}
+
+	if nftToken.OwnerId != sellerWalletId {
+		glogger.GetInstance().Error(ctx, "AcceptOffer - Seller wallet not match owner of nft token")
+		return "", helper.RespError(errorcode.BizNftNotPermission)
+	}
+
+	sellerWallet, err := e.GetActiveWallet(ctx, sellerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "AcceptOffer - Get seller wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := e.RejectMultiSig(ctx, sellerWallet); err != nil {
+		return "", err
+	}
+
+	// an open listing of the seller is canceled when the seller accepts an offer
+	if nftToken.LockedBy != "" {
+		if err := e.cancelLockingListing(ctx, nftToken); err != nil {
+			return "", err
+		}
+	}
+
+	if err := e.lockNft(ctx, nftToken, offerEntity.Id); err != nil {
+		return "", err
+	}
+
+	offerEntity.SellerWalletId = sellerWalletId
+	offerEntity.Status = listing.Filled
+	if err := e.updateOffer(ctx, offerEntity); err != nil {
+		return "", err
+	}
+
+	txId, err := e.createSettlement(ctx, offerEntity.BuyerWalletId, sellerWalletId, offerEntity.PriceTokenId,
+		offerEntity.NftTokenId, offerEntity.Price, offerEntity.Id)
+	if err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Exchange Service - AcceptOffer succeed (%s)-----------", txId)
+
+	return txId, nil
+}
+
+func (e *exchangeService) GetListings(ctx contractapi.TransactionContextInterface, nftTokenId, sellerWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - GetListings-----------")
+
+	queryString := query.GetListingQueryString("OwnerWalletId", sellerWalletId)
+	if nftTokenId != "" {
+		queryString = query.GetListingQueryString("NftTokenId", nftTokenId)
+	}
+
+	listings, err := e.QueryDocuments(ctx, queryString)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetListings - Query listings failed with error (%v)", err)
+		return "", err
+	}
+
+	return helper.MarshalStruct(listings), nil
+}
+
+func (e *exchangeService) GetOffers(ctx contractapi.TransactionContextInterface, nftTokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Exchange Service - GetOffers-----------")
+
+	offers, err := e.QueryDocuments(ctx, query.GetOfferByNftQueryString(nftTokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetOffers - Query offers failed with error (%v)", err)
+		return "", err
+	}
+
+	return helper.MarshalStruct(offers), nil
+}
+
+func (e *exchangeService) validateExpiry(ctx contractapi.TransactionContextInterface, expiry int64) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if helper.IsExpired(expiry, txTime.Seconds) {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Expiry (%d) has passed", expiry)
+		return helper.RespError(errorcode.BizListingExpired)
+	}
+	return nil
+}
+
+func (e *exchangeService) getOpenListing(ctx contractapi.TransactionContextInterface, listingId string) (*entity.Exchange, error) {
+	listingEntity, err := e.GetExchange(ctx, listingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if listingEntity.Status != listing.Open {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Listing (%s) has status (%s)", listingId, listingEntity.Status)
+		return nil, helper.RespError(errorcode.BizListingInvalidStatus)
+	}
+	return listingEntity, nil
+}
+
+func (e *exchangeService) getOpenOffer(ctx contractapi.TransactionContextInterface, offerId string) (*entity.Offer, error) {
+	offerEntity, err := e.GetOffer(ctx, offerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if offerEntity.Status != listing.Open {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Offer (%s) has status (%s)", offerId, offerEntity.Status)
+		return nil, helper.RespError(errorcode.BizListingInvalidStatus)
+	}
+	return offerEntity, nil
+}
+
+// cancelLockingListing cancel the open listing of the owner that is locking nft token
+func (e *exchangeService) cancelLockingListing(ctx contractapi.TransactionContextInterface, nftToken *entity.NFT) error {
+	isExisted, _, err := e.Repo.GetAndCheckExist(ctx, doc.Exchange, helper.ExchangeKey(nftToken.LockedBy))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Check listing (%s) failed with error (%v)", nftToken.LockedBy, err)
+		return helper.RespError(errorcode.BizUnableGetExchange)
+	}
+
+	if !isExisted {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - NftToken is locked by (%s)", nftToken.LockedBy)
+		return helper.RespError(errorcode.BizNftLocked)
+	}
+
+	listingEntity, err := e.getOpenListing(ctx, nftToken.LockedBy)
+	if err != nil {
+		return err
+	}
+
+	if listingEntity.OwnerWalletId != nftToken.OwnerId {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - NftToken is locked by (%s)", nftToken.LockedBy)
+		return helper.RespError(errorcode.BizNftLocked)
+	}
+
+	listingEntity.Status = listing.Canceled
+	return e.updateListing(ctx, listingEntity)
+}
+
+func (e *exchangeService) holdPrice(ctx contractapi.TransactionContextInterface, walletId, tokenId, price string) error {
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := e.HoldAmount(ctx, balanceMap, walletId, tokenId, price); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Hold price of wallet (%s) failed with error (%v)", walletId, err)
+		return helper.RespError(errorcode.BizUnableHoldBalance)
+	}
+	return e.UpdateBalance(ctx, balanceMap)
+}
+
+func (e *exchangeService) lockNft(ctx contractapi.TransactionContextInterface, nftToken *entity.NFT, lockedBy string) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	nftToken.LockedBy = lockedBy
+	nftToken.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := e.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Update NftToken (%s) failed with error (%v)", nftToken.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateNFT)
+	}
+	return nil
+}
+
+func (e *exchangeService) updateListing(ctx contractapi.TransactionContextInterface, listingEntity *entity.Exchange) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	listingEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := e.Repo.Update(ctx, listingEntity, doc.Exchange, helper.ExchangeKey(listingEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Update listing (%s) failed with error (%v)", listingEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateExchange)
+	}
+	return nil
+}
+
+func (e *exchangeService) updateOffer(ctx contractapi.TransactionContextInterface, offerEntity *entity.Offer) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	offerEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := e.Repo.Update(ctx, offerEntity, doc.Offer, helper.OfferKey(offerEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Update offer (%s) failed with error (%v)", offerEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateOffer)
+	}
+	return nil
+}
+
+// createSettlement create the transaction moving the held price to the seller and the nft token to the buyer
+func (e *exchangeService) createSettlement(ctx contractapi.TransactionContextInterface, buyerWalletId, sellerWalletId,
+	priceTokenId, nftTokenId, price, refId string) (string, error) {
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = buyerWalletId
+	txEntity.FromWallet = buyerWalletId
+	txEntity.ToWallet = sellerWalletId
+	txEntity.FromTokenId = priceTokenId
+	txEntity.ToTokenId = nftTokenId
+	txEntity.FromTokenAmount = price
+	txEntity.ToTokenAmount = price
+	txEntity.TxType = transaction.NftSettlement
+	txEntity.Note = refId
+
+	if err := e.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange Service - Create settlement transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+	return txEntity.Id, nil
+}
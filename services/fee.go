@@ -0,0 +1,38 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import (
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Fee is the fee schedule of transactions. The fee is charged when the transaction is settled by accounting
+type Fee interface {
+	// SetFeeSchedule to create or update the fee schedule of a transaction type of a token. Only admin is allowed
+	SetFeeSchedule(ctx contractapi.TransactionContextInterface, schedule token.FeeSchedule) (string, error)
+
+	// GetFeeSchedule return the fee schedule of a transaction type of a token
+	GetFeeSchedule(ctx contractapi.TransactionContextInterface, tokenId string, txType transaction.Type) (string, error)
+
+	// QuoteFee return the fee that a transaction of amount will be charged
+	QuoteFee(ctx contractapi.TransactionContextInterface, tokenId string, txType transaction.Type, amount string) (string, error)
+}
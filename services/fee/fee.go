@@ -0,0 +1,139 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package fee
+
+import (
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/fee"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type feeService struct {
+	*base.Base
+}
+
+func NewFeeService() services.Fee {
+	return &feeService{base.NewBase()}
+}
+
+// Quote is the fee charged on a transaction
+type Quote struct {
+	FeeAmount  string `json:"feeAmount"`
+	FeeTokenId string `json:"feeTokenId"`
+	FeeWallet  string `json:"feeWallet"`
+}
+
+func (f *feeService) SetFeeSchedule(ctx contractapi.TransactionContextInterface, schedule token.FeeSchedule) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Service - SetFeeSchedule-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "SetFeeSchedule - Caller is not admin")
+		return "", helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	if _, err := f.GetTokenType(ctx, schedule.TokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SetFeeSchedule - Get token failed with error (%v)", err)
+		return "", err
+	}
+
+	if schedule.FeeTokenId != "" {
+		if _, err := f.GetTokenType(ctx, schedule.FeeTokenId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "SetFeeSchedule - Get fee token failed with error (%v)", err)
+			return "", err
+		}
+	}
+
+	if _, err := f.GetActiveWallet(ctx, schedule.FeeWallet); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SetFeeSchedule - Get fee wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	scheduleEntity, isExisted, err := f.GetAndCheckExistFeeSchedule(ctx, schedule.TokenId, schedule.TxType)
+	if err != nil {
+		return "", err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if !isExisted {
+		scheduleEntity = entity.NewFeeSchedule(ctx)
+		scheduleEntity.Id = helper.GenerateID(doc.FeeSchedule, schedule.TokenId+string(schedule.TxType))
+	}
+	schedule.ToEntity(scheduleEntity)
+	scheduleEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+
+	if err := f.Repo.Update(ctx, scheduleEntity, doc.FeeSchedule, helper.FeeScheduleKey(schedule.TokenId, string(schedule.TxType))); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SetFeeSchedule - Update fee schedule failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableUpdateFeeSchedule)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Fee Service - SetFeeSchedule succeed (%s)-----------", scheduleEntity.Id)
+
+	return scheduleEntity.Id, nil
+}
+
+func (f *feeService) GetFeeSchedule(ctx contractapi.TransactionContextInterface, tokenId string, txType transaction.Type) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Service - GetFeeSchedule-----------")
+
+	schedule, isExisted, err := f.GetAndCheckExistFeeSchedule(ctx, tokenId, txType)
+	if err != nil {
+		return "", err
+	}
+
+	if !isExisted {
+		glogger.GetInstance().Errorf(ctx, "GetFeeSchedule - Fee schedule of token (%s) type (%s) does not exist", tokenId, txType)
+		return "", helper.RespError(errorcode.BizUnableGetFeeSchedule)
+	}
+
+	return helper.MarshalStruct(schedule), nil
+}
+
+func (f *feeService) QuoteFee(ctx contractapi.TransactionContextInterface, tokenId string, txType transaction.Type, amount string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Fee Service - QuoteFee-----------")
+
+	quote := Quote{FeeAmount: "0", FeeTokenId: tokenId}
+	schedule, isExisted, err := f.GetAndCheckExistFeeSchedule(ctx, tokenId, txType)
+	if err != nil {
+		return "", err
+	}
+
+	if !isExisted || schedule.Status != glossary.Active {
+		return helper.MarshalStruct(quote), nil
+	}
+
+	if quote.FeeAmount, err = fee.Calculate(schedule, amount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "QuoteFee - Calculate fee failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCalculateFee)
+	}
+
+	if schedule.FeeTokenId != "" {
+		quote.FeeTokenId = schedule.FeeTokenId
+	}
+	quote.FeeWallet = schedule.FeeWallet
+
+	return helper.MarshalStruct(quote), nil
+}
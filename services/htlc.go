@@ -0,0 +1,40 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Htlc is the hashed time-locked transfer used for atomic swaps with other ledgers.
+// The amount is held when locked, the receiver claims it with the preimage of the hash lock before the timeout
+// and the sender is refunded after the timeout. Both are settled by accounting.
+type Htlc interface {
+	// LockTransfer to hold amount of the from wallet for the to wallet under a hash lock until timeout (unix seconds)
+	LockTransfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount, hashLock string, timeout int64) (string, error)
+
+	// ClaimTransfer to settle a locked transfer to the receiver with the preimage of the hash lock
+	ClaimTransfer(ctx contractapi.TransactionContextInterface, lockId, preimage string) (string, error)
+
+	// RefundTransfer to settle a locked transfer back to the sender after the timeout, unless its preimage was revealed.
+	// The claim and refund transactions can not be canceled
+	RefundTransfer(ctx contractapi.TransactionContextInterface, lockId string) (string, error)
+
+	// GetLock return the hashed time-locked transfer
+	GetLock(ctx contractapi.TransactionContextInterface, lockId string) (string, error)
+}
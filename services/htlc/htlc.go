@@ -0,0 +1,199 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package htlc
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/htlc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type htlcService struct {
+	*base.Base
+}
+
+func NewHtlcService() services.Htlc {
+	return &htlcService{base.NewBase()}
+}
+
+func (h *htlcService) LockTransfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount,
+	hashLock string, timeout int64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Service - LockTransfer-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	walletFrom, _, err := h.ValidatePairWallet(ctx, fromWalletId, toWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Validation pair wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the lock is written without signatures, so it would bypass the threshold of a multi-signature wallet
+	if walletFrom.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Wallet (%s) is a multi-signature wallet", fromWalletId)
+		return "", helper.RespError(errorcode.BizHashLockMultiSig)
+	}
+
+	if err := h.ValidateTokenControl(ctx, fromWalletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Validation token control failed with error (%v)", err)
+		return "", err
+	}
+
+	if timeout <= txTime.Seconds {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Timeout (%d) has passed", timeout)
+		return "", helper.RespError(errorcode.BizHashLockTimeout)
+	}
+
+	balanceMap := make(map[string]*entity.BalanceCache, 1)
+	if err := h.HoldAmount(ctx, balanceMap, fromWalletId, tokenId, amount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Hold amount failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableHoldBalance)
+	}
+	if err := h.UpdateBalance(ctx, balanceMap); err != nil {
+		return "", err
+	}
+
+	lockEntity := entity.NewHashLock(ctx)
+	lockEntity.FromWallet = fromWalletId
+	lockEntity.ToWallet = toWalletId
+	lockEntity.TokenId = tokenId
+	lockEntity.Amount = amount
+	lockEntity.HashLock = hashLock
+	lockEntity.Timeout = timeout
+
+	if err := h.Repo.Create(ctx, lockEntity, doc.HashLock, helper.HashLockKey(lockEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "LockTransfer - Create hash lock failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateHashLock)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Htlc Service - LockTransfer succeed (%s)-----------", lockEntity.Id)
+
+	return lockEntity.Id, nil
+}
+
+func (h *htlcService) ClaimTransfer(ctx contractapi.TransactionContextInterface, lockId, preimage string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Service - ClaimTransfer-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	lockEntity, err := h.getLockedTransfer(ctx, lockId)
+	if err != nil {
+		return "", err
+	}
+
+	// a claim revealed before the timeout is settled again after the timeout
+	if lockEntity.Preimage == "" && txTime.Seconds >= lockEntity.Timeout {
+		glogger.GetInstance().Errorf(ctx, "ClaimTransfer - Time lock of (%s) has passed", lockId)
+		return "", helper.RespError(errorcode.BizHashLockTimeout)
+	}
+
+	if !helper.MatchHashLock(lockEntity.HashLock, preimage) {
+		glogger.GetInstance().Errorf(ctx, "ClaimTransfer - Preimage does not match hash lock of (%s)", lockId)
+		return "", helper.RespError(errorcode.BizInvalidPreimage)
+	}
+
+	// the preimage is revealed so the counterparty can claim on the other ledger
+	lockEntity.Preimage = preimage
+	return h.settle(ctx, lockEntity, transaction.HtlcClaim, htlc.Claimed)
+}
+
+func (h *htlcService) RefundTransfer(ctx contractapi.TransactionContextInterface, lockId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Service - RefundTransfer-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	lockEntity, err := h.getLockedTransfer(ctx, lockId)
+	if err != nil {
+		return "", err
+	}
+
+	if txTime.Seconds < lockEntity.Timeout {
+		glogger.GetInstance().Errorf(ctx, "RefundTransfer - Time lock of (%s) has not passed", lockId)
+		return "", helper.RespError(errorcode.BizHashLockNotTimeout)
+	}
+
+	// the receiver knows the preimage once it is revealed, the sender must not take the amount back
+	if lockEntity.Preimage != "" {
+		glogger.GetInstance().Errorf(ctx, "RefundTransfer - Preimage of (%s) is revealed", lockId)
+		return "", helper.RespError(errorcode.BizHashLockRevealed)
+	}
+
+	return h.settle(ctx, lockEntity, transaction.HtlcRefund, htlc.Refunded)
+}
+
+func (h *htlcService) GetLock(ctx contractapi.TransactionContextInterface, lockId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Htlc Service - GetLock-----------")
+
+	lockEntity, err := h.GetHashLock(ctx, lockId)
+	if err != nil {
+		return "", err
+	}
+
+	return helper.MarshalStruct(lockEntity), nil
+}
+
+func (h *htlcService) getLockedTransfer(ctx contractapi.TransactionContextInterface, lockId string) (*entity.HashLock, error) {
+	lockEntity, err := h.GetHashLock(ctx, lockId)
+	if err != nil {
+		return nil, err
+	}
+
+	if lockEntity.Status != htlc.Locked {
+		glogger.GetInstance().Errorf(ctx, "Htlc Service - Hash lock (%s) has status (%s)", lockId, lockEntity.Status)
+		return nil, helper.RespError(errorcode.BizHashLockInvalidStatus)
+	}
+	return lockEntity, nil
+}
+
+// settle create the transaction moving the held amount and mark the lock claimed or refunded
+func (h *htlcService) settle(ctx contractapi.TransactionContextInterface, lockEntity *entity.HashLock, txType transaction.Type,
+	status htlc.Status) (string, error) {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = lockEntity.FromWallet
+	txEntity.FromWallet = lockEntity.FromWallet
+	txEntity.ToWallet = lockEntity.ToWallet
+	txEntity.FromTokenId = lockEntity.TokenId
+	txEntity.ToTokenId = lockEntity.TokenId
+	txEntity.FromTokenAmount = lockEntity.Amount
+	txEntity.ToTokenAmount = lockEntity.Amount
+	txEntity.TxType = txType
+	txEntity.Note = lockEntity.Id
+
+	if err := h.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Htlc Service - Create (%s) transaction failed with error (%v)", txType, err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+
+	lockEntity.TxId = txEntity.Id
+	lockEntity.Status = status
+	lockEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := h.Repo.Update(ctx, lockEntity, doc.HashLock, helper.HashLockKey(lockEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Htlc Service - Update hash lock (%s) failed with error (%v)", lockEntity.Id, err)
+		return "", helper.RespError(errorcode.BizUnableUpdateHashLock)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Htlc Service - (%s) succeed (%s)-----------", txType, txEntity.Id)
+
+	return txEntity.Id, nil
+}
@@ -21,6 +21,7 @@ package services
 
 import (
 	"github.com/Akachain/gringotts/dto/iao"
+	"github.com/Akachain/gringotts/entity"
 	statusIao "github.com/Akachain/gringotts/glossary/iao"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
@@ -29,18 +30,41 @@ type Iao interface {
 	// CreateAsset to create new asset and token type
 	CreateAsset(ctx contractapi.TransactionContextInterface, code, name, ownerWallet, tokenName, tickerToken, maxSupply, totalValue, documentUrl string) (string, error)
 
-	// CreateIao to create new iao of asset
-	CreateIao(ctx contractapi.TransactionContextInterface, assetId, assetTokenAmount, startDate, endDate string, rate int64) (string, error)
+	// CreateIao to create new iao of asset, the distributed asset token is locked by vestingPlan when it is not empty.
+	// An iao with ProRata or Tiered allocation collects commitments instead of filling purchases, see AllocateIao.
+	// The sale is bounded by limit, it is canceled at the end date when its soft cap is not sold.
+	// With kycTiers, only the wallets of the eligibility list of the iao can buy.
+	// The iao is only paid in the tokens of prices at their price, there must be at least one
+	CreateIao(ctx contractapi.TransactionContextInterface, assetId, assetTokenAmount, startDate, endDate string, rate int64, vestingPlan entity.VestingPlan,
+		allocation statusIao.Allocation, allocationTier string, limit entity.IaoLimit, kycTiers []entity.KycTier, prices []entity.IaoPrice) (string, error)
 
-	// UpdateStatusIao to update status of IAO
+	// UpdateStatusIao to update status of IAO following the transitions of the iao status
 	UpdateStatusIao(ctx contractapi.TransactionContextInterface, iaoId string, status statusIao.Status) error
 
-	// BuyBatchAsset to handle multiple request buy asset
+	// BuyBatchAsset to handle multiple request buy asset, an iao only sells between its start and end date
 	BuyBatchAsset(ctx contractapi.TransactionContextInterface, req []iao.BuyAsset) (string, error)
 
 	// FinalizeIao to finish IAO and distribute AT to investor
 	FinalizeIao(ctx contractapi.TransactionContextInterface, iaoId []string) error
 
+	// AllocateIao to fill the commitments of a closed iao out of its remaining asset token, to write the investor book
+	// of the fills and to return the excess stable token through ReturnST transactions. A canceling iao returns every commitment.
+	// It returns the transaction id keying the investor book with the iao id
+	AllocateIao(ctx contractapi.TransactionContextInterface, iaoId string) (string, error)
+
+	// GetIaoStatus return the progress of the sale of iao toward its soft and hard cap
+	GetIaoStatus(ctx contractapi.TransactionContextInterface, iaoId string) (string, error)
+
+	// AddEligibility to add or replace wallets in the eligibility list of an iao with KYC tiers. Only admin is allowed
+	AddEligibility(ctx contractapi.TransactionContextInterface, iaoId string, entries []iao.Eligibility) error
+
+	// RemoveEligibility to remove wallets from the eligibility list of an iao. Only admin is allowed
+	RemoveEligibility(ctx contractapi.TransactionContextInterface, iaoId string, walletIds []string) error
+
+	// GetEligibility return the eligibility of wallet in iao, or the page of the eligibility list of iao after lastWalletId
+	// when walletId is empty
+	GetEligibility(ctx contractapi.TransactionContextInterface, iaoId, walletId, lastWalletId string) (string, error)
+
 	// CancelIao to cancel iao and return ST to investor
 	CancelIao(ctx contractapi.TransactionContextInterface, lstInvestorBook []string) error
 }
@@ -0,0 +1,180 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package iao
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/dto/iao"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+func (i *iaoService) AddEligibility(ctx contractapi.TransactionContextInterface, iaoId string, entries []iao.Eligibility) error {
+	glogger.GetInstance().Info(ctx, "-----------Iao Service - AddEligibility-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "AddEligibility - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	iaoEntity, err := i.GetIao(ctx, iaoId)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if _, ok := iaoEntity.KycTier(entry.Tier); !ok {
+			glogger.GetInstance().Errorf(ctx, "AddEligibility - KYC tier (%s) of wallet (%s) is not defined in iao (%s)", entry.Tier, entry.WalletId, iaoId)
+			return helper.RespError(errorcode.BizIaoKycTierNotFound)
+		}
+
+		eligibility := entity.NewIaoEligibility(ctx)
+		eligibility.Id = helper.GenerateID(doc.IaoEligibility, iaoId+"_"+entry.WalletId)
+		eligibility.IaoId = iaoId
+		eligibility.WalletId = entry.WalletId
+		eligibility.Tier = entry.Tier
+		eligibility.Jurisdiction = entry.Jurisdiction
+		eligibility.Expiry = entry.Expiry
+		eligibility.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+		if err := i.Repo.Update(ctx, eligibility, doc.IaoEligibility, helper.IaoEligibilityKey(iaoId, entry.WalletId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "AddEligibility - Update eligibility of wallet (%s) failed with err (%v)", entry.WalletId, err)
+			return helper.RespError(errorcode.BizUnableUpdateIaoEligibility)
+		}
+	}
+
+	glogger.GetInstance().Infof(ctx, "-----------Iao Service - AddEligibility succeed (%d)-----------", len(entries))
+
+	return nil
+}
+
+func (i *iaoService) RemoveEligibility(ctx contractapi.TransactionContextInterface, iaoId string, walletIds []string) error {
+	glogger.GetInstance().Info(ctx, "-----------Iao Service - RemoveEligibility-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "RemoveEligibility - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	for _, walletId := range walletIds {
+		if err := i.Repo.Delete(ctx, doc.IaoEligibility, helper.IaoEligibilityKey(iaoId, walletId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "RemoveEligibility - Delete eligibility of wallet (%s) failed with err (%v)", walletId, err)
+			return helper.RespError(errorcode.BizUnableUpdateIaoEligibility)
+		}
+	}
+
+	glogger.GetInstance().Infof(ctx, "-----------Iao Service - RemoveEligibility succeed (%d)-----------", len(walletIds))
+
+	return nil
+}
+
+func (i *iaoService) GetEligibility(ctx contractapi.TransactionContextInterface, iaoId, walletId, lastWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Service - GetEligibility-----------")
+
+	if walletId != "" {
+		eligibility, isExisted, err := i.getAndCheckEligibility(ctx, iaoId, walletId)
+		if err != nil {
+			return "", err
+		}
+		if !isExisted {
+			glogger.GetInstance().Errorf(ctx, "GetEligibility - Wallet (%s) is not in eligibility list of iao (%s)", walletId, iaoId)
+			return "", helper.RespError(errorcode.BizUnableGetIaoEligibility)
+		}
+		return helper.MarshalStruct(eligibility), nil
+	}
+
+	documents, err := i.QueryDocumentsLimit(ctx, query.GetIaoEligibilityQueryString(iaoId, lastWalletId), int(glossary.PaginationSize))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetEligibility - Get eligibility list of iao (%s) failed with err (%v)", iaoId, err)
+		return "", helper.RespError(errorcode.BizUnableGetIaoEligibility)
+	}
+
+	eligibilities := make([]*entity.IaoEligibility, 0, len(documents))
+	for _, document := range documents {
+		eligibility := entity.NewIaoEligibility()
+		if err := json.Unmarshal(document, eligibility); err != nil {
+			glogger.GetInstance().Errorf(ctx, "GetEligibility - Unmarshal eligibility failed with err (%v)", err)
+			return "", helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		eligibilities = append(eligibilities, eligibility)
+	}
+
+	return helper.MarshalStruct(eligibilities), nil
+}
+
+// checkEligibility return the KYC tier of wallet in an iao with KYC tiers, or the reason it can not buy.
+// An iao without KYC tiers is open to every wallet.
+func (i *iaoService) checkEligibility(ctx contractapi.TransactionContextInterface, eligibilityMap map[string]*entity.IaoEligibility,
+	iaoEntity *entity.Iao, walletId string) (*entity.KycTier, error) {
+	if len(iaoEntity.KycTiers) == 0 {
+		return nil, nil
+	}
+
+	key := iaoEntity.Id + "_" + walletId
+	if _, ok := eligibilityMap[key]; !ok {
+		eligibility, _, err := i.getAndCheckEligibility(ctx, iaoEntity.Id, walletId)
+		if err != nil {
+			return nil, errors.New("Unable to get eligibility of wallet")
+		}
+		eligibilityMap[key] = eligibility
+	}
+
+	eligibility := eligibilityMap[key]
+	if eligibility == nil {
+		return nil, errors.New("Wallet is not in the eligibility list of iao")
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if helper.IsExpired(eligibility.Expiry, txTime.Seconds) {
+		return nil, errors.New("Eligibility of wallet has expired")
+	}
+
+	kycTier, ok := iaoEntity.KycTier(eligibility.Tier)
+	if !ok {
+		return nil, errors.New("KYC tier of wallet is not defined in iao")
+	}
+	return kycTier, nil
+}
+
+func (i *iaoService) getAndCheckEligibility(ctx contractapi.TransactionContextInterface, iaoId, walletId string) (*entity.IaoEligibility, bool, error) {
+	isExisted, eligibilityData, err := i.Repo.GetAndCheckExist(ctx, doc.IaoEligibility, helper.IaoEligibilityKey(iaoId, walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Iao Service - Get eligibility of wallet (%s) failed with err (%v)", walletId, err)
+		return nil, false, helper.RespError(errorcode.BizUnableGetIaoEligibility)
+	}
+	if !isExisted {
+		return nil, false, nil
+	}
+
+	eligibility := entity.NewIaoEligibility()
+	if err = mapstructure.Decode(eligibilityData, &eligibility); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Iao Service - Decode eligibility failed with err (%v)", err)
+		return nil, false, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return eligibility, true, nil
+}
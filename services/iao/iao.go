@@ -31,11 +31,13 @@ import (
 	"github.com/Akachain/gringotts/glossary/transaction"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/allocation"
 	"github.com/Akachain/gringotts/services"
 	"github.com/Akachain/gringotts/services/base"
 	"github.com/Akachain/gringotts/services/token"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 	"github.com/pkg/errors"
+	"sort"
 	"strings"
 )
 
@@ -81,7 +83,8 @@ func (i *iaoService) CreateAsset(ctx contractapi.TransactionContextInterface, co
 	return result, nil
 }
 
-func (i *iaoService) CreateIao(ctx contractapi.TransactionContextInterface, assetId, assetTokenAmount, startDate, endDate string, rate int64) (string, error) {
+func (i *iaoService) CreateIao(ctx contractapi.TransactionContextInterface, assetId, assetTokenAmount, startDate, endDate string, rate int64, vestingPlan entity.VestingPlan,
+	allocation statusIao.Allocation, allocationTier string, limit entity.IaoLimit, kycTiers []entity.KycTier, prices []entity.IaoPrice) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------Iao Service - CreateIao-----------")
 
 	assetEntity, err := i.GetAsset(ctx, assetId)
@@ -95,6 +98,29 @@ func (i *iaoService) CreateIao(ctx contractapi.TransactionContextInterface, asse
 		return "", helper.RespError(errorcode.BizUnableCreateIao)
 	}
 
+	startTime, err := helper.ParseTimestamp(startDate)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Iao Service - Parse start date (%s) failed with err (%s)", startDate, err.Error())
+		return "", helper.RespError(errorcode.BizIaoInvalidDate)
+	}
+	endTime, err := helper.ParseTimestamp(endDate)
+	if err != nil || endTime <= startTime {
+		glogger.GetInstance().Errorf(ctx, "Iao Service - End date (%s) is invalid", endDate)
+		return "", helper.RespError(errorcode.BizIaoInvalidDate)
+	}
+
+	// the iao is paid in at least one accepted token, they must be active
+	if len(prices) == 0 {
+		glogger.GetInstance().Error(ctx, "Iao Service - Iao has no accepted payment token")
+		return "", helper.RespError(errorcode.BizUnableCreateIao)
+	}
+	for _, price := range prices {
+		if _, err := i.GetActiveToken(ctx, price.TokenId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Iao Service - Accepted token (%s) is not active with err (%v)", price.TokenId, err)
+			return "", err
+		}
+	}
+
 	iaoEntity := entity.NewIao(ctx)
 	iaoEntity.AssetId = assetId
 	iaoEntity.AssetTokenId = assetEntity.TokenId
@@ -102,7 +128,16 @@ func (i *iaoService) CreateIao(ctx contractapi.TransactionContextInterface, asse
 	iaoEntity.StableTokenAmount = "0"
 	iaoEntity.StartDate = startDate
 	iaoEntity.EndDate = endDate
+	iaoEntity.StartTime = startTime
+	iaoEntity.EndTime = endTime
+	iaoEntity.Allocation = allocation
+	iaoEntity.AllocationTier = allocationTier
+	iaoEntity.CommittedAssetToken = "0"
+	iaoEntity.Limit = limit
+	iaoEntity.KycTiers = kycTiers
 	iaoEntity.Rate = rate
+	iaoEntity.Prices = prices
+	iaoEntity.Vesting = vestingPlan
 
 	if err := i.Repo.Create(ctx, iaoEntity, doc.Iao, helper.IaoKey(iaoEntity.Id)); err != nil {
 		glogger.GetInstance().Errorf(ctx, "Iao Service - Create iao campaign of asset failed with error (%s)", err.Error())
@@ -150,6 +185,9 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 	balanceMap := make(map[string]*entity.BalanceCache, len(batchReq))
 	resultHandle := make([]iao.ResultHandle, 0, len(batchReq))
 	investorMap := make(map[string]*entity.InvestorBuyIao, len(batchReq))
+	commitmentMap := make(map[string]*entity.IaoCommitment, 0)
+	iaoInvestorMap := make(map[string]*entity.IaoInvestor, 0)
+	eligibilityMap := make(map[string]*entity.IaoEligibility, 0)
 
 	for _, req := range batchReq {
 		res := req.CloneToResult()
@@ -160,6 +198,57 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 			resultHandle = append(resultHandle, res)
 			continue
 		}
+
+		// limits apply to the total of wallet over all its purchases in the iao
+		iaoInvestor, err := i.getIaoInvestor(ctx, iaoInvestorMap, req.IaoId, req.WalletId)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) failed get total of investor with err (%v)", req.ReqId, err)
+			res.Status = transaction.Rejected
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+
+		if !iaoEntity.IsAccepted(req.TokenId) {
+			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) token (%s) is not accepted by iao", req.ReqId, req.TokenId)
+			res.Status = transaction.Rejected
+			res.Reason = "Token is not accepted by iao"
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+
+		kycTier, err := i.checkEligibility(ctx, eligibilityMap, iaoEntity, req.WalletId)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) wallet is not eligible with err (%v)", req.ReqId, err)
+			res.Status = transaction.Rejected
+			res.Reason = err.Error()
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+
+		// commitments are filled by the allocation run when the iao is closed
+		if iaoEntity.Allocation.IsCommitment() {
+			if err := checkPurchaseLimit(iaoEntity.Limit, kycTier, iaoInvestor.AssetTokenAmount, req.NumberAT); err != nil {
+				glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) out of purchase limit with err (%v)", req.ReqId, err)
+				res.Status = transaction.Rejected
+				res.Reason = err.Error()
+				resultHandle = append(resultHandle, res)
+				continue
+			}
+			stableToken, err := i.addCommitment(ctx, commitmentMap, balanceMap, iaoEntity, req)
+			if err != nil {
+				glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) failed to commit with err (%v)", req.ReqId, err)
+				res.Status = transaction.Rejected
+				resultHandle = append(resultHandle, res)
+				continue
+			}
+			iaoInvestor.AssetTokenAmount, _ = helper.AddBalance(iaoInvestor.AssetTokenAmount, req.NumberAT)
+			res.Status = transaction.Pending
+			res.NumberATFilled = "0"
+			res.NumberST = stableToken
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+
 		if helper.CompareStringBalance(iaoEntity.RemainingAssetToken, "0") <= 0 {
 			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) remaining of iao is zero", req.ReqId)
 			res.Status = transaction.Rejected
@@ -175,7 +264,28 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 			numberATBuy = iaoEntity.RemainingAssetToken
 		}
 
-		stableToken, err := helper.MulBalance(numberATBuy, iaoEntity.Rate)
+		if iaoEntity.Limit.HardCap != "" {
+			if helper.CompareStringBalance(iaoEntity.SoldAssetToken(), iaoEntity.Limit.HardCap) >= 0 {
+				glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) hard cap of iao is sold", req.ReqId)
+				res.Status = transaction.Rejected
+				resultHandle = append(resultHandle, res)
+				continue
+			}
+			hardCapLeft, _ := helper.SubBalance(iaoEntity.Limit.HardCap, iaoEntity.SoldAssetToken())
+			if helper.CompareStringBalance(numberATBuy, hardCapLeft) > 0 {
+				numberATBuy = hardCapLeft
+			}
+		}
+
+		if err := checkPurchaseLimit(iaoEntity.Limit, kycTier, iaoInvestor.AssetTokenAmount, numberATBuy); err != nil {
+			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) out of purchase limit with err (%v)", req.ReqId, err)
+			res.Status = transaction.Rejected
+			res.Reason = err.Error()
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+
+		stableToken, err := iaoEntity.Cost(req.TokenId, numberATBuy)
 		if err != nil {
 			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) failed to calculate stable token", req.ReqId)
 			res.Status = transaction.Rejected
@@ -191,7 +301,7 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 			continue
 		}
 
-		if err := i.addInvestorBook(investorMap, req, stableToken, iaoEntity.AssetTokenId); err != nil {
+		if err := i.addInvestorBook(investorMap, req, numberATBuy, stableToken, iaoEntity.AssetTokenId); err != nil {
 			glogger.GetInstance().Errorf(ctx, "BuyBatchAsset - Handle req (%s) failed get create investor book", req.ReqId, err.Error())
 			res.Status = transaction.Rejected
 			resultHandle = append(resultHandle, res)
@@ -202,6 +312,7 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 		iaoEntity.RemainingAssetToken = updateATRemain
 		iaoEntity.StableTokenAmount = updateST
 		iaoMap[iaoEntity.Id] = iaoEntity
+		iaoInvestor.AssetTokenAmount, _ = helper.AddBalance(iaoInvestor.AssetTokenAmount, numberATBuy)
 
 		res.Status = transaction.Confirmed
 		res.NumberATFilled = numberATBuy
@@ -228,6 +339,14 @@ func (i *iaoService) BuyBatchAsset(ctx contractapi.TransactionContextInterface,
 		return "", err
 	}
 
+	if err := i.updateCommitment(ctx, commitmentMap); err != nil {
+		return "", err
+	}
+
+	if err := i.updateIaoInvestor(ctx, iaoInvestorMap); err != nil {
+		return "", err
+	}
+
 	buyCache := entity.NewIaoCache(ctx)
 	buyCache.Hash = inputHash
 	buyCache.Result = string(resultJson)
@@ -249,6 +368,14 @@ func (i *iaoService) UpdateStatusIao(ctx contractapi.TransactionContextInterface
 	}
 
 	if iaoEntity.Status != status {
+		if !iaoEntity.Status.CanTransitTo(status) {
+			glogger.GetInstance().Errorf(ctx, "UpdateStatus - Iao (%s) can not move from (%s) to (%s)", iaoId, iaoEntity.Status, status)
+			return helper.RespError(errorcode.BizIaoInvalidTransition)
+		}
+		if status == statusIao.Distributing && !iaoEntity.IsSoftCapReached() {
+			glogger.GetInstance().Errorf(ctx, "UpdateStatus - Iao (%s) sold (%s) under soft cap (%s)", iaoId, iaoEntity.SoldAssetToken(), iaoEntity.Limit.SoftCap)
+			return helper.RespError(errorcode.BizIaoSoftCapNotReached)
+		}
 		iaoEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
 		iaoEntity.Status = status
 		if err := i.Repo.Update(ctx, iaoEntity, doc.Iao, helper.IaoKey(iaoEntity.Id)); err != nil {
@@ -304,9 +431,12 @@ func (i *iaoService) FinalizeIao(ctx contractapi.TransactionContextInterface, ls
 		}
 	}
 
-	for keyMap, investor := range mapInvestor {
+	// one transaction per investor, ordered so every peer writes the same ids
+	for index, keyMap := range sortedInvestorKeys(mapInvestor) {
+		investor := mapInvestor[keyMap]
 		iaoCompositeKey := strings.Split(keyMap, "_")
 		txEntity := entity.NewTransaction(ctx)
+		txEntity.Id = helper.GenerateBatchID(doc.Transactions, ctx.GetStub().GetTxID(), index)
 		txEntity.SpenderWallet = iaoCompositeKey[0]
 		txEntity.FromWallet = iaoCompositeKey[0]
 		txEntity.ToWallet = investor.WalletId
@@ -315,6 +445,7 @@ func (i *iaoService) FinalizeIao(ctx contractapi.TransactionContextInterface, ls
 		txEntity.FromTokenAmount = investor.AssetTokenAmount
 		txEntity.ToTokenAmount = investor.AssetTokenAmount
 		txEntity.TxType = transaction.DistributionAT
+		txEntity.Note = iaoCompositeKey[0]
 		if err := i.Repo.Update(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
 			glogger.GetInstance().Errorf(ctx, "FinalizeIao - Create distribution AT  failed with err (%v)", err.Error())
 			return helper.RespError(errorcode.BizUnableUpdateInvestorBook)
@@ -368,9 +499,12 @@ func (i *iaoService) CancelIao(ctx contractapi.TransactionContextInterface, lstI
 		}
 	}
 
-	for keyMap, investor := range mapInvestor {
+	// one transaction per investor, ordered so every peer writes the same ids
+	for index, keyMap := range sortedInvestorKeys(mapInvestor) {
+		investor := mapInvestor[keyMap]
 		iaoCompositeKey := strings.Split(keyMap, "_")
 		txEntity := entity.NewTransaction(ctx)
+		txEntity.Id = helper.GenerateBatchID(doc.Transactions, ctx.GetStub().GetTxID(), index)
 		txEntity.SpenderWallet = iaoCompositeKey[0]
 		txEntity.FromWallet = iaoCompositeKey[0]
 		txEntity.ToWallet = investor.WalletId
@@ -388,6 +522,178 @@ func (i *iaoService) CancelIao(ctx contractapi.TransactionContextInterface, lstI
 	return nil
 }
 
+func (i *iaoService) AllocateIao(ctx contractapi.TransactionContextInterface, iaoId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Service - AllocateIao-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	iaoEntity, err := i.GetIao(ctx, iaoId)
+	if err != nil {
+		return "", err
+	}
+
+	if !iaoEntity.Allocation.IsCommitment() {
+		glogger.GetInstance().Errorf(ctx, "AllocateIao - Iao (%s) has allocation (%s)", iaoId, iaoEntity.Allocation)
+		return "", helper.RespError(errorcode.BizIaoNotCommitment)
+	}
+
+	if iaoEntity.Allocated {
+		glogger.GetInstance().Errorf(ctx, "AllocateIao - Iao (%s) was allocated", iaoId)
+		return "", helper.RespError(errorcode.BizIaoAllocated)
+	}
+
+	// the sale must be closed, a canceling iao fills nothing and returns every commitment.
+	// No more than the hard cap is filled
+	supply := iaoEntity.RemainingAssetToken
+	if iaoEntity.Limit.HardCap != "" && helper.CompareStringBalance(supply, iaoEntity.Limit.HardCap) > 0 {
+		supply = iaoEntity.Limit.HardCap
+	}
+	switch iaoEntity.Status {
+	case statusIao.Distributing:
+	case statusIao.Canceling:
+		supply = "0"
+	default:
+		glogger.GetInstance().Errorf(ctx, "AllocateIao - Iao (%s) has status (%s)", iaoId, iaoEntity.Status)
+		return "", helper.RespError(errorcode.BizIaoInvalidStatus)
+	}
+
+	commitments, err := i.GetIaoCommitments(ctx, iaoId)
+	if err != nil {
+		return "", err
+	}
+
+	amounts := make([]string, 0, len(commitments))
+	for _, commitment := range commitments {
+		amounts = append(amounts, commitment.AssetTokenAmount)
+	}
+
+	var fills []string
+	if iaoEntity.Allocation == statusIao.Tiered {
+		fills, err = allocation.Tiered(supply, iaoEntity.AllocationTier, amounts)
+	} else {
+		fills, err = allocation.ProRata(supply, amounts)
+	}
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "AllocateIao - Allocate commitments of iao (%s) failed with err (%v)", iaoId, err)
+		return "", helper.RespError(errorcode.BizUnableUpdateIao)
+	}
+
+	lstInvestor := make([]*entity.InvestorBuyIao, 0, len(commitments))
+	filledAT, filledST := "0", "0"
+	refundIndex := 0
+	for index, commitment := range commitments {
+		fillST, err := iaoEntity.Cost(commitment.StableTokenId, fills[index])
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "AllocateIao - Calculate stable token of commitment (%s) failed with err (%v)", commitment.Id, err)
+			return "", helper.RespError(errorcode.BizUnableUpdateIao)
+		}
+		refundST, err := helper.SubBalance(commitment.StableTokenAmount, fillST)
+		if err != nil {
+			return "", helper.RespError(errorcode.BizUnableUpdateIao)
+		}
+
+		if helper.CompareStringBalance(fills[index], "0") > 0 {
+			lstInvestor = append(lstInvestor, &entity.InvestorBuyIao{
+				WalletId:          commitment.WalletId,
+				AssetTokenId:      iaoEntity.AssetTokenId,
+				StableTokenId:     commitment.StableTokenId,
+				StableTokenAmount: fillST,
+				AssetTokenAmount:  fills[index],
+			})
+			filledAT, _ = helper.AddBalance(filledAT, fills[index])
+			filledST, _ = helper.AddBalance(filledST, fillST)
+		}
+
+		if helper.CompareStringBalance(refundST, "0") > 0 {
+			txEntity := entity.NewTransaction(ctx)
+			txEntity.Id = helper.GenerateBatchID(doc.Transactions, ctx.GetStub().GetTxID(), refundIndex)
+			txEntity.SpenderWallet = iaoId
+			txEntity.FromWallet = iaoId
+			txEntity.ToWallet = commitment.WalletId
+			txEntity.FromTokenId = commitment.StableTokenId
+			txEntity.ToTokenId = commitment.StableTokenId
+			txEntity.FromTokenAmount = refundST
+			txEntity.ToTokenAmount = refundST
+			txEntity.TxType = transaction.ReturnST
+			txEntity.Note = iaoId
+			if err := i.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+				glogger.GetInstance().Errorf(ctx, "AllocateIao - Create return ST failed with err (%v)", err)
+				return "", helper.RespError(errorcode.BizUnableCreateTX)
+			}
+			refundIndex++
+		}
+	}
+
+	if len(lstInvestor) > 0 {
+		strJsonInvestor, _ := json.Marshal(lstInvestor)
+		investorBookEntity := entity.NewInvestorBook(ctx)
+		investorBookEntity.Investor = string(strJsonInvestor)
+		investorBookEntity.IaoId = iaoId
+		investorBookEntity.Status = investor_book.NotDistributed
+		if err := i.Repo.Create(ctx, investorBookEntity, doc.InvestorBook, helper.InvestorBookKey(iaoId, ctx.GetStub().GetTxID())); err != nil {
+			glogger.GetInstance().Errorf(ctx, "AllocateIao - Create investor book of iao (%s) failed with err (%v)", iaoId, err)
+			return "", helper.RespError(errorcode.BizUnableCreateInvestorBook)
+		}
+	}
+
+	iaoEntity.RemainingAssetToken, _ = helper.SubBalance(iaoEntity.RemainingAssetToken, filledAT)
+	iaoEntity.StableTokenAmount, _ = helper.AddBalance(iaoEntity.StableTokenAmount, filledST)
+	iaoEntity.Allocated = true
+	iaoEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := i.Repo.Update(ctx, iaoEntity, doc.Iao, helper.IaoKey(iaoId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "AllocateIao - Update iao (%s) failed with err (%v)", iaoId, err)
+		return "", helper.RespError(errorcode.BizUnableUpdateIao)
+	}
+
+	glogger.GetInstance().Infof(ctx, "-----------Iao Service - AllocateIao succeed (%s)-----------", filledAT)
+
+	return ctx.GetStub().GetTxID(), nil
+}
+
+func (i *iaoService) GetIaoStatus(ctx contractapi.TransactionContextInterface, iaoId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Iao Service - GetIaoStatus-----------")
+
+	iaoEntity, err := i.GetIao(ctx, iaoId)
+	if err != nil {
+		return "", err
+	}
+
+	sold := iaoEntity.SoldAssetToken()
+	status := iao.IaoStatus{
+		IaoId:            iaoEntity.Id,
+		Status:           iaoEntity.Status,
+		Allocation:       iaoEntity.Allocation,
+		Rate:             iaoEntity.Rate,
+		Prices:           make([]iao.Price, 0, len(iaoEntity.Prices)),
+		StartTime:        iaoEntity.StartTime,
+		EndTime:          iaoEntity.EndTime,
+		AssetTokenAmount: iaoEntity.AssetTokenAmount,
+		Sold:             sold,
+		Remaining:        iaoEntity.RemainingAssetToken,
+		SoftCap:          iaoEntity.Limit.SoftCap,
+		HardCap:          iaoEntity.Limit.HardCap,
+		MinPurchase:      iaoEntity.Limit.MinPurchase,
+		MaxPurchase:      iaoEntity.Limit.MaxPurchase,
+		SoftCapReached:   iaoEntity.IsSoftCapReached(),
+		HardCapReached:   iaoEntity.Limit.HardCap != "" && helper.CompareStringBalance(sold, iaoEntity.Limit.HardCap) >= 0,
+	}
+
+	for _, price := range iaoEntity.Prices {
+		status.Prices = append(status.Prices, iao.Price{TokenId: price.TokenId, Price: price.Price})
+	}
+
+	return helper.MarshalStruct(status), nil
+}
+
+// sortedInvestorKeys return the keys of investors in order, map iteration order is random
+func sortedInvestorKeys(mapInvestor map[string]entity.InvestorBuyIao) []string {
+	keys := make([]string, 0, len(mapInvestor))
+	for key := range mapInvestor {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (i *iaoService) getIaoInfo(ctx contractapi.TransactionContextInterface, iaoMap map[string]*entity.Iao, iaoId string) (*entity.Iao, error) {
 	if _, ok := iaoMap[iaoId]; !ok {
 		iaoEntity, err := i.GetIao(ctx, iaoId)
@@ -402,6 +708,22 @@ func (i *iaoService) getIaoInfo(ctx contractapi.TransactionContextInterface, iao
 		return nil, errors.New("Iao has status not opening")
 	}
 
+	// the sale closes itself at the end date, the iao is saved with the other iao of the batch.
+	// It is canceled when its soft cap is not sold
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if iaoMap[iaoId].EndTime > 0 && txTime.Seconds >= iaoMap[iaoId].EndTime {
+		if iaoMap[iaoId].IsSoftCapReached() {
+			iaoMap[iaoId].Status = statusIao.Distributing
+		} else {
+			iaoMap[iaoId].Status = statusIao.Canceling
+		}
+		return nil, errors.New("Iao has ended")
+	}
+
+	if !iaoMap[iaoId].IsOnSale(txTime.Seconds) {
+		return nil, errors.New("Iao has not started")
+	}
+
 	return iaoMap[iaoId], nil
 }
 
@@ -454,7 +776,7 @@ func (i *iaoService) insertInvestorBook(ctx contractapi.TransactionContextInterf
 	return nil
 }
 
-func (i *iaoService) addInvestorBook(investorBookMap map[string]*entity.InvestorBuyIao, req iao.BuyAsset, amountST, assetTokenId string) (err error) {
+func (i *iaoService) addInvestorBook(investorBookMap map[string]*entity.InvestorBuyIao, req iao.BuyAsset, amountAT, amountST, assetTokenId string) (err error) {
 	var investor *entity.InvestorBuyIao
 	key := req.IaoId + "_" + req.WalletId
 	if _, ok := investorBookMap[key]; !ok {
@@ -469,7 +791,7 @@ func (i *iaoService) addInvestorBook(investorBookMap map[string]*entity.Investor
 		investor = investorBookMap[key]
 	}
 
-	balanceAT, err := helper.AddBalance(investor.AssetTokenAmount, req.NumberAT)
+	balanceAT, err := helper.AddBalance(investor.AssetTokenAmount, amountAT)
 	if err != nil {
 		return err
 	}
@@ -485,3 +807,111 @@ func (i *iaoService) addInvestorBook(investorBookMap map[string]*entity.Investor
 
 	return nil
 }
+
+// addCommitment hold the stable token of request from the iao balance of wallet and add it to the commitment of wallet
+func (i *iaoService) addCommitment(ctx contractapi.TransactionContextInterface, commitmentMap map[string]*entity.IaoCommitment,
+	balanceMap map[string]*entity.BalanceCache, iaoEntity *entity.Iao, req iao.BuyAsset) (string, error) {
+	if helper.CompareStringBalance(req.NumberAT, "0") <= 0 {
+		return "", errors.New("Number of asset token must be greater than zero")
+	}
+
+	stableToken, err := iaoEntity.Cost(req.TokenId, req.NumberAT)
+	if err != nil {
+		return "", err
+	}
+
+	key := req.IaoId + "_" + req.WalletId + "_" + req.TokenId
+	if _, ok := commitmentMap[key]; !ok {
+		commitment, isExisted, err := i.GetAndCheckIaoCommitment(ctx, req.IaoId, req.WalletId, req.TokenId)
+		if err != nil {
+			return "", err
+		}
+		if !isExisted {
+			commitment = entity.NewIaoCommitment(ctx)
+			commitment.Id = helper.GenerateID(doc.IaoCommitment, key)
+			commitment.IaoId = req.IaoId
+			commitment.WalletId = req.WalletId
+			commitment.StableTokenId = req.TokenId
+		}
+		commitmentMap[key] = commitment
+	}
+
+	if err := i.SubAmount(ctx, balanceMap, doc.IaoBalances, req.WalletId, req.TokenId, stableToken); err != nil {
+		return "", err
+	}
+
+	commitment := commitmentMap[key]
+	commitment.AssetTokenAmount, _ = helper.AddBalance(commitment.AssetTokenAmount, req.NumberAT)
+	commitment.StableTokenAmount, _ = helper.AddBalance(commitment.StableTokenAmount, stableToken)
+	iaoEntity.CommittedAssetToken, _ = helper.AddBalance(iaoEntity.CommittedAssetToken, req.NumberAT)
+
+	return stableToken, nil
+}
+
+// updateCommitment save the commitments changed by the batch
+func (i *iaoService) updateCommitment(ctx contractapi.TransactionContextInterface, commitmentMap map[string]*entity.IaoCommitment) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	for key, commitment := range commitmentMap {
+		commitment.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+		if err := i.Repo.Update(ctx, commitment, doc.IaoCommitment, helper.IaoCommitmentKey(commitment.IaoId, commitment.WalletId, commitment.StableTokenId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "UpdateCommitment - Update commitment (%s) failed with err (%v)", key, err)
+			return helper.RespError(errorcode.BizUnableUpdateIaoCommitment)
+		}
+	}
+	return nil
+}
+
+// getIaoInvestor return the total bought by wallet in iao, loaded once per batch
+func (i *iaoService) getIaoInvestor(ctx contractapi.TransactionContextInterface, iaoInvestorMap map[string]*entity.IaoInvestor,
+	iaoId, walletId string) (*entity.IaoInvestor, error) {
+	key := iaoId + "_" + walletId
+	if _, ok := iaoInvestorMap[key]; !ok {
+		iaoInvestor, isExisted, err := i.GetAndCheckIaoInvestor(ctx, iaoId, walletId)
+		if err != nil {
+			return nil, err
+		}
+		if !isExisted {
+			iaoInvestor = entity.NewIaoInvestor(ctx)
+			iaoInvestor.Id = helper.GenerateID(doc.IaoInvestor, key)
+			iaoInvestor.IaoId = iaoId
+			iaoInvestor.WalletId = walletId
+		}
+		iaoInvestorMap[key] = iaoInvestor
+	}
+	return iaoInvestorMap[key], nil
+}
+
+// updateIaoInvestor save the totals of wallets that bought in the batch
+func (i *iaoService) updateIaoInvestor(ctx contractapi.TransactionContextInterface, iaoInvestorMap map[string]*entity.IaoInvestor) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	for key, iaoInvestor := range iaoInvestorMap {
+		if helper.CompareStringBalance(iaoInvestor.AssetTokenAmount, "0") <= 0 {
+			continue
+		}
+		iaoInvestor.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+		if err := i.Repo.Update(ctx, iaoInvestor, doc.IaoInvestor, helper.IaoInvestorKey(iaoInvestor.IaoId, iaoInvestor.WalletId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "UpdateIaoInvestor - Update total of investor (%s) failed with err (%v)", key, err)
+			return helper.RespError(errorcode.BizUnableUpdateIaoInvestor)
+		}
+	}
+	return nil
+}
+
+// checkPurchaseLimit return an error if the total of wallet after buying amount is out of the purchase limits
+// or over the maximum investment of its KYC tier
+func checkPurchaseLimit(limit entity.IaoLimit, kycTier *entity.KycTier, total, amount string) error {
+	totalAfter, err := helper.AddBalance(total, amount)
+	if err != nil {
+		return err
+	}
+	if limit.MinPurchase != "" && helper.CompareStringBalance(totalAfter, limit.MinPurchase) < 0 {
+		return errors.New("Total purchase of wallet is under the minimum purchase")
+	}
+	if limit.MaxPurchase != "" && helper.CompareStringBalance(totalAfter, limit.MaxPurchase) > 0 {
+		return errors.New("Total purchase of wallet is over the maximum purchase")
+	}
+	if kycTier != nil && kycTier.MaxInvestment != "" && helper.CompareStringBalance(totalAfter, kycTier.MaxInvestment) > 0 {
+		return errors.New("Total purchase of wallet is over the maximum investment of its KYC tier")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package idempotency
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/mitchellh/mapstructure"
+)
+
+type idempotencyService struct {
+	*base.Base
+}
+
+func NewIdempotencyService() services.Idempotency {
+	return &idempotencyService{base.NewBase()}
+}
+
+func (i *idempotencyService) Load(ctx contractapi.TransactionContextInterface, requestId, operation, hash string) (string, bool, error) {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	isExisted, recordData, err := i.Repo.GetAndCheckExist(ctx, doc.Idempotency, helper.IdempotencyKey(requestId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Idempotency Service - Get record (%s) failed with error (%v)", requestId, err)
+		return "", false, helper.RespError(errorcode.BizUnableGetIdempotency)
+	}
+
+	if !isExisted {
+		return "", false, nil
+	}
+
+	record := entity.NewIdempotency()
+	if err = mapstructure.Decode(recordData, &record); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Idempotency Service - Decode record failed with error (%v)", err)
+		return "", false, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+
+	// an expired record is overwritten by the new request
+	if helper.IsExpired(record.Expiry, txTime.Seconds) {
+		return "", false, nil
+	}
+
+	if record.Operation != operation || record.Hash != hash {
+		glogger.GetInstance().Errorf(ctx, "Idempotency Service - Request id (%s) is used by another payload of (%s)", requestId, record.Operation)
+		return "", false, helper.RespError(errorcode.BizIdempotencyConflict)
+	}
+	glogger.GetInstance().Infof(ctx, "Idempotency Service - Replay result of request id (%s)", requestId)
+
+	return record.Result, true, nil
+}
+
+func (i *idempotencyService) Save(ctx contractapi.TransactionContextInterface, requestId, operation, hash, result string) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	record := entity.NewIdempotency(ctx)
+	record.RequestId = requestId
+	record.Operation = operation
+	record.Hash = hash
+	record.Result = result
+	record.Expiry = txTime.Seconds + glossary.IdempotencyTtl
+	if err := i.Repo.Update(ctx, record, doc.Idempotency, helper.IdempotencyKey(requestId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Idempotency Service - Save record (%s) failed with error (%v)", requestId, err)
+		return helper.RespError(errorcode.BizUnableUpdateIdempotency)
+	}
+	return nil
+}
+
+func (i *idempotencyService) Cleanup(ctx contractapi.TransactionContextInterface) (int, error) {
+	glogger.GetInstance().Info(ctx, "-----------Idempotency Service - Cleanup-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "Cleanup - Caller is not admin")
+		return 0, helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	// limit the write set of a transaction, the rest is deleted by the next cleanup
+	records, err := i.QueryDocumentsLimit(ctx, query.GetExpiredIdempotencyQueryString(txTime.Seconds), int(glossary.PaginationSize))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Cleanup - Query expired records failed with error (%v)", err)
+		return 0, err
+	}
+
+	for _, recordData := range records {
+		record := entity.NewIdempotency()
+		if err := json.Unmarshal(recordData, record); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Cleanup - Unmarshal record failed with error (%v)", err)
+			return 0, helper.RespError(errorcode.BizUnableMapDecode)
+		}
+
+		if err := i.Repo.Delete(ctx, doc.Idempotency, helper.IdempotencyKey(record.RequestId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Cleanup - Delete record (%s) failed with error (%v)", record.RequestId, err)
+			return 0, helper.RespError(errorcode.BizUnableUpdateIdempotency)
+		}
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Idempotency Service - Cleanup succeed (%d)-----------", len(records))
+
+	return len(records), nil
+}
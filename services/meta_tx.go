@@ -0,0 +1,34 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import (
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MetaTx is the transfer signed off-chain by the ECDSA key of a wallet, a relayer submits it on behalf of the wallet
+type MetaTx interface {
+	// RegisterWalletKey to set the public key verifying signed transfers of a wallet. Only admin is allowed
+	RegisterWalletKey(ctx contractapi.TransactionContextInterface, walletId, publicKey string) error
+
+	// SubmitSignedTransfer to verify a signed transfer and create the pending transaction of its action
+	SubmitSignedTransfer(ctx contractapi.TransactionContextInterface, signedTransfer token.SignedTransfer) error
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package metatx
+
+import (
+	"encoding/json"
+	nftDto "github.com/Akachain/gringotts/dto/nft"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/signature"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/Akachain/gringotts/services/nft"
+	tokenService "github.com/Akachain/gringotts/services/token"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type metaTxService struct {
+	*base.Base
+	tokenService services.Token
+	nftService   services.NFT
+}
+
+func NewMetaTxService() services.MetaTx {
+	return &metaTxService{
+		Base:         base.NewBase(),
+		tokenService: tokenService.NewTokenService(),
+		nftService:   nft.NewNftService(),
+	}
+}
+
+func (m *metaTxService) RegisterWalletKey(ctx contractapi.TransactionContextInterface, walletId, publicKey string) error {
+	glogger.GetInstance().Info(ctx, "-----------MetaTx Service - RegisterWalletKey-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "RegisterWalletKey - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	if _, err := signature.ParsePublicKey(publicKey); err != nil {
+		glogger.GetInstance().Errorf(ctx, "RegisterWalletKey - Parse public key failed with error (%v)", err)
+		return helper.RespError(errorcode.BizInvalidPublicKey)
+	}
+
+	wallet, err := m.GetActiveWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RegisterWalletKey - Get wallet failed with error (%v)", err)
+		return err
+	}
+
+	// the nonce is kept when the key is rotated so old signatures can not be replayed
+	wallet.PublicKey = publicKey
+	wallet.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := m.Repo.Update(ctx, wallet, doc.Wallets, helper.WalletKey(wallet.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "RegisterWalletKey - Update wallet failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateWallet)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------MetaTx Service - RegisterWalletKey succeed (%s)-----------", walletId)
+
+	return nil
+}
+
+func (m *metaTxService) SubmitSignedTransfer(ctx contractapi.TransactionContextInterface, signedTransfer token.SignedTransfer) error {
+	glogger.GetInstance().Info(ctx, "-----------MetaTx Service - SubmitSignedTransfer-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	if helper.IsExpired(signedTransfer.Expiry, txTime.Seconds) {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Signed transfer is expired at (%d)", signedTransfer.Expiry)
+		return helper.RespError(errorcode.BizSignedTransferExpired)
+	}
+
+	// the payload is decoded first to know the wallet signing it
+	var submit func() error
+	var signerWalletId string
+	switch signedTransfer.Action {
+	case transaction.Transfer:
+		var transferDto token.TransferToken
+		if err := m.decodePayload(ctx, signedTransfer.Payload, &transferDto); err != nil {
+			return err
+		}
+		signerWalletId = transferDto.FromWalletId
+		submit = func() error {
+			_, err := m.tokenService.Transfer(ctx, transferDto.FromWalletId, transferDto.ToWalletId, transferDto.TokenId, transferDto.Amount, transferDto.Expiry)
+			return err
+		}
+	case transaction.Exchange:
+		var exchangeDto token.ExchangeToken
+		if err := m.decodePayload(ctx, signedTransfer.Payload, &exchangeDto); err != nil {
+			return err
+		}
+		signerWalletId = exchangeDto.FromWalletId
+		submit = func() error {
+			return m.tokenService.Exchange(ctx, exchangeDto.FromWalletId, exchangeDto.ToWalletId, exchangeDto.FromTokenId,
+				exchangeDto.ToTokenId, exchangeDto.FromTokenAmount, exchangeDto.ToTokenAmount, exchangeDto.Expiry)
+		}
+	case transaction.TransferNft:
+		var transferNftDto nftDto.TransferNFT
+		if err := m.decodePayload(ctx, signedTransfer.Payload, &transferNftDto); err != nil {
+			return err
+		}
+		signerWalletId = transferNftDto.FromWalletId
+		submit = func() error {
+			return m.nftService.TransferFrom(ctx, transferNftDto.FromWalletId, transferNftDto.ToWalletId,
+				transferNftDto.FromTokenId, transferNftDto.NftTokenId, transferNftDto.Price)
+		}
+	default:
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Action (%s) is not supported", signedTransfer.Action)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	wallet, err := m.GetActiveWallet(ctx, signerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Get signer wallet failed with error (%v)", err)
+		return err
+	}
+
+	if wallet.PublicKey == "" {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Wallet (%s) has no public key", wallet.Id)
+		return helper.RespError(errorcode.BizWalletKeyNotRegistered)
+	}
+
+	// nonce of a wallet only increases, a used signature can not be replayed
+	if signedTransfer.Nonce <= wallet.Nonce {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Nonce (%d) is not greater than (%d)", signedTransfer.Nonce, wallet.Nonce)
+		return helper.RespError(errorcode.BizInvalidNonce)
+	}
+
+	chaincodeName, err := helper.GetChaincodeName(ctx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Get chaincode name failed with error (%v)", err)
+		return helper.RespError(errorcode.BizInvalidSignature)
+	}
+
+	message := signature.Message(ctx.GetStub().GetChannelID(), chaincodeName, string(signedTransfer.Action),
+		signedTransfer.Nonce, signedTransfer.Expiry, signedTransfer.Payload)
+	if err := signature.Verify(wallet.PublicKey, message, signedTransfer.Signature); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Verify signature failed with error (%v)", err)
+		return helper.RespError(errorcode.BizInvalidSignature)
+	}
+
+	wallet.Nonce = signedTransfer.Nonce
+	wallet.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := m.Repo.Update(ctx, wallet, doc.Wallets, helper.WalletKey(wallet.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Update nonce of wallet failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateWallet)
+	}
+
+	if err := submit(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Submit (%s) failed with error (%v)", signedTransfer.Action, err)
+		return err
+	}
+
+	relayerId, _ := helper.GetCallerId(ctx)
+	glogger.GetInstance().Infof(ctx, "-----------MetaTx Service - SubmitSignedTransfer succeed (%s: nonce %d, relayer %s)-----------",
+		wallet.Id, signedTransfer.Nonce, relayerId)
+
+	return nil
+}
+
+// decodePayload unmarshal the signed payload into the dto of the action and validate it
+func (m *metaTxService) decodePayload(ctx contractapi.TransactionContextInterface, payload string, dto interface{ IsValid() error }) error {
+	if err := json.Unmarshal([]byte(payload), dto); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Unmarshal payload failed with error (%v)", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+
+	if err := dto.IsValid(); err != nil {
+		glogger.GetInstance().Errorf(ctx, "SubmitSignedTransfer - Payload invalidate %v", err)
+		return helper.RespError(errorcode.InvalidParam)
+	}
+	return nil
+}
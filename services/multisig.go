@@ -0,0 +1,37 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import (
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// MultiSig is the control of multi-signature wallets. Outgoing transactions of such wallet are kept as open intents
+// and the pending transaction is written once threshold of owners sign
+type MultiSig interface {
+	// SignIntent to add signature of the caller to an open intent. It returns id of the transaction when the intent is executed
+	SignIntent(ctx contractapi.TransactionContextInterface, intentId string) (string, error)
+
+	// CancelIntent to cancel an open intent. Only owners of the wallet are allowed
+	CancelIntent(ctx contractapi.TransactionContextInterface, intentId string) error
+
+	// GetOpenIntents return all open intents of a multi-signature wallet
+	GetOpenIntents(ctx contractapi.TransactionContextInterface, walletId string) (string, error)
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package multisig
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/intent"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type multiSigService struct {
+	*base.Base
+}
+
+func NewMultiSigService() services.MultiSig {
+	return &multiSigService{base.NewBase()}
+}
+
+func (m *multiSigService) SignIntent(ctx contractapi.TransactionContextInterface, intentId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Service - SignIntent-----------")
+
+	intentEntity, callerId, err := m.getOwnedIntent(ctx, intentId)
+	if err != nil {
+		return "", err
+	}
+
+	if helper.ArrayContains(intentEntity.Signatures, callerId) {
+		glogger.GetInstance().Errorf(ctx, "SignIntent - Caller has already signed intent (%s)", intentId)
+		return "", helper.RespError(errorcode.BizIntentAlreadySigned)
+	}
+
+	// the pending transaction is written once enough owners signed
+	intentEntity.Signatures = helper.ArrayInsert(intentEntity.Signatures, callerId)
+	if len(intentEntity.Signatures) >= intentEntity.Threshold {
+		if err := m.ExecuteIntent(ctx, intentEntity); err != nil {
+			glogger.GetInstance().Errorf(ctx, "SignIntent - Execute intent (%s) failed with error (%v)", intentId, err)
+			return "", err
+		}
+	}
+
+	if err := m.updateIntent(ctx, intentEntity); err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------MultiSig Service - SignIntent succeed (%s: %s)-----------", intentId, intentEntity.Status)
+
+	return intentEntity.TxId, nil
+}
+
+func (m *multiSigService) CancelIntent(ctx contractapi.TransactionContextInterface, intentId string) error {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Service - CancelIntent-----------")
+
+	intentEntity, _, err := m.getOwnedIntent(ctx, intentId)
+	if err != nil {
+		return err
+	}
+
+	intentEntity.Status = intent.Canceled
+	if err := m.updateIntent(ctx, intentEntity); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------MultiSig Service - CancelIntent succeed (%s)-----------", intentId)
+
+	return nil
+}
+
+func (m *multiSigService) GetOpenIntents(ctx contractapi.TransactionContextInterface, walletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------MultiSig Service - GetOpenIntents-----------")
+
+	intents, err := m.QueryDocuments(ctx, query.GetOpenIntentByWalletQueryString(walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetOpenIntents - Query open intents failed with error (%v)", err)
+		return "", err
+	}
+
+	return helper.MarshalStruct(intents), nil
+}
+
+// getOwnedIntent return the open intent and the caller id when the caller is an owner of its wallet
+func (m *multiSigService) getOwnedIntent(ctx contractapi.TransactionContextInterface, intentId string) (*entity.Intent, string, error) {
+	intentEntity, err := m.GetIntent(ctx, intentId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if intentEntity.Status != intent.Open {
+		glogger.GetInstance().Errorf(ctx, "MultiSig Service - Intent (%s) has status (%s)", intentId, intentEntity.Status)
+		return nil, "", helper.RespError(errorcode.BizIntentInvalidStatus)
+	}
+
+	callerId, err := helper.GetCallerId(ctx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "MultiSig Service - Get caller identity failed with error (%v)", err)
+		return nil, "", helper.RespError(errorcode.BizUnableGetIdentity)
+	}
+
+	if !helper.ArrayContains(intentEntity.Owners, callerId) {
+		glogger.GetInstance().Errorf(ctx, "MultiSig Service - Caller is not an owner of wallet (%s)", intentEntity.WalletId)
+		return nil, "", helper.RespError(errorcode.BizIntentNotOwner)
+	}
+	return intentEntity, callerId, nil
+}
+
+func (m *multiSigService) updateIntent(ctx contractapi.TransactionContextInterface, intentEntity *entity.Intent) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	intentEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := m.Repo.Update(ctx, intentEntity, doc.Intent, helper.IntentKey(intentEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MultiSig Service - Update intent (%s) failed with error (%v)", intentEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateIntent)
+	}
+	return nil
+}
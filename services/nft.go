@@ -23,7 +23,7 @@ import "github.com/hyperledger/fabric-contract-api-go/contractapi"
 
 type NFT interface {
 	// Mint generate new NFT token
-	Mint(ctx contractapi.TransactionContextInterface, gs1Number string, ownerWalletId string, metaData string, hashData string) (string, error)
+	Mint(ctx contractapi.TransactionContextInterface, gs1Number string, ownerWalletId string, metaData string, hashData string, royaltyWalletId string, royaltyBps int64) (string, error)
 
 	// OwnerOf return owner wallet id of nft token
 	OwnerOf(ctx contractapi.TransactionContextInterface, nftTokenId string) (string, error)
@@ -33,4 +33,13 @@ type NFT interface {
 
 	// TransferFrom to transfer nft token from owner to other wallet
 	TransferFrom(ctx contractapi.TransactionContextInterface, ownerWalletId string, toWalletId string, fromTokenId string, nftTokenId string, price float64) error
+
+	// RoyaltyInfo return the royalty wallet and the royalty amount of nft token sold at price
+	RoyaltyInfo(ctx contractapi.TransactionContextInterface, nftTokenId string, price float64) (string, error)
+
+	// FractionalizeNft lock nft token in a vault wallet and mint shares of a new token type to the owner
+	FractionalizeNft(ctx contractapi.TransactionContextInterface, nftTokenId, tokenName, tickerToken, shares string) (string, error)
+
+	// RedeemNft burn all the shares of the vault and release nft token to the redeemer
+	RedeemNft(ctx contractapi.TransactionContextInterface, nftTokenId, redeemerWalletId string) error
 }
@@ -20,30 +20,41 @@
 package nft
 
 import (
+	"fmt"
+	nftDto "github.com/Akachain/gringotts/dto/nft"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
 	"github.com/Akachain/gringotts/glossary/doc"
 	"github.com/Akachain/gringotts/glossary/sidechain"
 	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/glossary/vault"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
 	"github.com/Akachain/gringotts/pkg/unit"
 	"github.com/Akachain/gringotts/services"
 	"github.com/Akachain/gringotts/services/base"
+	"github.com/Akachain/gringotts/services/token"
+	"github.com/Akachain/gringotts/services/wallet"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
 type nftService struct {
 	*base.Base
+	tokenService  services.Token
+	walletService services.Wallet
 }
 
 func NewNftService() services.NFT {
 	return &nftService{
 		base.NewBase(),
+		token.NewTokenService(),
+		wallet.NewWalletService(),
 	}
 }
 
-func (n *nftService) Mint(ctx contractapi.TransactionContextInterface, gs1Number string, ownerWalletId string, metaData string, hashData string) (string, error) {
+func (n *nftService) Mint(ctx contractapi.TransactionContextInterface, gs1Number string, ownerWalletId string, metaData string,
+	hashData string, royaltyWalletId string, royaltyBps int64) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------NftToken Service - Mint-----------")
 
 	if _, err := n.GetActiveWallet(ctx, ownerWalletId); err != nil {
@@ -51,11 +62,20 @@ func (n *nftService) Mint(ctx contractapi.TransactionContextInterface, gs1Number
 		return "", err
 	}
 
+	if royaltyWalletId != "" {
+		if _, err := n.GetActiveWallet(ctx, royaltyWalletId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Mint - Get royalty wallet failed with error (%v)", err)
+			return "", err
+		}
+	}
+
 	nftEntity := entity.NewNFT(ctx)
 	nftEntity.GS1Number = gs1Number
 	nftEntity.OwnerId = ownerWalletId
 	nftEntity.MetaData = metaData
 	nftEntity.HashData = hashData
+	nftEntity.RoyaltyWalletId = royaltyWalletId
+	nftEntity.RoyaltyBps = royaltyBps
 
 	if err := n.Repo.Create(ctx, nftEntity, doc.NftToken, helper.NFTKey(nftEntity.Id)); err != nil {
 		glogger.GetInstance().Errorf(ctx, "NftToken Service - Mint NftToken failed with error (%v)", err)
@@ -99,11 +119,16 @@ func (n *nftService) TransferFrom(ctx contractapi.TransactionContextInterface, f
 	fromTokenId string, nftTokenId string, price float64) error {
 	glogger.GetInstance().Info(ctx, "-----------NftToken Service - TransferFrom-----------")
 
-	if _, _, err := n.ValidatePairWallet(ctx, fromWalletId, toWalletId); err != nil {
+	fromWallet, _, err := n.ValidatePairWallet(ctx, fromWalletId, toWalletId)
+	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "TransferFrom - Validation pair wallet nft failed with error (%s)", err.Error())
 		return err
 	}
 
+	if err := n.RejectMultiSig(ctx, fromWallet); err != nil {
+		return err
+	}
+
 	// handler owner of nft
 	nftToken, err := n.GetNFT(ctx, nftTokenId)
 	if err != nil {
@@ -116,6 +141,11 @@ func (n *nftService) TransferFrom(ctx contractapi.TransactionContextInterface, f
 		return helper.RespError(errorcode.BizNftNotPermission)
 	}
 
+	if nftToken.LockedBy != "" {
+		glogger.GetInstance().Errorf(ctx, "TransferFrom - NftToken is locked by (%s)", nftToken.LockedBy)
+		return helper.RespError(errorcode.BizNftLocked)
+	}
+
 	// convert price to akc base
 	amountUnit := unit.NewBalanceUnitFromFloat(price)
 
@@ -138,3 +168,182 @@ func (n *nftService) TransferFrom(ctx contractapi.TransactionContextInterface, f
 
 	return nil
 }
+
+func (n *nftService) RoyaltyInfo(ctx contractapi.TransactionContextInterface, nftTokenId string, price float64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------NftToken Service - RoyaltyInfo-----------")
+
+	nftToken, err := n.GetNFT(ctx, nftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RoyaltyInfo - Get NftToken failed with error (%v)", err)
+		return "", err
+	}
+
+	// convert price to akc base like TransferFrom
+	amountUnit := unit.NewBalanceUnitFromFloat(price)
+
+	royaltyAmount, err := helper.BasisPointBalance(amountUnit.String(), nftToken.RoyaltyBps)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RoyaltyInfo - Calculate royalty failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCalculateRoyalty)
+	}
+
+	royaltyInfo := nftDto.RoyaltyInfo{
+		RoyaltyWalletId: nftToken.RoyaltyWalletId,
+		RoyaltyAmount:   royaltyAmount,
+	}
+	return helper.MarshalStruct(royaltyInfo), nil
+}
+
+func (n *nftService) FractionalizeNft(ctx contractapi.TransactionContextInterface, nftTokenId, tokenName, tickerToken, shares string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------NftToken Service - FractionalizeNft-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	nftToken, err := n.GetNFT(ctx, nftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Get NftToken failed with error (%v)", err)
+		return "", err
+	}
+
+	if nftToken.LockedBy != "" {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - NftToken is locked by (%s)", nftToken.LockedBy)
+		return "", helper.RespError(errorcode.BizNftLocked)
+	}
+
+	if _, err := n.GetActiveWallet(ctx, nftToken.OwnerId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Get owner wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the share token is capped at the number of shares and managed by the vault, so no more can be minted later
+	vaultEntity := entity.NewVault(ctx)
+	shareTokenId, err := n.tokenService.CreateManagedType(ctx, tokenName, tickerToken, shares, vaultEntity.Id)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Create share token type failed with error (%v)", err)
+		return "", err
+	}
+
+	vaultWalletId, err := n.walletService.Create(ctx, shareTokenId, glossary.Active)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Create vault wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	vaultEntity.NftTokenId = nftToken.Id
+	vaultEntity.WalletId = vaultWalletId
+	vaultEntity.OwnerWalletId = nftToken.OwnerId
+	vaultEntity.ShareTokenId = shareTokenId
+	vaultEntity.Shares = shares
+	if err := n.Repo.Create(ctx, vaultEntity, doc.Vault, helper.VaultKey(vaultEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Create vault failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateVault)
+	}
+
+	// the vault mints the shares to the owner, Mint rejects the managed share token
+	txMint := entity.NewTransaction(ctx)
+	txMint.SpenderWallet = nftToken.OwnerId
+	txMint.FromWallet = glossary.SystemWallet
+	txMint.ToWallet = nftToken.OwnerId
+	txMint.FromTokenId = shareTokenId
+	txMint.ToTokenId = shareTokenId
+	txMint.FromTokenAmount = shares
+	txMint.ToTokenAmount = shares
+	txMint.TxType = transaction.Mint
+	txMint.Note = vaultEntity.Id
+	if err := n.Repo.Create(ctx, txMint, doc.Transactions, helper.TransactionKey(txMint.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Create mint shares transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+
+	// lock nft token in the vault wallet
+	nftToken.OwnerId = vaultWalletId
+	nftToken.LockedBy = vaultEntity.Id
+	nftToken.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := n.Repo.Update(ctx, nftToken, doc.NftToken, helper.NFTKey(nftToken.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FractionalizeNft - Update NftToken failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableUpdateNFT)
+	}
+
+	result := fmt.Sprintf("{\"vaultId\":\"%s\",\"tokenId\":\"%s\"}", vaultEntity.Id, shareTokenId)
+	glogger.GetInstance().Infof(ctx, "-----------NftToken Service - FractionalizeNft succeed (%s)-----------", result)
+
+	return result, nil
+}
+
+func (n *nftService) RedeemNft(ctx contractapi.TransactionContextInterface, nftTokenId, redeemerWalletId string) error {
+	glogger.GetInstance().Info(ctx, "-----------NftToken Service - RedeemNft-----------")
+
+	redeemerWallet, err := n.GetActiveWallet(ctx, redeemerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Get redeemer wallet failed with error (%v)", err)
+		return err
+	}
+
+	if err := n.RejectMultiSig(ctx, redeemerWallet); err != nil {
+		return err
+	}
+
+	nftToken, err := n.GetNFT(ctx, nftTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Get NftToken failed with error (%v)", err)
+		return err
+	}
+
+	if nftToken.LockedBy == "" {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - NftToken (%s) is not fractionalized", nftTokenId)
+		return helper.RespError(errorcode.BizVaultInvalidStatus)
+	}
+
+	vaultEntity, err := n.GetVault(ctx, nftToken.LockedBy)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Get vault failed with error (%v)", err)
+		return err
+	}
+
+	if vaultEntity.Status != vault.Locked {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Vault (%s) has status (%s)", vaultEntity.Id, vaultEntity.Status)
+		return helper.RespError(errorcode.BizVaultInvalidStatus)
+	}
+
+	// redeemer must hold the whole outstanding supply of the shares
+	shareToken, err := n.GetTokenType(ctx, vaultEntity.ShareTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Get share token type failed with error (%v)", err)
+		return err
+	}
+
+	if helper.CompareStringBalance(shareToken.TotalSupply, "0") <= 0 {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Shares of vault (%s) are not minted", vaultEntity.Id)
+		return helper.RespError(errorcode.BizVaultInvalidStatus)
+	}
+
+	balance, err := n.GetBalanceOfToken(ctx, doc.SpotBalances, redeemerWalletId, vaultEntity.ShareTokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Get share balance failed with error (%v)", err)
+		return err
+	}
+
+	if helper.CompareStringBalance(balance.Balances, shareToken.TotalSupply) < 0 {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Wallet (%s) does not hold all the shares", redeemerWalletId)
+		return helper.RespError(errorcode.BizBalanceNotEnough)
+	}
+
+	// create tx burn shares and release nft token
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = redeemerWalletId
+	txEntity.FromWallet = redeemerWalletId
+	txEntity.ToWallet = glossary.SystemWallet
+	txEntity.FromTokenId = vaultEntity.ShareTokenId
+	txEntity.ToTokenId = nftToken.Id
+	txEntity.FromTokenAmount = shareToken.TotalSupply
+	txEntity.ToTokenAmount = shareToken.TotalSupply
+	txEntity.TxType = transaction.RedeemNft
+	txEntity.Note = vaultEntity.Id
+
+	if err := n.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "RedeemNft - Create redeem transaction failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableCreateTX)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------NftToken Service - RedeemNft succeed (%s)-----------", txEntity.Id)
+
+	return nil
+}
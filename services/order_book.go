@@ -0,0 +1,45 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// OrderBook is the limit order book of token pairs.
+// Balance of open orders is locked in the exchange balance of the wallet until the order is filled or canceled.
+type OrderBook interface {
+	// PlaceLimitOrder to place an order to buy or sell amount of base token at price in quote token
+	PlaceLimitOrder(ctx contractapi.TransactionContextInterface, walletId, baseTokenId, quoteTokenId string, side order.Side, amount, price string) (string, error)
+
+	// CancelOrder to cancel an open order and unlock the remaining balance
+	CancelOrder(ctx contractapi.TransactionContextInterface, orderId, walletId string) error
+
+	// MatchOrders to handle multiple request match a buy order with a sell order
+	MatchOrders(ctx contractapi.TransactionContextInterface, req []exchange.MatchOrder) (string, error)
+
+	// GetDepth return remaining amount of open orders of a token pair group by price
+	GetDepth(ctx contractapi.TransactionContextInterface, baseTokenId, quoteTokenId string) (string, error)
+
+	// GetOrderHistory return orders and trades of a wallet
+	GetOrderHistory(ctx contractapi.TransactionContextInterface, walletId string) (string, error)
+}
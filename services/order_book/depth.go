@@ -0,0 +1,85 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package order_book
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/Akachain/gringotts/helper"
+	"sort"
+)
+
+// Level is the remaining amount of open orders at a price
+type Level struct {
+	Price  string `json:"price"`
+	Amount string `json:"amount"`
+}
+
+// Depth is the open orders of a token pair group by price.
+// Bids are sorted from the highest price, asks from the lowest price.
+type Depth struct {
+	Bids []Level `json:"bids"`
+	Asks []Level `json:"asks"`
+}
+
+func newDepth(orders []*entity.Order) (*Depth, error) {
+	bids := make(map[string]string, 0)
+	asks := make(map[string]string, 0)
+	for _, orderEntity := range orders {
+		remaining, err := helper.SubBalance(orderEntity.Amount, orderEntity.FilledAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		levels := asks
+		if orderEntity.Side == order.Buy {
+			levels = bids
+		}
+
+		current, ok := levels[orderEntity.Price]
+		if !ok {
+			current = "0"
+		}
+		if levels[orderEntity.Price], err = helper.AddBalance(current, remaining); err != nil {
+			return nil, err
+		}
+	}
+
+	depth := &Depth{
+		Bids: toLevels(bids),
+		Asks: toLevels(asks),
+	}
+	sort.Slice(depth.Bids, func(i, j int) bool {
+		return helper.CompareStringBalance(depth.Bids[i].Price, depth.Bids[j].Price) > 0
+	})
+	sort.Slice(depth.Asks, func(i, j int) bool {
+		return helper.CompareStringBalance(depth.Asks[i].Price, depth.Asks[j].Price) < 0
+	})
+
+	return depth, nil
+}
+
+func toLevels(levelMap map[string]string) []Level {
+	levels := make([]Level, 0, len(levelMap))
+	for price, amount := range levelMap {
+		levels = append(levels, Level{Price: price, Amount: amount})
+	}
+	return levels
+}
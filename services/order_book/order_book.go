@@ -0,0 +1,525 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package order_book
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+type orderBookService struct {
+	*base.Base
+}
+
+func NewOrderBookService() services.OrderBook {
+	return &orderBookService{
+		base.NewBase(),
+	}
+}
+
+func (o *orderBookService) PlaceLimitOrder(ctx contractapi.TransactionContextInterface, walletId, baseTokenId, quoteTokenId string,
+	side order.Side, amount, price string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Service - PlaceLimitOrder-----------")
+
+	wallet, err := o.GetActiveWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Get wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := o.RejectMultiSig(ctx, wallet); err != nil {
+		return "", err
+	}
+
+	if _, err := o.GetTokenType(ctx, baseTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Get base token failed with error (%v)", err)
+		return "", err
+	}
+
+	if _, err := o.GetTokenType(ctx, quoteTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Get quote token failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := o.validateControl(ctx, walletId, baseTokenId, quoteTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Token pair is not allowed for wallet (%s) with error (%v)", walletId, err)
+		return "", err
+	}
+
+	orderEntity := entity.NewOrder(ctx)
+	orderEntity.WalletId = walletId
+	orderEntity.BaseTokenId = baseTokenId
+	orderEntity.QuoteTokenId = quoteTokenId
+	orderEntity.Side = side
+	orderEntity.Amount = amount
+	orderEntity.Price = price
+	orderEntity.LockedAmount = amount
+
+	sequence, err := o.nextSequence(ctx, baseTokenId, quoteTokenId)
+	if err != nil {
+		return "", err
+	}
+	orderEntity.Sequence = sequence
+
+	// a buy order locks the quote token of the whole amount at the limit price
+	if side == order.Buy {
+		quoteAmount, err := helper.QuoteBalance(amount, price)
+		if err != nil || helper.CompareStringBalance(quoteAmount, "0") <= 0 {
+			glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Quote amount of order is invalid (%v)", err)
+			return "", helper.RespError(errorcode.InvalidParam)
+		}
+		orderEntity.LockedAmount = quoteAmount
+	}
+
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := o.lockAmount(ctx, balanceMap, walletId, o.lockedTokenId(orderEntity), orderEntity.LockedAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Lock balance of wallet (%s) failed with error (%v)", walletId, err)
+		return "", helper.RespError(errorcode.BizUnableLockBalance)
+	}
+	if err := o.UpdateBalance(ctx, balanceMap); err != nil {
+		return "", err
+	}
+
+	if err := o.Repo.Create(ctx, orderEntity, doc.Order, helper.OrderKey(orderEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Create order failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateOrder)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------OrderBook Service - PlaceLimitOrder succeed (%s)-----------", orderEntity.Id)
+
+	return orderEntity.Id, nil
+}
+
+func (o *orderBookService) CancelOrder(ctx contractapi.TransactionContextInterface, orderId, walletId string) error {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Service - CancelOrder-----------")
+
+	orderEntity, err := o.GetOrder(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	if !orderEntity.Status.IsOpen() {
+		glogger.GetInstance().Errorf(ctx, "CancelOrder - Order (%s) has status (%s)", orderId, orderEntity.Status)
+		return helper.RespError(errorcode.BizOrderInvalidStatus)
+	}
+
+	if orderEntity.WalletId != walletId {
+		glogger.GetInstance().Error(ctx, "CancelOrder - Wallet not match owner of order")
+		return helper.RespError(errorcode.BizOrderNotPermission)
+	}
+
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := o.unlockRemaining(ctx, balanceMap, orderEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelOrder - Unlock balance of order failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableReleaseBalance)
+	}
+	if err := o.UpdateBalance(ctx, balanceMap); err != nil {
+		return err
+	}
+
+	orderEntity.Status = order.Canceled
+	if err := o.updateOrders(ctx, map[string]*entity.Order{orderEntity.Id: orderEntity}); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------OrderBook Service - CancelOrder succeed (%s)-----------", orderEntity.Id)
+
+	return nil
+}
+
+func (o *orderBookService) MatchOrders(ctx contractapi.TransactionContextInterface, batchReq []exchange.MatchOrder) (string, error) {
+	glogger.GetInstance().Info(ctx, "Start MatchOrders")
+
+	// cache orders of the batch
+	orderMap := make(map[string]*entity.Order, len(batchReq)*2)
+	balanceMap := make(map[string]*entity.BalanceCache, len(batchReq)*4)
+	resultHandle := make([]exchange.MatchResult, 0, len(batchReq))
+	trades := make([]*entity.Trade, 0, len(batchReq))
+
+	for index, req := range batchReq {
+		res := req.CloneToResult()
+		trade, err := o.matchOrder(ctx, orderMap, balanceMap, req, index)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "MatchOrders - Handle req (%s) failed with error (%v)", req.ReqId, err)
+			res.Status = transaction.Rejected
+			resultHandle = append(resultHandle, res)
+			continue
+		}
+		trades = append(trades, trade)
+
+		res.Status = transaction.Confirmed
+		res.TradeId = trade.Id
+		res.Amount = trade.Amount
+		res.Price = trade.Price
+		res.QuoteAmount = trade.QuoteAmount
+		resultHandle = append(resultHandle, res)
+	}
+
+	// log create Read/Write Set
+	glogger.GetInstance().Info(ctx, "Start Create Read/Write")
+	resultJson, _ := json.Marshal(resultHandle)
+
+	// update balance
+	if err := o.UpdateBalance(ctx, balanceMap); err != nil {
+		return "", err
+	}
+
+	// update orders
+	if err := o.updateOrders(ctx, orderMap); err != nil {
+		return "", err
+	}
+
+	// insert trades
+	for _, trade := range trades {
+		if err := o.Repo.Create(ctx, trade, doc.Trade, helper.TradeKey(trade.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "MatchOrders - Create trade failed with err (%v)", err)
+			return "", helper.RespError(errorcode.BizUnableCreateTrade)
+		}
+	}
+	glogger.GetInstance().Info(ctx, "End MatchOrders")
+
+	return string(resultJson), nil
+}
+
+func (o *orderBookService) GetDepth(ctx contractapi.TransactionContextInterface, baseTokenId, quoteTokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Service - GetDepth-----------")
+
+	documents, err := o.QueryDocuments(ctx, query.GetOpenOrderByPairQueryString(baseTokenId, quoteTokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetDepth - Query open orders failed with error (%v)", err)
+		return "", err
+	}
+
+	orders := make([]*entity.Order, 0, len(documents))
+	for _, document := range documents {
+		orderEntity := entity.NewOrder()
+		if err := json.Unmarshal(document, orderEntity); err != nil {
+			glogger.GetInstance().Errorf(ctx, "GetDepth - Unmarshal order failed with error (%v)", err)
+			return "", helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		orders = append(orders, orderEntity)
+	}
+
+	depth, err := newDepth(orders)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetDepth - Aggregate depth failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableQueryData)
+	}
+
+	return helper.MarshalStruct(depth), nil
+}
+
+func (o *orderBookService) GetOrderHistory(ctx contractapi.TransactionContextInterface, walletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------OrderBook Service - GetOrderHistory-----------")
+
+	orders, err := o.QueryDocuments(ctx, query.GetOrderByWalletQueryString(walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetOrderHistory - Query orders failed with error (%v)", err)
+		return "", err
+	}
+
+	buyTrades, err := o.QueryDocuments(ctx, query.GetTradeQueryString("BuyerWalletId", walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetOrderHistory - Query buy trades failed with error (%v)", err)
+		return "", err
+	}
+
+	sellTrades, err := o.QueryDocuments(ctx, query.GetTradeQueryString("SellerWalletId", walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetOrderHistory - Query sell trades failed with error (%v)", err)
+		return "", err
+	}
+
+	history := struct {
+		Orders []json.RawMessage `json:"orders"`
+		Trades []json.RawMessage `json:"trades"`
+	}{orders, append(buyTrades, sellTrades...)}
+
+	return helper.MarshalStruct(history), nil
+}
+
+// matchOrder fill the buy order with the sell order at the price of the order placed first.
+// Balances and orders are updated in cache, the trade is returned to be created at the end of the batch.
+func (o *orderBookService) matchOrder(ctx contractapi.TransactionContextInterface, orderMap map[string]*entity.Order,
+	balanceMap map[string]*entity.BalanceCache, req exchange.MatchOrder, index int) (*entity.Trade, error) {
+	buyOrder, err := o.getOrderInfo(ctx, orderMap, req.BuyOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	sellOrder, err := o.getOrderInfo(ctx, orderMap, req.SellOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if buyOrder.Side != order.Buy || sellOrder.Side != order.Sell || !buyOrder.Status.IsOpen() || !sellOrder.Status.IsOpen() {
+		return nil, errors.New("Orders are not an open buy order and an open sell order")
+	}
+
+	if buyOrder.BaseTokenId != sellOrder.BaseTokenId || buyOrder.QuoteTokenId != sellOrder.QuoteTokenId {
+		return nil, errors.New("Orders are not of the same token pair")
+	}
+
+	if buyOrder.WalletId == sellOrder.WalletId {
+		return nil, errors.New("Orders are of the same wallet")
+	}
+
+	// a token paused or a balance frozen after the orders were placed stops the match
+	for _, orderEntity := range []*entity.Order{buyOrder, sellOrder} {
+		if err := o.validateControl(ctx, orderEntity.WalletId, orderEntity.BaseTokenId, orderEntity.QuoteTokenId); err != nil {
+			return nil, err
+		}
+	}
+
+	if helper.CompareStringBalance(buyOrder.Price, sellOrder.Price) < 0 {
+		return nil, errors.New("Buy price is lower than sell price")
+	}
+
+	// fill amount is the smallest remaining of both orders and the requested amount
+	fillAmount, err := minRemaining(buyOrder, sellOrder)
+	if err != nil {
+		return nil, err
+	}
+	if req.Amount != "" && helper.CompareStringBalance(req.Amount, fillAmount) < 0 {
+		fillAmount = req.Amount
+	}
+	if helper.CompareStringBalance(fillAmount, "0") <= 0 {
+		return nil, errors.New("Fill amount is invalid")
+	}
+
+	// the order placed first in the sequence of the pair is the maker, the trade is executed at its price
+	price := sellOrder.Price
+	if buyOrder.Sequence < sellOrder.Sequence {
+		price = buyOrder.Price
+	}
+
+	quoteAmount, err := helper.QuoteBalance(fillAmount, price)
+	if err != nil {
+		return nil, err
+	}
+	if helper.CompareStringBalance(quoteAmount, "0") <= 0 {
+		return nil, errors.New("Quote amount of fill is zero")
+	}
+
+	if err := o.settleFill(ctx, balanceMap, buyOrder, sellOrder, fillAmount, quoteAmount); err != nil {
+		return nil, err
+	}
+
+	trade := entity.NewTrade(index, ctx)
+	trade.BuyOrderId = buyOrder.Id
+	trade.SellOrderId = sellOrder.Id
+	trade.BuyerWalletId = buyOrder.WalletId
+	trade.SellerWalletId = sellOrder.WalletId
+	trade.BaseTokenId = buyOrder.BaseTokenId
+	trade.QuoteTokenId = buyOrder.QuoteTokenId
+	trade.Price = price
+	trade.Amount = fillAmount
+	trade.QuoteAmount = quoteAmount
+
+	return trade, nil
+}
+
+// settleFill move the locked balance of both orders to the spot balance of the counterparty.
+// A failed fill restores the balance cache and both orders, so the rest of the batch is settled on consistent balances.
+func (o *orderBookService) settleFill(ctx contractapi.TransactionContextInterface, balanceMap map[string]*entity.BalanceCache,
+	buyOrder, sellOrder *entity.Order, fillAmount, quoteAmount string) error {
+	if helper.CompareStringBalance(sellOrder.LockedAmount, fillAmount) < 0 || helper.CompareStringBalance(buyOrder.LockedAmount, quoteAmount) < 0 {
+		return errors.New("Locked amount of orders is not enough")
+	}
+
+	balances := base.SnapshotBalances(balanceMap)
+	buySnapshot, sellSnapshot := *buyOrder, *sellOrder
+
+	if err := o.fill(ctx, balanceMap, buyOrder, sellOrder, fillAmount, quoteAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "MatchOrders - Fill orders (%s, %s) failed, balance cache is restored", buyOrder.Id, sellOrder.Id)
+		base.RestoreBalances(balanceMap, balances)
+		*buyOrder, *sellOrder = buySnapshot, sellSnapshot
+		return err
+	}
+	return nil
+}
+
+func (o *orderBookService) fill(ctx contractapi.TransactionContextInterface, balanceMap map[string]*entity.BalanceCache,
+	buyOrder, sellOrder *entity.Order, fillAmount, quoteAmount string) error {
+	if err := o.SubAmount(ctx, balanceMap, doc.ExchangeBalances, sellOrder.WalletId, sellOrder.BaseTokenId, fillAmount); err != nil {
+		return err
+	}
+
+	if err := o.SubAmount(ctx, balanceMap, doc.ExchangeBalances, buyOrder.WalletId, buyOrder.QuoteTokenId, quoteAmount); err != nil {
+		return err
+	}
+
+	if err := o.AddAmount(ctx, balanceMap, doc.SpotBalances, buyOrder.WalletId, buyOrder.BaseTokenId, fillAmount); err != nil {
+		return err
+	}
+
+	if err := o.AddAmount(ctx, balanceMap, doc.SpotBalances, sellOrder.WalletId, sellOrder.QuoteTokenId, quoteAmount); err != nil {
+		return err
+	}
+
+	sellOrder.FilledAmount, _ = helper.AddBalance(sellOrder.FilledAmount, fillAmount)
+	sellOrder.LockedAmount, _ = helper.SubBalance(sellOrder.LockedAmount, fillAmount)
+	buyOrder.FilledAmount, _ = helper.AddBalance(buyOrder.FilledAmount, fillAmount)
+	buyOrder.LockedAmount, _ = helper.SubBalance(buyOrder.LockedAmount, quoteAmount)
+
+	for _, orderEntity := range []*entity.Order{buyOrder, sellOrder} {
+		if helper.CompareStringBalance(orderEntity.FilledAmount, orderEntity.Amount) < 0 {
+			orderEntity.Status = order.PartiallyFilled
+			continue
+		}
+
+		// quote token left of a filled buy order executed under its limit price is unlocked
+		orderEntity.Status = order.Filled
+		if err := o.unlockRemaining(ctx, balanceMap, orderEntity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *orderBookService) getOrderInfo(ctx contractapi.TransactionContextInterface, orderMap map[string]*entity.Order, orderId string) (*entity.Order, error) {
+	if orderEntity, ok := orderMap[orderId]; ok {
+		return orderEntity, nil
+	}
+
+	orderEntity, err := o.GetOrder(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+	orderMap[orderId] = orderEntity
+
+	return orderEntity, nil
+}
+
+func (o *orderBookService) updateOrders(ctx contractapi.TransactionContextInterface, orderMap map[string]*entity.Order) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	for _, orderEntity := range orderMap {
+		orderEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+		if err := o.Repo.Update(ctx, orderEntity, doc.Order, helper.OrderKey(orderEntity.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "OrderBook Service - Update order (%s) failed with error (%v)", orderEntity.Id, err)
+			return helper.RespError(errorcode.BizUnableUpdateOrder)
+		}
+	}
+	return nil
+}
+
+// nextSequence increase the order sequence of the token pair and return it, the sequence gives the price-time priority
+// of the orders of the pair. Orders of the same pair placed in the same block conflict on it, only the first is committed.
+func (o *orderBookService) nextSequence(ctx contractapi.TransactionContextInterface, baseTokenId, quoteTokenId string) (int64, error) {
+	isExisted, sequenceData, err := o.Repo.GetAndCheckExist(ctx, doc.OrderSequence, helper.OrderSequenceKey(baseTokenId, quoteTokenId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Get order sequence failed with error (%v)", err)
+		return 0, helper.RespError(errorcode.BizUnableCreateOrder)
+	}
+
+	sequence := entity.NewOrderSequence(ctx)
+	if isExisted {
+		if err := mapstructure.Decode(sequenceData, &sequence); err != nil {
+			glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Decode order sequence failed with error (%v)", err)
+			return 0, helper.RespError(errorcode.BizUnableMapDecode)
+		}
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	sequence.BaseTokenId = baseTokenId
+	sequence.QuoteTokenId = quoteTokenId
+	sequence.Last++
+	sequence.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := o.Repo.Update(ctx, sequence, doc.OrderSequence, helper.OrderSequenceKey(baseTokenId, quoteTokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "PlaceLimitOrder - Update order sequence failed with error (%v)", err)
+		return 0, helper.RespError(errorcode.BizUnableCreateOrder)
+	}
+	return sequence.Last, nil
+}
+
+// validateControl return error when the base or quote token is paused or deactivated, or its balance of the wallet is frozen
+func (o *orderBookService) validateControl(ctx contractapi.TransactionContextInterface, walletId, baseTokenId, quoteTokenId string) error {
+	for _, tokenId := range []string{baseTokenId, quoteTokenId} {
+		if err := o.ValidateTokenControl(ctx, walletId, tokenId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockedTokenId return the token locked by the order, base token of a sell order or quote token of a buy order
+func (o *orderBookService) lockedTokenId(orderEntity *entity.Order) string {
+	if orderEntity.Side == order.Buy {
+		return orderEntity.QuoteTokenId
+	}
+	return orderEntity.BaseTokenId
+}
+
+// lockAmount move amount from spot balance of wallet to its exchange balance
+func (o *orderBookService) lockAmount(ctx contractapi.TransactionContextInterface, balanceMap map[string]*entity.BalanceCache,
+	walletId, tokenId, amount string) error {
+	if err := o.SubSpendableAmount(ctx, balanceMap, walletId, tokenId, amount); err != nil {
+		return err
+	}
+	return o.AddAmount(ctx, balanceMap, doc.ExchangeBalances, walletId, tokenId, amount)
+}
+
+// unlockRemaining move the locked amount of the order back to the spot balance of the wallet
+func (o *orderBookService) unlockRemaining(ctx contractapi.TransactionContextInterface, balanceMap map[string]*entity.BalanceCache,
+	orderEntity *entity.Order) error {
+	if helper.CompareStringBalance(orderEntity.LockedAmount, "0") <= 0 {
+		return nil
+	}
+
+	tokenId := o.lockedTokenId(orderEntity)
+	if err := o.SubAmount(ctx, balanceMap, doc.ExchangeBalances, orderEntity.WalletId, tokenId, orderEntity.LockedAmount); err != nil {
+		return err
+	}
+	if err := o.AddAmount(ctx, balanceMap, doc.SpotBalances, orderEntity.WalletId, tokenId, orderEntity.LockedAmount); err != nil {
+		return err
+	}
+	orderEntity.LockedAmount = "0"
+
+	return nil
+}
+
+func minRemaining(buyOrder, sellOrder *entity.Order) (string, error) {
+	buyRemaining, err := helper.SubBalance(buyOrder.Amount, buyOrder.FilledAmount)
+	if err != nil {
+		return "", err
+	}
+
+	sellRemaining, err := helper.SubBalance(sellOrder.Amount, sellOrder.FilledAmount)
+	if err != nil {
+		return "", err
+	}
+
+	if helper.CompareStringBalance(buyRemaining, sellRemaining) < 0 {
+		return buyRemaining, nil
+	}
+	return sellRemaining, nil
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Pool is the constant-product liquidity pools between two token types.
+// Deposits, withdrawals and swaps are settled against the reserves of the pool by accounting job.
+type Pool interface {
+	// CreatePool to create the liquidity pool of a token pair with the fee of swaps in basis points
+	CreatePool(ctx contractapi.TransactionContextInterface, tokenA, tokenB string, feeBps int64) (string, error)
+
+	// AddLiquidity to deposit a token pair into the pool for lp token. It returns id of the transaction
+	AddLiquidity(ctx contractapi.TransactionContextInterface, poolId, walletId, amountA, amountB string) (string, error)
+
+	// RemoveLiquidity to burn lp token for the share of the reserves of the pool. It returns id of the transaction
+	RemoveLiquidity(ctx contractapi.TransactionContextInterface, poolId, walletId, liquidity string) (string, error)
+
+	// SwapExactIn to swap an exact amount of a token of the pool for at least minAmountOut of the other token.
+	// It returns id of the transaction
+	SwapExactIn(ctx contractapi.TransactionContextInterface, poolId, walletId, fromTokenId, amountIn, minAmountOut string) (string, error)
+
+	// GetPoolState return the reserves of the pool and the quote of amountIn of fromTokenId when it is given
+	GetPoolState(ctx contractapi.TransactionContextInterface, poolId, fromTokenId, amountIn string) (string, error)
+}
@@ -0,0 +1,313 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pool
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/amm"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/Akachain/gringotts/services/token"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type poolService struct {
+	*base.Base
+	tokenService services.Token
+}
+
+func NewPoolService() services.Pool {
+	return &poolService{
+		base.NewBase(),
+		token.NewTokenService(),
+	}
+}
+
+// State is the reserves of a pool and the quote of a swap
+type State struct {
+	*entity.Pool
+	ReserveA       string `json:"reserveA"`
+	ReserveB       string `json:"reserveB"`
+	TotalLiquidity string `json:"totalLiquidity"`
+	AmountOut      string `json:"amountOut,omitempty"`
+}
+
+func (p *poolService) CreatePool(ctx contractapi.TransactionContextInterface, tokenA, tokenB string, feeBps int64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Service - CreatePool-----------")
+
+	// token pair is kept in order so a pair has only one pool
+	if tokenA > tokenB {
+		tokenA, tokenB = tokenB, tokenA
+	}
+
+	if _, err := p.GetTokenType(ctx, tokenA); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreatePool - Get token A failed with error (%v)", err)
+		return "", err
+	}
+
+	if _, err := p.GetTokenType(ctx, tokenB); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreatePool - Get token B failed with error (%v)", err)
+		return "", err
+	}
+
+	pools, err := p.QueryDocuments(ctx, query.GetPoolByPairQueryString(tokenA, tokenB))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreatePool - Query pool of token pair failed with error (%v)", err)
+		return "", err
+	}
+
+	if len(pools) > 0 {
+		glogger.GetInstance().Errorf(ctx, "CreatePool - Pool of token pair (%s, %s) already exists", tokenA, tokenB)
+		return "", helper.RespError(errorcode.BizPoolExisted)
+	}
+
+	poolEntity := entity.NewPool(ctx)
+	poolEntity.TokenA = tokenA
+	poolEntity.TokenB = tokenB
+	poolEntity.FeeBps = feeBps
+
+	if err := p.Repo.Create(ctx, poolEntity, doc.Pool, helper.PoolKey(poolEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreatePool - Create pool failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreatePool)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Pool Service - CreatePool succeed (%s)-----------", poolEntity.Id)
+
+	return poolEntity.Id, nil
+}
+
+func (p *poolService) AddLiquidity(ctx contractapi.TransactionContextInterface, poolId, walletId, amountA, amountB string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Service - AddLiquidity-----------")
+
+	wallet, err := p.GetActiveWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "AddLiquidity - Get wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := p.RejectMultiSig(ctx, wallet); err != nil {
+		return "", err
+	}
+
+	poolEntity, err := p.getActivePool(ctx, poolId)
+	if err != nil {
+		return "", err
+	}
+
+	// lp token of the pool is created at the first deposit
+	if poolEntity.LpTokenId == "" {
+		if err := p.createLpToken(ctx, poolEntity); err != nil {
+			return "", err
+		}
+	}
+
+	txId, err := p.createTx(ctx, transaction.PoolAddLiquidity, walletId, poolEntity.Id, poolEntity.TokenA, poolEntity.TokenB, amountA, amountB)
+	if err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Pool Service - AddLiquidity succeed (%s)-----------", txId)
+
+	return txId, nil
+}
+
+func (p *poolService) RemoveLiquidity(ctx contractapi.TransactionContextInterface, poolId, walletId, liquidity string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Service - RemoveLiquidity-----------")
+
+	wallet, err := p.GetActiveWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "RemoveLiquidity - Get wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := p.RejectMultiSig(ctx, wallet); err != nil {
+		return "", err
+	}
+
+	poolEntity, err := p.getActivePool(ctx, poolId)
+	if err != nil {
+		return "", err
+	}
+
+	if poolEntity.LpTokenId == "" {
+		glogger.GetInstance().Errorf(ctx, "RemoveLiquidity - Pool (%s) has no liquidity", poolId)
+		return "", helper.RespError(errorcode.BizPoolInvalidToken)
+	}
+
+	txId, err := p.createTx(ctx, transaction.PoolRemoveLiquidity, walletId, poolEntity.Id, poolEntity.LpTokenId, poolEntity.LpTokenId, liquidity, liquidity)
+	if err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Pool Service - RemoveLiquidity succeed (%s)-----------", txId)
+
+	return txId, nil
+}
+
+func (p *poolService) SwapExactIn(ctx contractapi.TransactionContextInterface, poolId, walletId, fromTokenId, amountIn, minAmountOut string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Service - SwapExactIn-----------")
+
+	wallet, err := p.GetActiveWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "SwapExactIn - Get wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := p.RejectMultiSig(ctx, wallet); err != nil {
+		return "", err
+	}
+
+	poolEntity, err := p.getActivePool(ctx, poolId)
+	if err != nil {
+		return "", err
+	}
+
+	toTokenId, err := p.otherToken(ctx, poolEntity, fromTokenId)
+	if err != nil {
+		return "", err
+	}
+
+	txId, err := p.createTx(ctx, transaction.PoolSwap, walletId, poolEntity.Id, fromTokenId, toTokenId, amountIn, minAmountOut)
+	if err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Pool Service - SwapExactIn succeed (%s)-----------", txId)
+
+	return txId, nil
+}
+
+func (p *poolService) GetPoolState(ctx contractapi.TransactionContextInterface, poolId, fromTokenId, amountIn string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Pool Service - GetPoolState-----------")
+
+	poolEntity, err := p.GetPool(ctx, poolId)
+	if err != nil {
+		return "", err
+	}
+
+	balanceMap := make(map[string]*entity.BalanceCache, 3)
+	state := &State{Pool: poolEntity}
+	if state.ReserveA, err = p.CurrentAmount(ctx, balanceMap, doc.PoolBalances, poolEntity.Id, poolEntity.TokenA); err != nil {
+		return "", err
+	}
+	if state.ReserveB, err = p.CurrentAmount(ctx, balanceMap, doc.PoolBalances, poolEntity.Id, poolEntity.TokenB); err != nil {
+		return "", err
+	}
+	state.TotalLiquidity = "0"
+	if poolEntity.LpTokenId != "" {
+		if state.TotalLiquidity, err = p.CurrentAmount(ctx, balanceMap, doc.PoolBalances, poolEntity.Id, poolEntity.LpTokenId); err != nil {
+			return "", err
+		}
+	}
+
+	if fromTokenId != "" && amountIn != "" {
+		reserveIn, reserveOut := state.ReserveA, state.ReserveB
+		if fromTokenId == poolEntity.TokenB {
+			reserveIn, reserveOut = state.ReserveB, state.ReserveA
+		} else if fromTokenId != poolEntity.TokenA {
+			glogger.GetInstance().Errorf(ctx, "GetPoolState - Token (%s) is not in pool (%s)", fromTokenId, poolId)
+			return "", helper.RespError(errorcode.BizPoolInvalidToken)
+		}
+
+		if state.AmountOut, err = amm.SwapOut(reserveIn, reserveOut, amountIn, poolEntity.FeeBps); err != nil {
+			glogger.GetInstance().Errorf(ctx, "GetPoolState - Calculate amount out failed with error (%v)", err)
+			return "", helper.RespError(errorcode.BizUnableCalculatePool)
+		}
+	}
+
+	return helper.MarshalStruct(state), nil
+}
+
+func (p *poolService) getActivePool(ctx contractapi.TransactionContextInterface, poolId string) (*entity.Pool, error) {
+	poolEntity, err := p.GetPool(ctx, poolId)
+	if err != nil {
+		return nil, err
+	}
+
+	if poolEntity.Status != glossary.Active {
+		glogger.GetInstance().Errorf(ctx, "Pool Service - Pool (%s) has status (%s)", poolId, poolEntity.Status)
+		return nil, helper.RespError(errorcode.BizUnableGetPool)
+	}
+	return poolEntity, nil
+}
+
+func (p *poolService) otherToken(ctx contractapi.TransactionContextInterface, poolEntity *entity.Pool, tokenId string) (string, error) {
+	switch tokenId {
+	case poolEntity.TokenA:
+		return poolEntity.TokenB, nil
+	case poolEntity.TokenB:
+		return poolEntity.TokenA, nil
+	}
+	glogger.GetInstance().Errorf(ctx, "Pool Service - Token (%s) is not in pool (%s)", tokenId, poolEntity.Id)
+	return "", helper.RespError(errorcode.BizPoolInvalidToken)
+}
+
+// createLpToken create the lp token type of the pool, its name and ticker are made of the tickers of the token pair.
+// The lp token is managed by the pool, so its supply only changes when liquidity is added or removed.
+func (p *poolService) createLpToken(ctx contractapi.TransactionContextInterface, poolEntity *entity.Pool) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	tokenA, err := p.GetTokenType(ctx, poolEntity.TokenA)
+	if err != nil {
+		return err
+	}
+
+	tokenB, err := p.GetTokenType(ctx, poolEntity.TokenB)
+	if err != nil {
+		return err
+	}
+
+	ticker := tokenA.TickerToken + "-" + tokenB.TickerToken + "-LP"
+	lpTokenId, err := p.tokenService.CreateManagedType(ctx, ticker+" Liquidity", ticker, "", poolEntity.Id)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Service - Create lp token failed with error (%v)", err)
+		return err
+	}
+
+	poolEntity.LpTokenId = lpTokenId
+	poolEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := p.Repo.Update(ctx, poolEntity, doc.Pool, helper.PoolKey(poolEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Service - Update pool (%s) failed with error (%v)", poolEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdatePool)
+	}
+	return nil
+}
+
+func (p *poolService) createTx(ctx contractapi.TransactionContextInterface, txType transaction.Type, walletId, poolId,
+	fromTokenId, toTokenId, fromTokenAmount, toTokenAmount string) (string, error) {
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = walletId
+	txEntity.FromWallet = walletId
+	txEntity.ToWallet = poolId
+	txEntity.FromTokenId = fromTokenId
+	txEntity.ToTokenId = toTokenId
+	txEntity.FromTokenAmount = fromTokenAmount
+	txEntity.ToTokenAmount = toTokenAmount
+	txEntity.TxType = txType
+	txEntity.Note = poolId
+
+	if err := p.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Pool Service - Create transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+	return txEntity.Id, nil
+}
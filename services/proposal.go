@@ -0,0 +1,59 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// Proposal is the M-of-N approval of privileged operations.
+// An operation with an approval policy is created as a proposal and executed once enough approvers approve it
+type Proposal interface {
+	// SetApprovalPolicy to set approvers and threshold of an operation of a token, an empty token applies to every token.
+	// A threshold of zero lifts the approval. Only admin is allowed
+	SetApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string, approvers []string, threshold int, ttl int64) error
+
+	// ApplyApprovalPolicy to set the approval policy of an approved SetApprovalPolicy proposal, its approvers stand for the admin
+	ApplyApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string, approvers []string, threshold int, ttl int64) error
+
+	// GetApprovalPolicy return the approval policy that applies to an operation of a token
+	GetApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) (string, error)
+
+	// CheckApproval return error when an operation of a token can not be executed without an approved proposal
+	CheckApproval(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) error
+
+	// CreateProposal to propose an operation of a token with its serialized dto. The proposal is approved under the
+	// policy of policyOperation, which is the operation itself except for a change of policy approved under the policy it changes
+	CreateProposal(ctx contractapi.TransactionContextInterface, operation, policyOperation proposal.Operation, tokenId, payload string) (string, error)
+
+	// ApproveProposal to approve a proposal by the caller. The proposal returned is Approved once the threshold is reached
+	ApproveProposal(ctx contractapi.TransactionContextInterface, proposalId string) (*entity.Proposal, error)
+
+	// RejectProposal to reject a proposal by the caller. The proposal is Rejected once the threshold can not be reached
+	RejectProposal(ctx contractapi.TransactionContextInterface, proposalId string) error
+
+	// CompleteProposal to record the result of an approved proposal after its operation is executed
+	CompleteProposal(ctx contractapi.TransactionContextInterface, proposalEntity *entity.Proposal, result string) error
+
+	// GetProposal return the proposal and its status
+	GetProposal(ctx contractapi.TransactionContextInterface, proposalId string) (string, error)
+}
@@ -0,0 +1,296 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package proposal
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type proposalService struct {
+	*base.Base
+}
+
+func NewProposalService() services.Proposal {
+	return &proposalService{base.NewBase()}
+}
+
+func (p *proposalService) SetApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string,
+	approvers []string, threshold int, ttl int64) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - SetApprovalPolicy-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "SetApprovalPolicy - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	return p.writePolicy(ctx, operation, tokenId, approvers, threshold, ttl)
+}
+
+func (p *proposalService) ApplyApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string,
+	approvers []string, threshold int, ttl int64) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - ApplyApprovalPolicy-----------")
+	return p.writePolicy(ctx, operation, tokenId, approvers, threshold, ttl)
+}
+
+// writePolicy create or replace the approval policy of an operation of a token
+func (p *proposalService) writePolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string,
+	approvers []string, threshold int, ttl int64) error {
+	policy, isExisted, err := p.GetAndCheckExistApprovalPolicy(ctx, operation, tokenId)
+	if err != nil {
+		return err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if !isExisted {
+		policy = entity.NewApprovalPolicy(ctx)
+		policy.Id = helper.GenerateID(doc.ApprovalPolicy, string(operation)+tokenId)
+		policy.Operation = operation
+		policy.TokenId = tokenId
+	}
+
+	policy.Approvers = nil
+	for _, approver := range approvers {
+		policy.Approvers = helper.ArrayInsert(policy.Approvers, approver)
+	}
+	policy.Threshold = threshold
+	policy.Ttl = ttl
+	policy.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+
+	if err := p.Repo.Update(ctx, policy, doc.ApprovalPolicy, helper.ApprovalPolicyKey(string(operation), tokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Update approval policy failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateApprovalPolicy)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Proposal Service - Set approval policy succeed (%s)-----------", policy.Id)
+
+	return nil
+}
+
+func (p *proposalService) GetApprovalPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - GetApprovalPolicy-----------")
+
+	policy, err := p.getPolicy(ctx, operation, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	if policy == nil {
+		glogger.GetInstance().Errorf(ctx, "GetApprovalPolicy - Operation (%s) of token (%s) has no approval policy", operation, tokenId)
+		return "", helper.RespError(errorcode.BizUnableGetApprovalPolicy)
+	}
+	return helper.MarshalStruct(policy), nil
+}
+
+func (p *proposalService) CheckApproval(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) error {
+	policy, err := p.getPolicy(ctx, operation, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if policy != nil && policy.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "CheckApproval - Operation (%s) of token (%s) requires approval", operation, tokenId)
+		return helper.RespError(errorcode.BizApprovalRequired)
+	}
+	return nil
+}
+
+func (p *proposalService) CreateProposal(ctx contractapi.TransactionContextInterface, operation, policyOperation proposal.Operation, tokenId, payload string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - CreateProposal-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	callerId, err := helper.GetCallerId(ctx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateProposal - Get caller id failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableGetIdentity)
+	}
+
+	policy, err := p.getPolicy(ctx, policyOperation, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	if policy == nil || policy.Threshold <= 0 {
+		glogger.GetInstance().Errorf(ctx, "CreateProposal - Operation (%s) of token (%s) has no approval policy", policyOperation, tokenId)
+		return "", helper.RespError(errorcode.BizUnableGetApprovalPolicy)
+	}
+
+	ttl := policy.Ttl
+	if ttl <= 0 {
+		ttl = glossary.ProposalTtl
+	}
+
+	proposalEntity := entity.NewProposal(ctx)
+	proposalEntity.Operation = operation
+	proposalEntity.TokenId = tokenId
+	proposalEntity.Payload = payload
+	proposalEntity.Proposer = callerId
+	proposalEntity.Approvers = policy.Approvers
+	proposalEntity.Threshold = policy.Threshold
+	proposalEntity.Expiry = txTime.Seconds + ttl
+
+	if err := p.Repo.Create(ctx, proposalEntity, doc.Proposal, helper.ProposalKey(proposalEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateProposal - Create proposal failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateProposal)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Proposal Service - CreateProposal succeed (%s)-----------", proposalEntity.Id)
+
+	return proposalEntity.Id, nil
+}
+
+func (p *proposalService) ApproveProposal(ctx contractapi.TransactionContextInterface, proposalId string) (*entity.Proposal, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - ApproveProposal-----------")
+
+	proposalEntity, callerId, err := p.getVotableProposal(ctx, proposalId)
+	if err != nil {
+		return nil, err
+	}
+
+	proposalEntity.Approvals = helper.ArrayInsert(proposalEntity.Approvals, callerId)
+	if len(proposalEntity.Approvals) >= proposalEntity.Threshold {
+		proposalEntity.Status = proposal.Approved
+	}
+
+	if err := p.updateProposal(ctx, proposalEntity); err != nil {
+		return nil, err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Proposal Service - ApproveProposal succeed (%s: %s)-----------", proposalId, proposalEntity.Status)
+
+	return proposalEntity, nil
+}
+
+func (p *proposalService) RejectProposal(ctx contractapi.TransactionContextInterface, proposalId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - RejectProposal-----------")
+
+	proposalEntity, callerId, err := p.getVotableProposal(ctx, proposalId)
+	if err != nil {
+		return err
+	}
+
+	// the proposal is rejected once the approvers left can not reach the threshold
+	proposalEntity.Rejections = helper.ArrayInsert(proposalEntity.Rejections, callerId)
+	if len(proposalEntity.Approvers)-len(proposalEntity.Rejections) < proposalEntity.Threshold {
+		proposalEntity.Status = proposal.Rejected
+	}
+
+	if err := p.updateProposal(ctx, proposalEntity); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Proposal Service - RejectProposal succeed (%s: %s)-----------", proposalId, proposalEntity.Status)
+
+	return nil
+}
+
+func (p *proposalService) CompleteProposal(ctx contractapi.TransactionContextInterface, proposalEntity *entity.Proposal, result string) error {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - CompleteProposal-----------")
+
+	if proposalEntity.Status != proposal.Approved {
+		glogger.GetInstance().Errorf(ctx, "CompleteProposal - Proposal (%s) has status (%s)", proposalEntity.Id, proposalEntity.Status)
+		return helper.RespError(errorcode.BizProposalInvalidStatus)
+	}
+
+	proposalEntity.Status = proposal.Executed
+	proposalEntity.Result = result
+	return p.updateProposal(ctx, proposalEntity)
+}
+
+func (p *proposalService) GetProposal(ctx contractapi.TransactionContextInterface, proposalId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Proposal Service - GetProposal-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	proposalEntity, err := p.Base.GetProposal(ctx, proposalId)
+	if err != nil {
+		return "", err
+	}
+
+	// a pending proposal past its expiry can not be approved anymore
+	if proposalEntity.Status == proposal.Pending && helper.IsExpired(proposalEntity.Expiry, txTime.Seconds) {
+		proposalEntity.Status = proposal.Expired
+	}
+
+	return helper.MarshalStruct(proposalEntity), nil
+}
+
+// getPolicy return the approval policy of the token, or the policy of every token when the token has none
+func (p *proposalService) getPolicy(ctx contractapi.TransactionContextInterface, operation proposal.Operation, tokenId string) (*entity.ApprovalPolicy, error) {
+	if tokenId != "" {
+		policy, isExisted, err := p.GetAndCheckExistApprovalPolicy(ctx, operation, tokenId)
+		if err != nil || isExisted {
+			return policy, err
+		}
+	}
+
+	policy, _, err := p.GetAndCheckExistApprovalPolicy(ctx, operation, "")
+	return policy, err
+}
+
+// getVotableProposal return a pending proposal that the caller can still vote on
+func (p *proposalService) getVotableProposal(ctx contractapi.TransactionContextInterface, proposalId string) (*entity.Proposal, string, error) {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	callerId, err := helper.GetCallerId(ctx)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Get caller id failed with error (%v)", err)
+		return nil, "", helper.RespError(errorcode.BizUnableGetIdentity)
+	}
+
+	proposalEntity, err := p.Base.GetProposal(ctx, proposalId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if proposalEntity.Status != proposal.Pending {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Proposal (%s) has status (%s)", proposalId, proposalEntity.Status)
+		return nil, "", helper.RespError(errorcode.BizProposalInvalidStatus)
+	}
+
+	if helper.IsExpired(proposalEntity.Expiry, txTime.Seconds) {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Proposal (%s) has expired", proposalId)
+		return nil, "", helper.RespError(errorcode.BizProposalExpired)
+	}
+
+	if !helper.ArrayContains(proposalEntity.Approvers, callerId) {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Caller (%s) is not an approver of proposal (%s)", callerId, proposalId)
+		return nil, "", helper.RespError(errorcode.BizProposalNotApprover)
+	}
+
+	if helper.ArrayContains(proposalEntity.Approvals, callerId) || helper.ArrayContains(proposalEntity.Rejections, callerId) {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Caller (%s) has voted on proposal (%s)", callerId, proposalId)
+		return nil, "", helper.RespError(errorcode.BizProposalAlreadyVoted)
+	}
+	return proposalEntity, callerId, nil
+}
+
+func (p *proposalService) updateProposal(ctx contractapi.TransactionContextInterface, proposalEntity *entity.Proposal) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	proposalEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := p.Repo.Update(ctx, proposalEntity, doc.Proposal, helper.ProposalKey(proposalEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Proposal Service - Update proposal (%s) failed with error (%v)", proposalEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateProposal)
+	}
+	return nil
+}
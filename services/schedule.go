@@ -0,0 +1,45 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Schedule is the standing order of scheduled and recurring transfers.
+// Due runs are written as pending transfers by the operator and settled by accounting.
+type Schedule interface {
+	// CreateSchedule to create a standing order from a wallet, start and end are unix seconds
+	CreateSchedule(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount string,
+		startTime, interval, endTime int64, count int) (string, error)
+
+	// PauseSchedule to stop the runs of a schedule until it is resumed
+	PauseSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error
+
+	// ResumeSchedule to continue the runs of a paused schedule. Runs missed while paused are skipped
+	ResumeSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error
+
+	// CancelSchedule to stop the runs of a schedule permanently
+	CancelSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error
+
+	// GetSchedules return the schedules from a wallet
+	GetSchedules(ctx contractapi.TransactionContextInterface, walletId string) (string, error)
+
+	// ExecuteDueSchedules to write the pending transfers of due schedules and return the number written. Only admin is allowed
+	ExecuteDueSchedules(ctx contractapi.TransactionContextInterface) (int, error)
+}
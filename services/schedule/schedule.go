@@ -0,0 +1,265 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package schedule
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/schedule"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/mitchellh/mapstructure"
+)
+
+type scheduleService struct {
+	*base.Base
+}
+
+func NewScheduleService() services.Schedule {
+	return &scheduleService{base.NewBase()}
+}
+
+func (s *scheduleService) CreateSchedule(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId,
+	amount string, startTime, interval, endTime int64, count int) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - CreateSchedule-----------")
+
+	walletFrom, _, err := s.ValidatePairWallet(ctx, fromWalletId, toWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateSchedule - Validation pair wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the runs are written without signatures, so they would bypass the threshold of a multi-signature wallet
+	if walletFrom.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "CreateSchedule - Wallet (%s) is a multi-signature wallet", fromWalletId)
+		return "", helper.RespError(errorcode.BizScheduleMultiSig)
+	}
+
+	if err := s.ValidateTokenControl(ctx, fromWalletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateSchedule - Validation token control failed with error (%v)", err)
+		return "", err
+	}
+
+	scheduleEntity := entity.NewSchedule(ctx)
+	scheduleEntity.FromWallet = fromWalletId
+	scheduleEntity.ToWallet = toWalletId
+	scheduleEntity.TokenId = tokenId
+	scheduleEntity.Amount = amount
+	scheduleEntity.StartTime = startTime
+	scheduleEntity.Interval = interval
+	scheduleEntity.EndTime = endTime
+	scheduleEntity.Count = count
+	scheduleEntity.NextTime = startTime
+
+	if err := s.Repo.Create(ctx, scheduleEntity, doc.Schedule, helper.ScheduleKey(scheduleEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateSchedule - Create schedule failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateSchedule)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Schedule Service - CreateSchedule succeed (%s)-----------", scheduleEntity.Id)
+
+	return scheduleEntity.Id, nil
+}
+
+func (s *scheduleService) PauseSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - PauseSchedule-----------")
+
+	scheduleEntity, err := s.getOwnedSchedule(ctx, walletId, scheduleId, schedule.Active)
+	if err != nil {
+		return err
+	}
+
+	scheduleEntity.Status = schedule.Paused
+	return s.updateSchedule(ctx, scheduleEntity)
+}
+
+func (s *scheduleService) ResumeSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - ResumeSchedule-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	scheduleEntity, err := s.getOwnedSchedule(ctx, walletId, scheduleId, schedule.Paused)
+	if err != nil {
+		return err
+	}
+
+	// runs missed while paused are skipped, the schedule continues from its next run after now
+	if scheduleEntity.Interval > 0 {
+		for scheduleEntity.NextTime < txTime.Seconds {
+			scheduleEntity.NextTime += scheduleEntity.Interval
+		}
+	}
+
+	scheduleEntity.Status = schedule.Active
+	if scheduleEntity.IsDone() {
+		scheduleEntity.Status = schedule.Completed
+	}
+	return s.updateSchedule(ctx, scheduleEntity)
+}
+
+func (s *scheduleService) CancelSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - CancelSchedule-----------")
+
+	scheduleEntity, err := s.getOwnedSchedule(ctx, walletId, scheduleId, schedule.Active, schedule.Paused)
+	if err != nil {
+		return err
+	}
+
+	scheduleEntity.Status = schedule.Canceled
+	return s.updateSchedule(ctx, scheduleEntity)
+}
+
+func (s *scheduleService) GetSchedules(ctx contractapi.TransactionContextInterface, walletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - GetSchedules-----------")
+
+	schedules, err := s.QueryDocuments(ctx, query.GetScheduleByWalletQueryString(walletId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "GetSchedules - Query schedules failed with error (%v)", err)
+		return "", err
+	}
+
+	return helper.MarshalStruct(schedules), nil
+}
+
+func (s *scheduleService) ExecuteDueSchedules(ctx contractapi.TransactionContextInterface) (int, error) {
+	glogger.GetInstance().Info(ctx, "-----------Schedule Service - ExecuteDueSchedules-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "ExecuteDueSchedules - Caller is not admin")
+		return 0, helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	schedules, err := s.QueryDocuments(ctx, query.GetDueScheduleQueryString(txTime.Seconds))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ExecuteDueSchedules - Query due schedules failed with error (%v)", err)
+		return 0, err
+	}
+
+	// limit the write set of a transaction, the rest is executed by the next call
+	if len(schedules) > int(glossary.PaginationSize) {
+		schedules = schedules[:glossary.PaginationSize]
+	}
+
+	written := 0
+	for _, scheduleData := range schedules {
+		scheduleEntity := entity.NewSchedule()
+		if err := json.Unmarshal(scheduleData, scheduleEntity); err != nil {
+			glogger.GetInstance().Errorf(ctx, "ExecuteDueSchedules - Unmarshal schedule failed with error (%v)", err)
+			return 0, helper.RespError(errorcode.BizUnableMapDecode)
+		}
+
+		created, err := s.executeRun(ctx, scheduleEntity)
+		if err != nil {
+			return 0, err
+		}
+		if created {
+			written++
+		}
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Schedule Service - ExecuteDueSchedules succeed (%d)-----------", written)
+
+	return written, nil
+}
+
+// executeRun write the pending transfer of the next run of a due schedule and move the schedule to the run after.
+// One run is written per call, missed runs are caught up by the next calls.
+// The transaction id is derived from the schedule id and the run number so a run is never written twice.
+func (s *scheduleService) executeRun(ctx contractapi.TransactionContextInterface, scheduleEntity *entity.Schedule) (bool, error) {
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.Id = helper.GenerateBatchID(doc.Transactions, scheduleEntity.Id, scheduleEntity.Executed)
+	txEntity.SpenderWallet = scheduleEntity.FromWallet
+	txEntity.FromWallet = scheduleEntity.FromWallet
+	txEntity.ToWallet = scheduleEntity.ToWallet
+	txEntity.FromTokenId = scheduleEntity.TokenId
+	txEntity.ToTokenId = scheduleEntity.TokenId
+	txEntity.FromTokenAmount = scheduleEntity.Amount
+	txEntity.ToTokenAmount = scheduleEntity.Amount
+	txEntity.TxType = transaction.Transfer
+	txEntity.Note = scheduleEntity.Id
+
+	exist, err := s.Repo.IsExist(ctx, doc.Transactions, helper.TransactionKey(txEntity.Id))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Schedule Service - Check transaction (%s) failed with error (%v)", txEntity.Id, err)
+		return false, helper.RespError(errorcode.BizUnableGetTx)
+	}
+
+	if !exist {
+		if err := s.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Schedule Service - Create transaction of schedule (%s) failed with error (%v)", scheduleEntity.Id, err)
+			return false, helper.RespError(errorcode.BizUnableCreateTX)
+		}
+	}
+
+	scheduleEntity.Executed++
+	scheduleEntity.NextTime += scheduleEntity.Interval
+	if scheduleEntity.IsDone() {
+		scheduleEntity.Status = schedule.Completed
+	}
+	if err := s.updateSchedule(ctx, scheduleEntity); err != nil {
+		return false, err
+	}
+	return !exist, nil
+}
+
+// getOwnedSchedule return the schedule from the wallet when it has one of the status
+func (s *scheduleService) getOwnedSchedule(ctx contractapi.TransactionContextInterface, walletId, scheduleId string,
+	status ...schedule.Status) (*entity.Schedule, error) {
+	scheduleData, err := s.Repo.Get(ctx, doc.Schedule, helper.ScheduleKey(scheduleId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Schedule Service - Get schedule (%s) failed with error (%v)", scheduleId, err)
+		return nil, helper.RespError(errorcode.BizUnableGetSchedule)
+	}
+
+	scheduleEntity := entity.NewSchedule()
+	if err = mapstructure.Decode(scheduleData, &scheduleEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Schedule Service - Decode schedule failed with error (%v)", err)
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+
+	if scheduleEntity.FromWallet != walletId {
+		glogger.GetInstance().Errorf(ctx, "Schedule Service - Wallet (%s) is not the source of schedule (%s)", walletId, scheduleId)
+		return nil, helper.RespError(errorcode.BizNotScheduleSource)
+	}
+
+	for _, st := range status {
+		if scheduleEntity.Status == st {
+			return scheduleEntity, nil
+		}
+	}
+	glogger.GetInstance().Errorf(ctx, "Schedule Service - Schedule (%s) has status (%s)", scheduleId, scheduleEntity.Status)
+	return nil, helper.RespError(errorcode.BizScheduleInvalidStatus)
+}
+
+func (s *scheduleService) updateSchedule(ctx contractapi.TransactionContextInterface, scheduleEntity *entity.Schedule) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	scheduleEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := s.Repo.Update(ctx, scheduleEntity, doc.Schedule, helper.ScheduleKey(scheduleEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Schedule Service - Update schedule (%s) failed with error (%v)", scheduleEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateSchedule)
+	}
+	return nil
+}
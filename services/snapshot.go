@@ -0,0 +1,47 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Snapshot records the balances of the holders of a token and distributes an amount to them in proportion to their
+// balances. Snapshots and distributions are processed a page of holders per invocation until they are completed.
+// A balance is read when its page is scanned, so the token must be paused during the whole scan for an exact cutoff.
+type Snapshot interface {
+	// CreateSnapshot to start a snapshot of the spot balances of a paused token and scan its first page. Only admin is allowed
+	CreateSnapshot(ctx contractapi.TransactionContextInterface, tokenId string) (string, error)
+
+	// ScanSnapshot to scan the next page of holders of a snapshot, the token must still be paused. Only admin is allowed
+	ScanSnapshot(ctx contractapi.TransactionContextInterface, snapshotId string) error
+
+	// GetSnapshot return the snapshot
+	GetSnapshot(ctx contractapi.TransactionContextInterface, snapshotId string) (string, error)
+
+	// DistributeProRata to hold total amount of payout token from a wallet and pay the first page of holders of a completed
+	// snapshot. The rounding remainder is paid to the remainder wallet. Only admin is allowed
+	DistributeProRata(ctx contractapi.TransactionContextInterface, snapshotId, fromWalletId, payoutTokenId, totalAmount,
+		remainderWalletId string) (string, error)
+
+	// ContinueDistribution to pay the next page of holders of a distribution. Only admin is allowed
+	ContinueDistribution(ctx contractapi.TransactionContextInterface, distributionId string) error
+
+	// GetDistribution return the distribution
+	GetDistribution(ctx contractapi.TransactionContextInterface, distributionId string) (string, error)
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package snapshot
+
+import (
+	"encoding/json"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/snapshot"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/query"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/mitchellh/mapstructure"
+)
+
+type snapshotService struct {
+	*base.Base
+}
+
+func NewSnapshotService() services.Snapshot {
+	return &snapshotService{base.NewBase()}
+}
+
+func (s *snapshotService) CreateSnapshot(ctx contractapi.TransactionContextInterface, tokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - CreateSnapshot-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "CreateSnapshot - Caller is not admin")
+		return "", helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	if err := s.checkPaused(ctx, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateSnapshot - Check token failed with error (%v)", err)
+		return "", err
+	}
+
+	snapshotEntity := entity.NewSnapshot(ctx)
+	snapshotEntity.TokenId = tokenId
+	if err := s.Repo.Create(ctx, snapshotEntity, doc.Snapshot, helper.SnapshotKey(snapshotEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CreateSnapshot - Create snapshot failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateSnapshot)
+	}
+
+	if err := s.scanPage(ctx, snapshotEntity); err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Snapshot Service - CreateSnapshot succeed (%s)-----------", snapshotEntity.Id)
+
+	return snapshotEntity.Id, nil
+}
+
+func (s *snapshotService) ScanSnapshot(ctx contractapi.TransactionContextInterface, snapshotId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - ScanSnapshot-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "ScanSnapshot - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	snapshotEntity, err := s.getSnapshot(ctx, snapshotId)
+	if err != nil {
+		return err
+	}
+
+	if snapshotEntity.Status != snapshot.Scanning {
+		glogger.GetInstance().Errorf(ctx, "ScanSnapshot - Snapshot (%s) has status (%s)", snapshotId, snapshotEntity.Status)
+		return helper.RespError(errorcode.BizSnapshotInvalidStatus)
+	}
+
+	if err := s.checkPaused(ctx, snapshotEntity.TokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ScanSnapshot - Check token failed with error (%v)", err)
+		return err
+	}
+
+	return s.scanPage(ctx, snapshotEntity)
+}
+
+func (s *snapshotService) GetSnapshot(ctx contractapi.TransactionContextInterface, snapshotId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - GetSnapshot-----------")
+
+	snapshotEntity, err := s.getSnapshot(ctx, snapshotId)
+	if err != nil {
+		return "", err
+	}
+
+	return helper.MarshalStruct(snapshotEntity), nil
+}
+
+func (s *snapshotService) DistributeProRata(ctx contractapi.TransactionContextInterface, snapshotId, fromWalletId, payoutTokenId,
+	totalAmount, remainderWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - DistributeProRata-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "DistributeProRata - Caller is not admin")
+		return "", helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	snapshotEntity, err := s.getSnapshot(ctx, snapshotId)
+	if err != nil {
+		return "", err
+	}
+
+	if snapshotEntity.Status != snapshot.Completed {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Snapshot (%s) has status (%s)", snapshotId, snapshotEntity.Status)
+		return "", helper.RespError(errorcode.BizSnapshotInvalidStatus)
+	}
+
+	if snapshotEntity.Holders == 0 || helper.CompareStringBalance(snapshotEntity.TotalBalance, "0") <= 0 {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Snapshot (%s) has no holder balance", snapshotId)
+		return "", helper.RespError(errorcode.BizSnapshotEmpty)
+	}
+
+	walletFrom, _, err := s.ValidatePairWallet(ctx, fromWalletId, remainderWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Validation wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the payouts are written without intents, so they would bypass the threshold of a multi-signature wallet
+	if walletFrom.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Wallet (%s) is a multi-signature wallet", fromWalletId)
+		return "", helper.RespError(errorcode.BizBatchMultiSig)
+	}
+
+	if err := s.ValidateTokenControl(ctx, fromWalletId, payoutTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Validation token control failed with error (%v)", err)
+		return "", err
+	}
+
+	// the total amount is held so every payout of the later pages is funded
+	balanceMap := make(map[string]*entity.BalanceCache, 1)
+	if err := s.HoldAmount(ctx, balanceMap, fromWalletId, payoutTokenId, totalAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Hold amount failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableHoldBalance)
+	}
+	if err := s.UpdateBalance(ctx, balanceMap); err != nil {
+		return "", err
+	}
+
+	distributionEntity := entity.NewDistribution(ctx)
+	distributionEntity.SnapshotId = snapshotId
+	distributionEntity.FromWallet = fromWalletId
+	distributionEntity.PayoutTokenId = payoutTokenId
+	distributionEntity.TotalAmount = totalAmount
+	distributionEntity.RemainderWallet = remainderWalletId
+	if err := s.Repo.Create(ctx, distributionEntity, doc.Distribution, helper.DistributionKey(distributionEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "DistributeProRata - Create distribution failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateDistribution)
+	}
+
+	if err := s.payPage(ctx, distributionEntity, snapshotEntity); err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Snapshot Service - DistributeProRata succeed (%s)-----------", distributionEntity.Id)
+
+	return distributionEntity.Id, nil
+}
+
+func (s *snapshotService) ContinueDistribution(ctx contractapi.TransactionContextInterface, distributionId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - ContinueDistribution-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "ContinueDistribution - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	distributionEntity, err := s.getDistribution(ctx, distributionId)
+	if err != nil {
+		return err
+	}
+
+	if distributionEntity.Status != snapshot.Distributing {
+		glogger.GetInstance().Errorf(ctx, "ContinueDistribution - Distribution (%s) has status (%s)", distributionId, distributionEntity.Status)
+		return helper.RespError(errorcode.BizDistributionInvalidStatus)
+	}
+
+	snapshotEntity, err := s.getSnapshot(ctx, distributionEntity.SnapshotId)
+	if err != nil {
+		return err
+	}
+
+	return s.payPage(ctx, distributionEntity, snapshotEntity)
+}
+
+func (s *snapshotService) GetDistribution(ctx contractapi.TransactionContextInterface, distributionId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Snapshot Service - GetDistribution-----------")
+
+	distributionEntity, err := s.getDistribution(ctx, distributionId)
+	if err != nil {
+		return "", err
+	}
+
+	return helper.MarshalStruct(distributionEntity), nil
+}
+
+// checkPaused return error when the token is not paused, the balances of a token that can move are counted twice
+// or missed when they move between the pages of a scan
+func (s *snapshotService) checkPaused(ctx contractapi.TransactionContextInterface, tokenId string) error {
+	token, err := s.GetTokenType(ctx, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if token.Status != glossary.Paused {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Token (%s) has status (%s)", tokenId, token.Status)
+		return helper.RespError(errorcode.BizSnapshotTokenNotPaused)
+	}
+	return nil
+}
+
+// scanPage record the balances of the next page of holders in order of wallet id.
+// The snapshot is completed when the page is not full.
+func (s *snapshotService) scanPage(ctx contractapi.TransactionContextInterface, snapshotEntity *entity.Snapshot) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	balances, err := s.QueryDocumentsLimit(ctx, query.GetHolderBalanceQueryString(snapshotEntity.TokenId, snapshotEntity.LastWalletId),
+		int(glossary.PaginationSize))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Query holder balances failed with error (%v)", err)
+		return err
+	}
+
+	for _, balanceData := range balances {
+		balance := new(entity.Balance)
+		if err := json.Unmarshal(balanceData, balance); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Unmarshal balance failed with error (%v)", err)
+			return helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		snapshotEntity.LastWalletId = balance.WalletId
+
+		if balance.WalletId == glossary.SystemWallet || helper.CompareStringBalance(balance.Balances, "0") <= 0 {
+			continue
+		}
+
+		holderBalance := entity.NewSnapshotBalance(ctx)
+		holderBalance.Id = helper.GenerateID(doc.SnapshotBalance, snapshotEntity.Id+balance.WalletId)
+		holderBalance.SnapshotId = snapshotEntity.Id
+		holderBalance.WalletId = balance.WalletId
+		holderBalance.Balance = balance.Balances
+		if err := s.Repo.Create(ctx, holderBalance, doc.SnapshotBalance, helper.SnapshotBalanceKey(snapshotEntity.Id, balance.WalletId)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Create balance of (%s) failed with error (%v)", balance.WalletId, err)
+			return helper.RespError(errorcode.BizUnableCreateSnapshot)
+		}
+
+		if snapshotEntity.TotalBalance, err = helper.AddBalance(snapshotEntity.TotalBalance, balance.Balances); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Sum balance failed with error (%v)", err)
+			return helper.RespError(errorcode.BizUnableCreateSnapshot)
+		}
+		snapshotEntity.Holders++
+	}
+
+	if len(balances) < int(glossary.PaginationSize) {
+		snapshotEntity.Status = snapshot.Completed
+	}
+
+	snapshotEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := s.Repo.Update(ctx, snapshotEntity, doc.Snapshot, helper.SnapshotKey(snapshotEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Update snapshot (%s) failed with error (%v)", snapshotEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateSnapshot)
+	}
+	return nil
+}
+
+// payPage write the payouts of the next page of holders in order of wallet id. The share of a holder is rounded down,
+// the remainder is paid to the remainder wallet and the distribution is completed when the page is not full.
+// The transaction id is derived from the distribution id and the payout number so a payout is never written twice.
+func (s *snapshotService) payPage(ctx contractapi.TransactionContextInterface, distributionEntity *entity.Distribution,
+	snapshotEntity *entity.Snapshot) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	balances, err := s.QueryDocumentsLimit(ctx, query.GetSnapshotBalanceQueryString(snapshotEntity.Id, distributionEntity.LastWalletId),
+		int(glossary.PaginationSize))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Query snapshot balances failed with error (%v)", err)
+		return err
+	}
+
+	for _, balanceData := range balances {
+		holderBalance := entity.NewSnapshotBalance()
+		if err := json.Unmarshal(balanceData, holderBalance); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Unmarshal snapshot balance failed with error (%v)", err)
+			return helper.RespError(errorcode.BizUnableMapDecode)
+		}
+		distributionEntity.LastWalletId = holderBalance.WalletId
+
+		share, err := helper.ProRataBalance(distributionEntity.TotalAmount, holderBalance.Balance, snapshotEntity.TotalBalance)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Calculate share of (%s) failed with error (%v)", holderBalance.WalletId, err)
+			return helper.RespError(errorcode.BizUnableUpdateDistribution)
+		}
+
+		if err := s.pay(ctx, distributionEntity, holderBalance.WalletId, share); err != nil {
+			return err
+		}
+	}
+
+	if len(balances) < int(glossary.PaginationSize) {
+		remainder, err := helper.SubBalance(distributionEntity.TotalAmount, distributionEntity.Distributed)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Snapshot Service - Calculate remainder failed with error (%v)", err)
+			return helper.RespError(errorcode.BizUnableUpdateDistribution)
+		}
+
+		if err := s.pay(ctx, distributionEntity, distributionEntity.RemainderWallet, remainder); err != nil {
+			return err
+		}
+		distributionEntity.Status = snapshot.Completed
+	}
+
+	distributionEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := s.Repo.Update(ctx, distributionEntity, doc.Distribution, helper.DistributionKey(distributionEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Update distribution (%s) failed with error (%v)", distributionEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateDistribution)
+	}
+	return nil
+}
+
+// pay write the pending payout of amount to the wallet, nothing is written for a zero amount
+func (s *snapshotService) pay(ctx contractapi.TransactionContextInterface, distributionEntity *entity.Distribution, walletId, amount string) error {
+	if helper.CompareStringBalance(amount, "0") <= 0 {
+		return nil
+	}
+
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.Id = helper.GenerateBatchID(doc.Transactions, distributionEntity.Id, distributionEntity.Paid)
+	txEntity.SpenderWallet = distributionEntity.FromWallet
+	txEntity.FromWallet = distributionEntity.FromWallet
+	txEntity.ToWallet = walletId
+	txEntity.FromTokenId = distributionEntity.PayoutTokenId
+	txEntity.ToTokenId = distributionEntity.PayoutTokenId
+	txEntity.FromTokenAmount = amount
+	txEntity.ToTokenAmount = amount
+	txEntity.TxType = transaction.ProRataDistribution
+	txEntity.Note = distributionEntity.Id
+
+	if err := s.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Create payout to (%s) failed with error (%v)", walletId, err)
+		return helper.RespError(errorcode.BizUnableCreateTX)
+	}
+
+	distributed, err := helper.AddBalance(distributionEntity.Distributed, amount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Sum distributed amount failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateDistribution)
+	}
+	distributionEntity.Distributed = distributed
+	distributionEntity.Paid++
+	return nil
+}
+
+func (s *snapshotService) getSnapshot(ctx contractapi.TransactionContextInterface, snapshotId string) (*entity.Snapshot, error) {
+	snapshotData, err := s.Repo.Get(ctx, doc.Snapshot, helper.SnapshotKey(snapshotId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Get snapshot (%s) failed with error (%v)", snapshotId, err)
+		return nil, helper.RespError(errorcode.BizUnableGetSnapshot)
+	}
+
+	snapshotEntity := entity.NewSnapshot()
+	if err = mapstructure.Decode(snapshotData, &snapshotEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Decode snapshot failed with error (%v)", err)
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return snapshotEntity, nil
+}
+
+func (s *snapshotService) getDistribution(ctx contractapi.TransactionContextInterface, distributionId string) (*entity.Distribution, error) {
+	distributionData, err := s.Repo.Get(ctx, doc.Distribution, helper.DistributionKey(distributionId))
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Get distribution (%s) failed with error (%v)", distributionId, err)
+		return nil, helper.RespError(errorcode.BizUnableGetDistribution)
+	}
+
+	distributionEntity := entity.NewDistribution()
+	if err = mapstructure.Decode(distributionData, &distributionEntity); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Snapshot Service - Decode distribution failed with error (%v)", err)
+		return nil, helper.RespError(errorcode.BizUnableMapDecode)
+	}
+	return distributionEntity, nil
+}
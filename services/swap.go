@@ -0,0 +1,40 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Swap is a two-party token swap that needs the consent of both wallets.
+// Amount of each side is held in escrow and is settled by accounting job through Exchange transaction.
+type Swap interface {
+	// ProposeSwap to propose a swap to the taker wallet, amount of the maker is held until the swap is accepted or canceled
+	ProposeSwap(ctx contractapi.TransactionContextInterface, makerWalletId, takerWalletId, makerTokenId, takerTokenId,
+		makerAmount, takerAmount string, expiry int64) (string, error)
+
+	// AcceptSwap the taker accepts an open swap. It returns id of the exchange transaction
+	AcceptSwap(ctx contractapi.TransactionContextInterface, swapId, takerWalletId string) (string, error)
+
+	// CancelSwap to cancel an open swap and release the held amount of the maker.
+	// The maker can cancel at any time, the other wallet only when the swap has expired
+	CancelSwap(ctx contractapi.TransactionContextInterface, swapId, walletId string) error
+
+	// GetSwap return the swap
+	GetSwap(ctx contractapi.TransactionContextInterface, swapId string) (string, error)
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package swap
+
+import (
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/swap"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type swapService struct {
+	*base.Base
+}
+
+func NewSwapService() services.Swap {
+	return &swapService{
+		base.NewBase(),
+	}
+}
+
+func (s *swapService) ProposeSwap(ctx contractapi.TransactionContextInterface, makerWalletId, takerWalletId, makerTokenId,
+	takerTokenId, makerAmount, takerAmount string, expiry int64) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Service - ProposeSwap-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	makerWallet, _, err := s.ValidatePairWallet(ctx, makerWalletId, takerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposeSwap - Validation pair wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := s.RejectMultiSig(ctx, makerWallet); err != nil {
+		return "", err
+	}
+
+	if _, err := s.GetTokenType(ctx, makerTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposeSwap - Get maker token failed with error (%v)", err)
+		return "", err
+	}
+
+	if _, err := s.GetTokenType(ctx, takerTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposeSwap - Get taker token failed with error (%v)", err)
+		return "", err
+	}
+
+	if helper.IsExpired(expiry, txTime.Seconds) {
+		glogger.GetInstance().Errorf(ctx, "ProposeSwap - Expiry (%d) has passed", expiry)
+		return "", helper.RespError(errorcode.BizSwapExpired)
+	}
+
+	if err := s.hold(ctx, makerWalletId, makerTokenId, makerAmount); err != nil {
+		return "", err
+	}
+
+	swapEntity := entity.NewSwap(ctx)
+	swapEntity.MakerWalletId = makerWalletId
+	swapEntity.TakerWalletId = takerWalletId
+	swapEntity.MakerTokenId = makerTokenId
+	swapEntity.TakerTokenId = takerTokenId
+	swapEntity.MakerAmount = makerAmount
+	swapEntity.TakerAmount = takerAmount
+	swapEntity.Expiry = expiry
+
+	if err := s.Repo.Create(ctx, swapEntity, doc.Swap, helper.SwapKey(swapEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "ProposeSwap - Create swap failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateSwap)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Swap Service - ProposeSwap succeed (%s)-----------", swapEntity.Id)
+
+	return swapEntity.Id, nil
+}
+
+func (s *swapService) AcceptSwap(ctx contractapi.TransactionContextInterface, swapId, takerWalletId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Service - AcceptSwap-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	swapEntity, err := s.getOpenSwap(ctx, swapId)
+	if err != nil {
+		return "", err
+	}
+
+	if swapEntity.TakerWalletId != takerWalletId {
+		glogger.GetInstance().Error(ctx, "AcceptSwap - Taker wallet not match taker of swap")
+		return "", helper.RespError(errorcode.BizSwapNotPermission)
+	}
+
+	if helper.IsExpired(swapEntity.Expiry, txTime.Seconds) {
+		glogger.GetInstance().Errorf(ctx, "AcceptSwap - Swap (%s) has expired", swapId)
+		return "", helper.RespError(errorcode.BizSwapExpired)
+	}
+
+	_, takerWallet, err := s.ValidatePairWallet(ctx, swapEntity.MakerWalletId, takerWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "AcceptSwap - Validation pair wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := s.RejectMultiSig(ctx, takerWallet); err != nil {
+		return "", err
+	}
+
+	if err := s.hold(ctx, takerWalletId, swapEntity.TakerTokenId, swapEntity.TakerAmount); err != nil {
+		return "", err
+	}
+
+	// create tx exchange settled from the held amount of both wallets
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = takerWalletId
+	txEntity.FromWallet = swapEntity.MakerWalletId
+	txEntity.ToWallet = takerWalletId
+	txEntity.FromTokenId = swapEntity.MakerTokenId
+	txEntity.ToTokenId = swapEntity.TakerTokenId
+	txEntity.FromTokenAmount = swapEntity.MakerAmount
+	txEntity.ToTokenAmount = swapEntity.TakerAmount
+	txEntity.TxType = transaction.Exchange
+	txEntity.Note = swapEntity.Id
+
+	if err := s.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "AcceptSwap - Create exchange transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+
+	swapEntity.TxId = txEntity.Id
+	swapEntity.Status = swap.Accepted
+	if err := s.updateSwap(ctx, swapEntity); err != nil {
+		return "", err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Swap Service - AcceptSwap succeed (%s)-----------", txEntity.Id)
+
+	return txEntity.Id, nil
+}
+
+func (s *swapService) CancelSwap(ctx contractapi.TransactionContextInterface, swapId, walletId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Swap Service - CancelSwap-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	swapEntity, err := s.getOpenSwap(ctx, swapId)
+	if err != nil {
+		return err
+	}
+
+	isExpired := helper.IsExpired(swapEntity.Expiry, txTime.Seconds)
+	if swapEntity.MakerWalletId != walletId && !isExpired {
+		glogger.GetInstance().Error(ctx, "CancelSwap - Wallet not match maker of swap")
+		return helper.RespError(errorcode.BizSwapNotPermission)
+	}
+
+	// release the held amount to the maker
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := s.ReleaseHold(ctx, balanceMap, swapEntity.MakerWalletId, swapEntity.MakerTokenId, swapEntity.MakerAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelSwap - Release hold of swap failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableReleaseBalance)
+	}
+	if err := s.UpdateBalance(ctx, balanceMap); err != nil {
+		return err
+	}
+
+	swapEntity.Status = swap.Canceled
+	if isExpired {
+		swapEntity.Status = swap.Expired
+	}
+	if err := s.updateSwap(ctx, swapEntity); err != nil {
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Swap Service - CancelSwap succeed (%s)-----------", swapEntity.Id)
+
+	return nil
+}
+
+func (s *swapService) GetSwap(ctx contractapi.TransactionContextInterface, swapId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Swap Service - GetSwap-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	swapEntity, err := s.Base.GetSwap(ctx, swapId)
+	if err != nil {
+		return "", err
+	}
+
+	// an open swap past its expiry can not be accepted anymore, its held amount is waiting to be released
+	if swapEntity.Status == swap.Open && helper.IsExpired(swapEntity.Expiry, txTime.Seconds) {
+		swapEntity.Status = swap.Expired
+	}
+
+	return helper.MarshalStruct(swapEntity), nil
+}
+
+func (s *swapService) getOpenSwap(ctx contractapi.TransactionContextInterface, swapId string) (*entity.Swap, error) {
+	swapEntity, err := s.Base.GetSwap(ctx, swapId)
+	if err != nil {
+		return nil, err
+	}
+
+	if swapEntity.Status != swap.Open {
+		glogger.GetInstance().Errorf(ctx, "Swap Service - Swap (%s) has status (%s)", swapId, swapEntity.Status)
+		return nil, helper.RespError(errorcode.BizSwapInvalidStatus)
+	}
+	return swapEntity, nil
+}
+
+func (s *swapService) hold(ctx contractapi.TransactionContextInterface, walletId, tokenId, amount string) error {
+	balanceMap := make(map[string]*entity.BalanceCache, 2)
+	if err := s.HoldAmount(ctx, balanceMap, walletId, tokenId, amount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Swap Service - Hold amount of wallet (%s) failed with error (%v)", walletId, err)
+		return helper.RespError(errorcode.BizUnableHoldBalance)
+	}
+	return s.UpdateBalance(ctx, balanceMap)
+}
+
+func (s *swapService) updateSwap(ctx contractapi.TransactionContextInterface, swapEntity *entity.Swap) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	swapEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := s.Repo.Update(ctx, swapEntity, doc.Swap, helper.SwapKey(swapEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Swap Service - Update swap (%s) failed with error (%v)", swapEntity.Id, err)
+		return helper.RespError(errorcode.BizUnableUpdateSwap)
+	}
+	return nil
+}
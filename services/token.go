@@ -20,6 +20,7 @@
 package services
 
 import (
+	"github.com/Akachain/gringotts/dto/token"
 	"github.com/Akachain/gringotts/glossary/sidechain"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
@@ -27,8 +28,8 @@ import (
 type Token interface {
 	// Transfer to transfer token between wallet.
 	// But state balance of wallet not update at the time.
-	// It will be update when accounting job start
-	Transfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount string) (string, error)
+	// It will be update when accounting job start. The transaction is expired when it is not settled before expiry
+	Transfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount string, expiry int64) (string, error)
 
 	// TransferWithNote same with Transfer function but add note in the transaction
 	TransferWithNote(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount, note string) (string, error)
@@ -39,14 +40,48 @@ type Token interface {
 	// Mint to init token in the system
 	Mint(ctx contractapi.TransactionContextInterface, walletId, tokenId, amount string) error
 
+	// TransferBatch to transfer token from a wallet to every item of the batch, one pending transaction per item.
+	// It return the status of every item, an item failing validation is rejected without failing the batch
+	TransferBatch(ctx contractapi.TransactionContextInterface, fromWalletId, tokenId string, items []token.BatchItem) (string, error)
+
+	// MintBatch to mint token to every item of the batch, one pending transaction per item.
+	// It return the status of every item, an item failing validation is rejected without failing the batch
+	MintBatch(ctx contractapi.TransactionContextInterface, tokenId string, items []token.BatchItem) (string, error)
+
 	// Burn to delete token in the system
 	Burn(ctx contractapi.TransactionContextInterface, walletId, tokenId, amount string) error
 
 	// CreateType to create new token type in the system.
 	CreateType(ctx contractapi.TransactionContextInterface, name, tickerToken, maxSupply string) (string, error)
 
-	// Exchange to swap between token type.
-	Exchange(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, fromTokenId, toTokenId, fromTokenAmount, toTokenAmount string) error
+	// CreateManagedType to create new token type whose supply is only changed by its manager (vault or pool)
+	CreateManagedType(ctx contractapi.TransactionContextInterface, name, tickerToken, maxSupply, manager string) (string, error)
+
+	// Exchange to swap between token type. The transaction is expired when it is not settled before expiry
+	Exchange(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, fromTokenId, toTokenId, fromTokenAmount, toTokenAmount string, expiry int64) error
+
+	// CancelTransaction to cancel a pending transaction of the spender wallet and release its held amount.
+	// Hashed time-locked claims and refunds, marketplace settlements and accepted swaps can not be canceled
+	CancelTransaction(ctx contractapi.TransactionContextInterface, walletId, txId string) error
+
+	// Reverse to pay back amount of a confirmed transfer from its receiver to its sender, the whole remaining amount when empty.
+	// Only the receiver wallet or admin is allowed
+	Reverse(ctx contractapi.TransactionContextInterface, txId, walletId, amount string) (string, error)
+
+	// PauseToken to block all transactions of token until it is unpaused. Only admin is allowed
+	PauseToken(ctx contractapi.TransactionContextInterface, tokenId string) error
+
+	// UnpauseToken to allow transactions of a paused token again. Only admin is allowed
+	UnpauseToken(ctx contractapi.TransactionContextInterface, tokenId string) error
+
+	// DeactivateToken to block all transactions of token for good. Only admin is allowed
+	DeactivateToken(ctx contractapi.TransactionContextInterface, tokenId string) error
+
+	// FreezeBalance to block transactions of token of a wallet with a reason. Only admin is allowed
+	FreezeBalance(ctx contractapi.TransactionContextInterface, walletId, tokenId, reason string) error
+
+	// UnfreezeBalance to lift the freeze of balance of token of a wallet. Only admin is allowed
+	UnfreezeBalance(ctx contractapi.TransactionContextInterface, walletId, tokenId string) error
 
 	// Issue to issue new token type from stable token.
 	Issue(ctx contractapi.TransactionContextInterface, walletId, fromTokenId, toTokenId, fromTokenAmount, toTokenAmount string) error
@@ -20,6 +20,7 @@
 package token
 
 import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
 	"github.com/Akachain/gringotts/entity"
 	"github.com/Akachain/gringotts/errorcode"
 	"github.com/Akachain/gringotts/glossary"
@@ -28,9 +29,9 @@ import (
 	"github.com/Akachain/gringotts/glossary/transaction"
 	"github.com/Akachain/gringotts/helper"
 	"github.com/Akachain/gringotts/helper/glogger"
+	txHandler "github.com/Akachain/gringotts/pkg/tx"
 	"github.com/Akachain/gringotts/services/base"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
-	"strings"
 )
 
 type tokenService struct {
@@ -46,12 +47,12 @@ func NewTokenService() *tokenService {
 func (t *tokenService) TransferWithNote(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId,
 	amount, note string) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------Token Service - TransferWithNote-----------")
-	return t.transferToken(ctx, fromWalletId, toWalletId, tokenId, amount, note)
+	return t.transferToken(ctx, fromWalletId, toWalletId, tokenId, amount, note, 0)
 }
 
-func (t *tokenService) Transfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount string) (string, error) {
+func (t *tokenService) Transfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount string, expiry int64) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------Token Service - Transfer-----------")
-	return t.transferToken(ctx, fromWalletId, toWalletId, tokenId, amount, "")
+	return t.transferToken(ctx, fromWalletId, toWalletId, tokenId, amount, "", expiry)
 }
 
 func (t *tokenService) TransferSideChain(ctx contractapi.TransactionContextInterface, walletId, tokenId string,
@@ -63,6 +64,11 @@ func (t *tokenService) TransferSideChain(ctx contractapi.TransactionContextInter
 		return err
 	}
 
+	if err := t.ValidateTokenControl(ctx, walletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TransferSideChain - Validation token control failed with error (%v)", err)
+		return err
+	}
+
 	note := fromChain + "_" + toChain
 	// create new transfer transaction
 	txEntity := entity.NewTransaction(ctx)
@@ -76,11 +82,13 @@ func (t *tokenService) TransferSideChain(ctx contractapi.TransactionContextInter
 	txEntity.TxType = transaction.SideChainTransfer
 	txEntity.Note = string(note)
 
-	if err := t.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+	// transaction from a multi-signature wallet waits for signatures of its owners
+	txId, err := t.SubmitTransaction(ctx, txEntity)
+	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "TransferSideChain - Create transfer transaction failed with error (%v)", err)
-		return helper.RespError(errorcode.BizUnableCreateTX)
+		return err
 	}
-	glogger.GetInstance().Infof(ctx, "-----------Token Service - TransferSideChain succeed (%s)-----------", txEntity.Id)
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - TransferSideChain succeed (%s)-----------", txId)
 
 	return nil
 }
@@ -94,6 +102,21 @@ func (t *tokenService) Mint(ctx contractapi.TransactionContextInterface, walletI
 		return err
 	}
 
+	if err := t.ValidateTokenControl(ctx, walletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Mint - Validation token control failed with error (%v)", err)
+		return err
+	}
+
+	if err := t.ValidateIssuable(ctx, tokenId); err != nil {
+		return err
+	}
+
+	// check enrollment policy, new token is minted by the system
+	if err := t.ApplyIssuancePolicy(ctx, tokenId, "", walletId, amount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Mint - Check issuance policy failed with error (%v)", err)
+		return err
+	}
+
 	// check total supply with max supply
 
 	// create tx mint token
@@ -116,6 +139,138 @@ func (t *tokenService) Mint(ctx contractapi.TransactionContextInterface, walletI
 	return nil
 }
 
+func (t *tokenService) TransferBatch(ctx contractapi.TransactionContextInterface, fromWalletId, tokenId string,
+	items []tokenDto.BatchItem) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - TransferBatch-----------")
+	walletMap := make(map[string]*entity.Wallet)
+
+	walletFrom, err := t.GetCachedActiveWallet(ctx, walletMap, fromWalletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "TransferBatch - Get from wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	// the items are written without intents, so they would bypass the threshold of a multi-signature wallet
+	if walletFrom.Threshold > 0 {
+		glogger.GetInstance().Errorf(ctx, "TransferBatch - Wallet (%s) is a multi-signature wallet", fromWalletId)
+		return "", helper.RespError(errorcode.BizBatchMultiSig)
+	}
+
+	if err := t.ValidateTokenControl(ctx, fromWalletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "TransferBatch - Validation control of from wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	results := make([]tokenDto.BatchItemResult, len(items))
+	for index, item := range items {
+		results[index] = tokenDto.BatchItemResult{WalletId: item.WalletId, Amount: item.Amount, Status: transaction.Rejected}
+
+		if _, err := t.GetCachedActiveWallet(ctx, walletMap, item.WalletId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TransferBatch - Get to wallet of item (%d) failed with error (%v)", index, err)
+			results[index].Error = err.Error()
+			continue
+		}
+
+		if err := t.ValidateTokenControl(ctx, item.WalletId, tokenId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TransferBatch - Validation control of item (%d) failed with error (%v)", index, err)
+			results[index].Error = err.Error()
+			continue
+		}
+
+		txEntity := entity.NewTransaction(ctx)
+		txEntity.Id = helper.GenerateBatchID(doc.Transactions, ctx.GetStub().GetTxID(), index)
+		txEntity.SpenderWallet = fromWalletId
+		txEntity.FromWallet = fromWalletId
+		txEntity.ToWallet = item.WalletId
+		txEntity.FromTokenId = tokenId
+		txEntity.ToTokenId = tokenId
+		txEntity.FromTokenAmount = item.Amount
+		txEntity.ToTokenAmount = item.Amount
+		txEntity.TxType = transaction.Transfer
+
+		if err := t.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "TransferBatch - Create transfer transaction of item (%d) failed with error (%v)", index, err)
+			return "", helper.RespError(errorcode.BizUnableCreateTX)
+		}
+		results[index].TxId = txEntity.Id
+		results[index].Status = transaction.Pending
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - TransferBatch succeed (%d)-----------", len(items))
+
+	return helper.MarshalStruct(results), nil
+}
+
+func (t *tokenService) MintBatch(ctx contractapi.TransactionContextInterface, tokenId string, items []tokenDto.BatchItem) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - MintBatch-----------")
+	walletMap := make(map[string]*entity.Wallet)
+
+	if err := t.ValidateIssuable(ctx, tokenId); err != nil {
+		return "", err
+	}
+
+	// every item is checked against the same enrollment, which is written once with the issued amounts
+	enrollment, hasPolicy, err := t.GetAndCheckExistEnrollment(ctx, tokenId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "MintBatch - Get enrollment failed with error (%v)", err)
+		return "", err
+	}
+	isRecorded := false
+
+	results := make([]tokenDto.BatchItemResult, len(items))
+	for index, item := range items {
+		results[index] = tokenDto.BatchItemResult{WalletId: item.WalletId, Amount: item.Amount, Status: transaction.Rejected}
+
+		if _, err := t.GetCachedActiveWallet(ctx, walletMap, item.WalletId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "MintBatch - Get wallet of item (%d) failed with error (%v)", index, err)
+			results[index].Error = err.Error()
+			continue
+		}
+
+		if err := t.ValidateTokenControl(ctx, item.WalletId, tokenId); err != nil {
+			glogger.GetInstance().Errorf(ctx, "MintBatch - Validation token control of item (%d) failed with error (%v)", index, err)
+			results[index].Error = err.Error()
+			continue
+		}
+
+		if hasPolicy {
+			recorded, err := t.CheckIssuancePolicy(ctx, enrollment, "", item.WalletId, item.Amount)
+			if err != nil {
+				glogger.GetInstance().Errorf(ctx, "MintBatch - Check issuance policy of item (%d) failed with error (%v)", index, err)
+				results[index].Error = err.Error()
+				continue
+			}
+			isRecorded = isRecorded || recorded
+		}
+
+		txMint := entity.NewTransaction(ctx)
+		txMint.Id = helper.GenerateBatchID(doc.Transactions, ctx.GetStub().GetTxID(), index)
+		txMint.SpenderWallet = item.WalletId
+		txMint.FromWallet = glossary.SystemWallet
+		txMint.ToWallet = item.WalletId
+		txMint.FromTokenId = tokenId
+		txMint.ToTokenId = tokenId
+		txMint.FromTokenAmount = item.Amount
+		txMint.ToTokenAmount = item.Amount
+		txMint.TxType = transaction.Mint
+
+		if err := t.Repo.Create(ctx, txMint, doc.Transactions, helper.TransactionKey(txMint.Id)); err != nil {
+			glogger.GetInstance().Errorf(ctx, "MintBatch - Create mint transaction of item (%d) failed with error (%v)", index, err)
+			return "", helper.RespError(errorcode.BizUnableCreateTX)
+		}
+		results[index].TxId = txMint.Id
+		results[index].Status = transaction.Pending
+	}
+
+	if isRecorded {
+		if err := t.UpdateEnrollment(ctx, enrollment); err != nil {
+			return "", err
+		}
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - MintBatch succeed (%d)-----------", len(items))
+
+	return helper.MarshalStruct(results), nil
+}
+
 func (t *tokenService) Burn(ctx contractapi.TransactionContextInterface, walletId, tokenId, amount string) error {
 	glogger.GetInstance().Info(ctx, "-----------Token Service - Burn-----------")
 
@@ -126,6 +281,11 @@ func (t *tokenService) Burn(ctx contractapi.TransactionContextInterface, walletI
 		return err
 	}
 
+	if err := t.ValidateTokenControl(ctx, walletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Burn - Validation token control failed with error (%v)", err)
+		return err
+	}
+
 	// get balance of token
 	//balanceToken, err := t.GetBalanceOfToken(ctx, doc.SpotBalances, wallet.Id, tokenId)
 	//if err != nil {
@@ -150,22 +310,29 @@ func (t *tokenService) Burn(ctx contractapi.TransactionContextInterface, walletI
 	txBurn.ToTokenAmount = amount
 	txBurn.TxType = transaction.Burn
 
-	if err := t.Repo.Create(ctx, txBurn, doc.Transactions, helper.TransactionKey(txBurn.Id)); err != nil {
+	// transaction from a multi-signature wallet waits for signatures of its owners
+	txId, err := t.SubmitTransaction(ctx, txBurn)
+	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "Burn - Create burn transaction failed with error (%v)", err)
-		return helper.RespError(errorcode.BizUnableCreateTX)
+		return err
 	}
-	glogger.GetInstance().Infof(ctx, "-----------Token Service - Burn succeed (%s)-----------", txBurn.Id)
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - Burn succeed (%s)-----------", txId)
 
 	return nil
 }
 
 func (t *tokenService) CreateType(ctx contractapi.TransactionContextInterface, name, tickerToken, maxSupply string) (string, error) {
+	return t.CreateManagedType(ctx, name, tickerToken, maxSupply, "")
+}
+
+func (t *tokenService) CreateManagedType(ctx contractapi.TransactionContextInterface, name, tickerToken, maxSupply, manager string) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------Token Service - CreateType-----------")
 
 	tokenEntity := entity.NewToken(ctx)
 	tokenEntity.Name = name
 	tokenEntity.TickerToken = tickerToken
 	tokenEntity.MaxSupply = maxSupply
+	tokenEntity.Manager = manager
 
 	if err := t.Repo.Create(ctx, tokenEntity, doc.Tokens, helper.TokenKey(tokenEntity.Id)); err != nil {
 		glogger.GetInstance().Errorf(ctx, "CreateType - Create token type failed with error (%s)", err.Error())
@@ -177,7 +344,7 @@ func (t *tokenService) CreateType(ctx contractapi.TransactionContextInterface, n
 }
 
 func (t *tokenService) Exchange(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, fromTokenId,
-	toTokenId, fromTokenAmount, toTokenAmount string) error {
+	toTokenId, fromTokenAmount, toTokenAmount string, expiry int64) error {
 	glogger.GetInstance().Info(ctx, "-----------Token Service - Exchange-----------")
 
 	// validate from wallet and to wallet have active or not
@@ -186,7 +353,15 @@ func (t *tokenService) Exchange(ctx contractapi.TransactionContextInterface, fro
 		return err
 	}
 
-	// TODO: validate token
+	if err := t.ValidateTokenControl(ctx, fromWalletId, fromTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange - Validation control of from token failed with error (%v)", err)
+		return err
+	}
+
+	if err := t.ValidateTokenControl(ctx, toWalletId, toTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Exchange - Validation control of to token failed with error (%v)", err)
+		return err
+	}
 
 	// create new swap transaction
 	txEntity := entity.NewTransaction(ctx)
@@ -198,12 +373,15 @@ func (t *tokenService) Exchange(ctx contractapi.TransactionContextInterface, fro
 	txEntity.FromTokenAmount = fromTokenAmount
 	txEntity.ToTokenAmount = toTokenAmount
 	txEntity.TxType = transaction.Exchange
+	txEntity.Expiry = expiry
 
-	if err := t.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+	// transaction from a multi-signature wallet waits for signatures of its owners
+	txId, err := t.SubmitTransaction(ctx, txEntity)
+	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "Exchange - Create exchange transaction failed with error (%v)", err)
-		return helper.RespError(errorcode.BizUnableCreateTX)
+		return err
 	}
-	glogger.GetInstance().Infof(ctx, "-----------Token Service - Exchange succeed (%s)-----------", txEntity.Id)
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - Exchange succeed (%s)-----------", txId)
 
 	return nil
 }
@@ -218,26 +396,28 @@ func (t *tokenService) Issue(ctx contractapi.TransactionContextInterface, wallet
 		return err
 	}
 
-	// check enrollment policy
-	enrollment, isExisted, err := t.GetAndCheckExistEnrollment(ctx, toTokenId)
-	if err != nil {
-		glogger.GetInstance().Errorf(ctx, "Issue - Check exit enrollment failed with err (%s)", err.Error())
-		return helper.RespError(errorcode.BizUnableGetEnrollment)
-	}
-	if isExisted {
-		// check permission to issue new token
-		if enrollment.FromWalletId != "" {
-			if !strings.Contains(enrollment.FromWalletId, wallet.Id) {
-				glogger.GetInstance().Errorf(ctx, "Issue - From wallet do not have permission issue token (%s)", toTokenId)
-				return helper.RespError(errorcode.BizIssueNotPermission)
-			}
-		}
-		if enrollment.ToWalletId != "" {
-			if !strings.Contains(enrollment.ToWalletId, wallet.Id) {
-				glogger.GetInstance().Errorf(ctx, "Issue - To wallet do not have permission issue token (%s)", toTokenId)
-				return helper.RespError(errorcode.BizIssueNotPermission)
-			}
-		}
+	if err := t.RejectMultiSig(ctx, wallet); err != nil {
+		return err
+	}
+
+	if err := t.ValidateTokenControl(ctx, wallet.Id, fromTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Issue - Validation control of from token failed with err (%v)", err)
+		return err
+	}
+
+	if err := t.ValidateTokenControl(ctx, wallet.Id, toTokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Issue - Validation control of to token failed with err (%v)", err)
+		return err
+	}
+
+	if err := t.ValidateIssuable(ctx, toTokenId); err != nil {
+		return err
+	}
+
+	// check enrollment policy, the wallet issues new token to itself
+	if err := t.ApplyIssuancePolicy(ctx, toTokenId, wallet.Id, wallet.Id, toTokenAmount); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Issue - Check issuance policy failed with err (%v)", err)
+		return err
 	}
 
 	// validate total supply of AT token
@@ -280,6 +460,295 @@ func (t *tokenService) Issue(ctx contractapi.TransactionContextInterface, wallet
 	return nil
 }
 
+func (t *tokenService) PauseToken(ctx contractapi.TransactionContextInterface, tokenId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - PauseToken-----------")
+	return t.updateTokenStatus(ctx, tokenId, glossary.Active, glossary.Paused)
+}
+
+func (t *tokenService) UnpauseToken(ctx contractapi.TransactionContextInterface, tokenId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - UnpauseToken-----------")
+	return t.updateTokenStatus(ctx, tokenId, glossary.Paused, glossary.Active)
+}
+
+func (t *tokenService) DeactivateToken(ctx contractapi.TransactionContextInterface, tokenId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - DeactivateToken-----------")
+
+	tokenEntity, err := t.GetTokenType(ctx, tokenId)
+	if err != nil {
+		return err
+	}
+
+	// a deactivated token can not be activated again
+	if tokenEntity.Status == glossary.InActive {
+		glogger.GetInstance().Errorf(ctx, "DeactivateToken - Token (%s) has been deactivated", tokenId)
+		return helper.RespError(errorcode.BizTokenInvalidStatus)
+	}
+	return t.updateTokenStatus(ctx, tokenId, tokenEntity.Status, glossary.InActive)
+}
+
+func (t *tokenService) FreezeBalance(ctx contractapi.TransactionContextInterface, walletId, tokenId, reason string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - FreezeBalance-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "FreezeBalance - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	if _, err := t.GetWallet(ctx, walletId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FreezeBalance - Get wallet failed with error (%v)", err)
+		return err
+	}
+
+	if _, err := t.GetTokenType(ctx, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FreezeBalance - Get token failed with error (%v)", err)
+		return err
+	}
+
+	freeze, isExisted, err := t.GetAndCheckExistFreeze(ctx, walletId, tokenId)
+	if err != nil {
+		return err
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	if !isExisted {
+		freeze = entity.NewFreeze(ctx)
+		freeze.WalletId = walletId
+		freeze.TokenId = tokenId
+	}
+	freeze.Reason = reason
+	freeze.Status = glossary.Active
+	freeze.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+
+	if err := t.Repo.Update(ctx, freeze, doc.Freeze, helper.FreezeKey(walletId, tokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "FreezeBalance - Update freeze failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateFreeze)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - FreezeBalance succeed (%s)-----------", freeze.Id)
+
+	return nil
+}
+
+func (t *tokenService) UnfreezeBalance(ctx contractapi.TransactionContextInterface, walletId, tokenId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - UnfreezeBalance-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "UnfreezeBalance - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	freeze, isExisted, err := t.GetAndCheckExistFreeze(ctx, walletId, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if !isExisted || freeze.Status != glossary.Active {
+		glogger.GetInstance().Errorf(ctx, "UnfreezeBalance - Balance of token (%s) of wallet (%s) is not frozen", tokenId, walletId)
+		return helper.RespError(errorcode.BizUnableGetFreeze)
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	freeze.Status = glossary.InActive
+	freeze.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+
+	if err := t.Repo.Update(ctx, freeze, doc.Freeze, helper.FreezeKey(walletId, tokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "UnfreezeBalance - Update freeze failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateFreeze)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - UnfreezeBalance succeed (%s)-----------", freeze.Id)
+
+	return nil
+}
+
+// updateTokenStatus change the status of token from status to the new status, only admin is allowed
+func (t *tokenService) updateTokenStatus(ctx contractapi.TransactionContextInterface, tokenId string, from, to glossary.Status) error {
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "UpdateTokenStatus - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	tokenEntity, err := t.GetTokenType(ctx, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if tokenEntity.Status != from {
+		glogger.GetInstance().Errorf(ctx, "UpdateTokenStatus - Token (%s) has status (%s)", tokenId, tokenEntity.Status)
+		return helper.RespError(errorcode.BizTokenInvalidStatus)
+	}
+
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	tokenEntity.Status = to
+	tokenEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+
+	if err := t.Repo.Update(ctx, tokenEntity, doc.Tokens, helper.TokenKey(tokenEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "UpdateTokenStatus - Update token (%s) failed with error (%v)", tokenId, err)
+		return helper.RespError(errorcode.BizUnableUpdateToken)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - UpdateTokenStatus succeed (%s: %s)-----------", tokenId, to)
+
+	return nil
+}
+
+func (t *tokenService) CancelTransaction(ctx contractapi.TransactionContextInterface, walletId, txId string) error {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - CancelTransaction-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	txEntity, err := t.GetTransaction(ctx, txId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Get transaction failed with error (%v)", err)
+		return err
+	}
+
+	if txEntity.Status != transaction.Pending {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Transaction (%s) has status (%s)", txId, txEntity.Status)
+		return helper.RespError(errorcode.BizTransactionNotPending)
+	}
+
+	// the claim of a hashed time-locked transfer reveals the preimage, its settlement must not be undone
+	if txEntity.TxType == transaction.HtlcClaim || txEntity.TxType == transaction.HtlcRefund {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Transaction (%s) of type (%s) can not be canceled", txId, txEntity.TxType)
+		return helper.RespError(errorcode.BizTransactionNotCancelable)
+	}
+
+	// a marketplace settlement or an accepted swap is agreed by both parties and closes its listing, offer or swap,
+	// one party can not take it back
+	if txEntity.TxType == transaction.NftSettlement || (txEntity.TxType == transaction.Exchange && txEntity.Note != "") {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Transaction (%s) of type (%s) can not be canceled", txId, txEntity.TxType)
+		return helper.RespError(errorcode.BizTransactionNotCancelable)
+	}
+
+	if txEntity.SpenderWallet != walletId {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Wallet (%s) is not the spender of transaction (%s)", walletId, txId)
+		return helper.RespError(errorcode.BizNotSpender)
+	}
+
+	// only owners can cancel a transaction of a multi-signature wallet
+	wallet, err := t.GetWallet(ctx, walletId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Get wallet failed with error (%v)", err)
+		return err
+	}
+	if wallet.Threshold > 0 {
+		callerId, err := helper.GetCallerId(ctx)
+		if err != nil || !helper.ArrayContains(wallet.Owners, callerId) {
+			glogger.GetInstance().Errorf(ctx, "CancelTransaction - Caller is not an owner of wallet (%s)", walletId)
+			return helper.RespError(errorcode.BizIntentNotOwner)
+		}
+	}
+
+	mapCurrentBalance := make(map[string]*entity.BalanceCache)
+	if err := txHandler.ReleaseTx(ctx, txEntity, mapCurrentBalance); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Release transaction failed with error (%v)", err)
+		return err
+	}
+
+	txEntity.Status = transaction.Canceled
+	txEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Update transaction failed with error (%v)", err)
+		return helper.RespError(errorcode.BizUnableUpdateTX)
+	}
+
+	if err := t.UpdateBalance(ctx, mapCurrentBalance); err != nil {
+		glogger.GetInstance().Errorf(ctx, "CancelTransaction - Update balance failed with error (%v)", err)
+		return err
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - CancelTransaction succeed (%s)-----------", txId)
+
+	return nil
+}
+
+func (t *tokenService) Reverse(ctx contractapi.TransactionContextInterface, txId, walletId, amount string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Token Service - Reverse-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	original, err := t.GetTransaction(ctx, txId)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Get transaction failed with error (%v)", err)
+		return "", err
+	}
+
+	if original.TxType != transaction.Transfer || original.Status != transaction.Confirmed {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Transaction (%s) of type (%s) has status (%s)", txId, original.TxType, original.Status)
+		return "", helper.RespError(errorcode.BizTransactionNotReversible)
+	}
+
+	// the receiver takes back the transfer, admin is allowed to charge back any transfer
+	if !helper.IsAdmin(ctx) {
+		if original.ToWallet != walletId {
+			glogger.GetInstance().Errorf(ctx, "Reverse - Wallet (%s) is not the receiver of transaction (%s)", walletId, txId)
+			return "", helper.RespError(errorcode.BizNotReceiver)
+		}
+
+		receiver, err := t.GetWallet(ctx, walletId)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Reverse - Get receiver wallet failed with error (%v)", err)
+			return "", err
+		}
+		if receiver.Threshold > 0 {
+			callerId, err := helper.GetCallerId(ctx)
+			if err != nil || !helper.ArrayContains(receiver.Owners, callerId) {
+				glogger.GetInstance().Errorf(ctx, "Reverse - Caller is not an owner of wallet (%s)", walletId)
+				return "", helper.RespError(errorcode.BizIntentNotOwner)
+			}
+		}
+	}
+
+	// a transfer which has never been reversed has no reversed amount
+	reversedAmount := original.ReversedAmount
+	if reversedAmount == "" {
+		reversedAmount = "0"
+	}
+
+	remaining, err := helper.SubBalance(original.ToTokenAmount, reversedAmount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Calculate remaining amount failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizReverseOverAmount)
+	}
+
+	if amount == "" {
+		amount = remaining
+	}
+
+	if helper.CompareStringBalance(amount, "0") <= 0 || helper.CompareStringBalance(amount, remaining) > 0 {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Amount (%s) is over the remaining amount (%s)", amount, remaining)
+		return "", helper.RespError(errorcode.BizReverseOverAmount)
+	}
+
+	// the amount is reserved on the original transfer until the reversal is settled
+	reversedAmount, err = helper.AddBalance(reversedAmount, amount)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Calculate reversed amount failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizReverseOverAmount)
+	}
+
+	original.ReversedAmount = reversedAmount
+	original.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := t.Repo.Update(ctx, original, doc.Transactions, helper.TransactionKey(original.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Update original transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableUpdateTX)
+	}
+
+	txEntity := entity.NewTransaction(ctx)
+	txEntity.SpenderWallet = original.ToWallet
+	txEntity.FromWallet = original.ToWallet
+	txEntity.ToWallet = original.FromWallet
+	txEntity.FromTokenId = original.ToTokenId
+	txEntity.ToTokenId = original.FromTokenId
+	txEntity.FromTokenAmount = amount
+	txEntity.ToTokenAmount = amount
+	txEntity.TxType = transaction.Reverse
+	txEntity.Note = original.Id
+
+	if err := t.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Reverse - Create reverse transaction failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableCreateTX)
+	}
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - Reverse succeed (%s)-----------", txEntity.Id)
+
+	return txEntity.Id, nil
+}
+
 func (t *tokenService) validateTransfer(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId string) error {
 	if _, _, err := t.ValidatePairWallet(ctx, fromWalletId, toWalletId); err != nil {
 		return err
@@ -298,12 +767,22 @@ func (t *tokenService) validateTransfer(ctx contractapi.TransactionContextInterf
 	return nil
 }
 
-func (t *tokenService) transferToken(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount, note string) (string, error) {
+func (t *tokenService) transferToken(ctx contractapi.TransactionContextInterface, fromWalletId, toWalletId, tokenId, amount, note string, expiry int64) (string, error) {
 	if err := t.validateTransfer(ctx, fromWalletId, toWalletId); err != nil {
 		glogger.GetInstance().Errorf(ctx, "Transfer - Validation transfer failed with error (%v)", err)
 		return "", err
 	}
 
+	if err := t.ValidateTokenControl(ctx, fromWalletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Transfer - Validation control of from wallet failed with error (%v)", err)
+		return "", err
+	}
+
+	if err := t.ValidateTokenControl(ctx, toWalletId, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Transfer - Validation control of to wallet failed with error (%v)", err)
+		return "", err
+	}
+
 	// create new transfer transaction
 	txEntity := entity.NewTransaction(ctx)
 	txEntity.SpenderWallet = fromWalletId
@@ -315,12 +794,15 @@ func (t *tokenService) transferToken(ctx contractapi.TransactionContextInterface
 	txEntity.ToTokenAmount = amount
 	txEntity.TxType = transaction.Transfer
 	txEntity.Note = note
+	txEntity.Expiry = expiry
 
-	if err := t.Repo.Create(ctx, txEntity, doc.Transactions, helper.TransactionKey(txEntity.Id)); err != nil {
+	// transaction from a multi-signature wallet waits for signatures of its owners
+	txId, err := t.SubmitTransaction(ctx, txEntity)
+	if err != nil {
 		glogger.GetInstance().Errorf(ctx, "Transfer - Create transfer transaction failed with error (%v)", err)
-		return "", helper.RespError(errorcode.BizUnableCreateTX)
+		return "", err
 	}
-	glogger.GetInstance().Infof(ctx, "-----------Token Service - Transfer succeed (%s)-----------", txEntity.Id)
+	glogger.GetInstance().Infof(ctx, "-----------Token Service - Transfer succeed (%s)-----------", txId)
 
-	return txEntity.Id, nil
+	return txId, nil
 }
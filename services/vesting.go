@@ -0,0 +1,32 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package services
+
+import "github.com/hyperledger/fabric-contract-api-go/contractapi"
+
+// Vesting is the lock-up of distributed tokens. Grants are created when an IAO with a vesting plan is settled,
+// the unvested balance of a grant can not be spent and may be revoked by the issuer of the grant.
+type Vesting interface {
+	// RevokeVesting to move the unvested amount of a grant from the wallet back to the issuer. It returns the revoked amount
+	RevokeVesting(ctx contractapi.TransactionContextInterface, issuerWalletId, walletId, tokenId, vestingId string) (string, error)
+
+	// GetVestingStatus return the locked, vested and unvested balance of token in wallet with its grants
+	GetVestingStatus(ctx contractapi.TransactionContextInterface, walletId, tokenId string) (string, error)
+}
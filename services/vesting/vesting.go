@@ -0,0 +1,175 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package vesting
+
+import (
+	tokenDto "github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/pkg/vesting"
+	"github.com/Akachain/gringotts/services"
+	"github.com/Akachain/gringotts/services/base"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type vestingService struct {
+	*base.Base
+}
+
+func NewVestingService() services.Vesting {
+	return &vestingService{base.NewBase()}
+}
+
+func (v *vestingService) RevokeVesting(ctx contractapi.TransactionContextInterface, issuerWalletId, walletId, tokenId, vestingId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Vesting Service - RevokeVesting-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	vestingEntity, err := v.GetVesting(ctx, walletId, tokenId, vestingId)
+	if err != nil {
+		return "", err
+	}
+
+	if vestingEntity.IssuerWallet != issuerWalletId {
+		glogger.GetInstance().Errorf(ctx, "Vesting Service - Wallet (%s) is not the issuer of vesting (%s)", issuerWalletId, vestingId)
+		return "", helper.RespError(errorcode.BizNotVestingIssuer)
+	}
+
+	if vestingEntity.RevokedAt > 0 {
+		glogger.GetInstance().Errorf(ctx, "Vesting Service - Vesting (%s) was revoked at (%d)", vestingId, vestingEntity.RevokedAt)
+		return "", helper.RespError(errorcode.BizVestingRevoked)
+	}
+
+	if _, err := v.GetActiveWallet(ctx, issuerWalletId); err != nil {
+		return "", err
+	}
+
+	unvested, err := vesting.Unvested(vestingEntity, txTime.Seconds)
+	if err != nil {
+		glogger.GetInstance().Errorf(ctx, "Vesting Service - Calculate unvested amount failed with error (%v)", err)
+		return "", helper.RespError(errorcode.BizUnableUpdateVesting)
+	}
+
+	// the unvested amount leaves the locked and spot balance of wallet to the spot balance of issuer
+	if helper.CompareStringBalance(unvested, "0") > 0 {
+		mapBalanceToken := make(map[string]*entity.BalanceCache)
+		if err := v.SubAmount(ctx, mapBalanceToken, doc.SpotBalances, walletId, tokenId, unvested); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Vesting Service - Sub unvested amount of wallet (%s) failed with error (%v)", walletId, err)
+			return "", helper.RespError(errorcode.BizBalanceNotEnough)
+		}
+		if err := v.SubAmount(ctx, mapBalanceToken, doc.LockedBalances, walletId, tokenId, unvested); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Vesting Service - Sub locked amount of wallet (%s) failed with error (%v)", walletId, err)
+			return "", helper.RespError(errorcode.BizBalanceNotEnough)
+		}
+		if err := v.AddAmount(ctx, mapBalanceToken, doc.SpotBalances, issuerWalletId, tokenId, unvested); err != nil {
+			glogger.GetInstance().Errorf(ctx, "Vesting Service - Add unvested amount to issuer (%s) failed with error (%v)", issuerWalletId, err)
+			return "", helper.RespError(errorcode.BizUnableUpdateBalance)
+		}
+		if err := v.UpdateBalance(ctx, mapBalanceToken); err != nil {
+			return "", err
+		}
+	}
+
+	vestingEntity.Revoked = unvested
+	vestingEntity.RevokedAt = txTime.Seconds
+	vestingEntity.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := v.Repo.Update(ctx, vestingEntity, doc.Vesting, helper.VestingKey(walletId, tokenId, vestingId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Vesting Service - Update vesting (%s) failed with error (%v)", vestingId, err)
+		return "", helper.RespError(errorcode.BizUnableUpdateVesting)
+	}
+
+	glogger.GetInstance().Infof(ctx, "-----------Vesting Service - RevokeVesting succeed (%s)-----------", unvested)
+
+	return unvested, nil
+}
+
+func (v *vestingService) GetVestingStatus(ctx contractapi.TransactionContextInterface, walletId, tokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Vesting Service - GetVestingStatus-----------")
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+
+	status := tokenDto.VestingStatus{
+		WalletId: walletId,
+		TokenId:  tokenId,
+		Locked:   "0",
+		Vested:   "0",
+		Unvested: "0",
+		Grants:   make([]tokenDto.VestingGrant, 0),
+	}
+
+	balance, isExisted, err := v.GetAndCheckBalanceOfToken(ctx, doc.LockedBalances, walletId, tokenId)
+	if err != nil {
+		return "", err
+	}
+	if isExisted {
+		status.Locked = balance.Balances
+	}
+
+	vestings, err := v.GetVestings(ctx, walletId, tokenId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, vestingEntity := range vestings {
+		grant, err := vestingGrant(vestingEntity, txTime.Seconds)
+		if err != nil {
+			glogger.GetInstance().Errorf(ctx, "Vesting Service - Calculate vesting (%s) failed with error (%v)", vestingEntity.Id, err)
+			return "", helper.RespError(errorcode.BizUnableGetVesting)
+		}
+		if status.Vested, err = helper.AddBalance(status.Vested, grant.Vested); err != nil {
+			return "", helper.RespError(errorcode.BizUnableGetVesting)
+		}
+		if status.Unvested, err = helper.AddBalance(status.Unvested, grant.Unvested); err != nil {
+			return "", helper.RespError(errorcode.BizUnableGetVesting)
+		}
+		status.Grants = append(status.Grants, grant)
+	}
+
+	return helper.MarshalStruct(status), nil
+}
+
+func vestingGrant(vestingEntity *entity.Vesting, now int64) (tokenDto.VestingGrant, error) {
+	unvested, err := vesting.Unvested(vestingEntity, now)
+	if err != nil {
+		return tokenDto.VestingGrant{}, err
+	}
+
+	vested, err := helper.SubBalance(vestingEntity.Total, unvested)
+	if err != nil {
+		return tokenDto.VestingGrant{}, err
+	}
+	if vested, err = helper.SubBalance(vested, vestingEntity.Revoked); err != nil {
+		return tokenDto.VestingGrant{}, err
+	}
+
+	return tokenDto.VestingGrant{
+		VestingId:    vestingEntity.Id,
+		IaoId:        vestingEntity.IaoId,
+		IssuerWallet: vestingEntity.IssuerWallet,
+		Total:        vestingEntity.Total,
+		Vested:       vested,
+		Unvested:     unvested,
+		Revoked:      vestingEntity.Revoked,
+		StartTime:    vestingEntity.StartTime,
+		RevokedAt:    vestingEntity.RevokedAt,
+		Plan:         vestingEntity.Plan,
+	}, nil
+}
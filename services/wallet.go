@@ -28,13 +28,24 @@ type Wallet interface {
 	// Create to create new wallet. Each wallet belong to token type
 	Create(ctx contractapi.TransactionContextInterface, tokenId string, status glossary.Status) (string, error)
 
+	// CreateMultiSig to create new multi-signature wallet. Its outgoing transactions are written once threshold of owners sign
+	CreateMultiSig(ctx contractapi.TransactionContextInterface, tokenId string, status glossary.Status, owners []string, threshold int) (string, error)
+
 	// Update to update status of wallet. Active or InActive
 	Update(ctx contractapi.TransactionContextInterface, walletId string, status glossary.Status) error
 
 	// BalanceOf get balance of wallet
 	BalanceOf(ctx contractapi.TransactionContextInterface, walletId string, tokenId string) (string, error)
 
-	// EnrollToken to register wallet id that will be issue/mint token into.
-	// Currently support add list from wallet id and to wallet id
+	// EnrollToken to add wallet ids to the issuers (from wallet) and recipients (to wallet) of the issuance policy of token
 	EnrollToken(ctx contractapi.TransactionContextInterface, tokenId string, fromWalletId []string, toWalletId []string) error
+
+	// RemoveEnrollment to remove wallet ids from the issuers and recipients of the issuance policy of token
+	RemoveEnrollment(ctx contractapi.TransactionContextInterface, tokenId string, fromWalletId []string, toWalletId []string) error
+
+	// SetIssuanceQuota to limit the total amount of token issued or minted to a wallet. An empty quota removes the limit
+	SetIssuanceQuota(ctx contractapi.TransactionContextInterface, tokenId, walletId, quota string) error
+
+	// GetEnrollment return the issuance policy of token
+	GetEnrollment(ctx contractapi.TransactionContextInterface, tokenId string) (string, error)
 }
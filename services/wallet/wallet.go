@@ -29,7 +29,6 @@ import (
 	"github.com/Akachain/gringotts/helper/glogger"
 	"github.com/Akachain/gringotts/services/base"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
-	"strings"
 )
 
 type walletService struct {
@@ -42,10 +41,34 @@ func NewWalletService() *walletService {
 
 func (w *walletService) Create(ctx contractapi.TransactionContextInterface, tokenId string, status glossary.Status) (string, error) {
 	glogger.GetInstance().Info(ctx, "-----------Wallet Service - Create-----------")
+	return w.createWallet(ctx, tokenId, status, nil, 0)
+}
+
+func (w *walletService) CreateMultiSig(ctx contractapi.TransactionContextInterface, tokenId string, status glossary.Status,
+	owners []string, threshold int) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Service - CreateMultiSig-----------")
+
+	// owners are kept as a sorted set
+	ownerSet := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		ownerSet = helper.ArrayInsert(ownerSet, owner)
+	}
+	return w.createWallet(ctx, tokenId, status, ownerSet, threshold)
+}
+
+func (w *walletService) createWallet(ctx contractapi.TransactionContextInterface, tokenId string, status glossary.Status,
+	owners []string, threshold int) (string, error) {
+	// wallet can only be created for an active token
+	if _, err := w.GetActiveToken(ctx, tokenId); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Create - Get active token failed with error (%v)", err)
+		return "", err
+	}
 
 	// create wallet
 	walletEntity := entity.NewWallet(ctx)
 	walletEntity.Status = status
+	walletEntity.Owners = owners
+	walletEntity.Threshold = threshold
 	if err := w.Repo.Create(ctx, walletEntity, doc.Wallets, helper.WalletKey(walletEntity.Id)); err != nil {
 		glogger.GetInstance().Errorf(ctx, "Create - Create wallet failed with error (%v)", err)
 		return "", helper.RespError(errorcode.BizUnableCreateWallet)
@@ -109,30 +132,95 @@ func (w *walletService) EnrollToken(ctx contractapi.TransactionContextInterface,
 		return helper.RespError(errorcode.BizUnableGetEnrollment)
 	}
 
-	if isExisted {
-		if len(fromWalletId) > 0 && !helper.ArrayContains(fromWalletId, "") {
-			enrollment.FromWalletId = enrollment.FromWalletId + "," + strings.Join(fromWalletId, ",")
-		}
-		if len(toWalletId) > 0 && !helper.ArrayContains(toWalletId, "") {
-			enrollment.ToWalletId = enrollment.ToWalletId + "," + strings.Join(toWalletId, ",")
+	if !isExisted {
+		enrollment = entity.NewEnrollment(ctx)
+		enrollment.TokenId = tokenId
+	}
+
+	for _, walletId := range fromWalletId {
+		if walletId != "" {
+			enrollment.Issuers = helper.ArrayInsert(enrollment.Issuers, walletId)
+			enrollment.IssuersRestricted = true
 		}
-		if err := w.Repo.Update(ctx, enrollment, doc.Enrollments, helper.EnrollmentKey(tokenId)); err != nil {
-			glogger.GetInstance().Errorf(ctx, "Wallet Service - Update enrollment failed with err (%v)", err)
-			return helper.RespError(errorcode.BizUnableCreateEnrollment)
+	}
+	for _, walletId := range toWalletId {
+		if walletId != "" {
+			enrollment.Recipients = helper.ArrayInsert(enrollment.Recipients, walletId)
+			enrollment.RecipientsRestricted = true
 		}
+	}
+	return w.updateEnrollment(ctx, enrollment)
+}
+
+func (w *walletService) RemoveEnrollment(ctx contractapi.TransactionContextInterface, tokenId string, fromWalletId []string, toWalletId []string) error {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Service - RemoveEnrollment-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "RemoveEnrollment - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	enrollment, err := w.Base.GetEnrollment(ctx, tokenId)
+	if err != nil {
+		return err
+	}
+
+	for _, walletId := range fromWalletId {
+		enrollment.Issuers = helper.ArrayRemove(enrollment.Issuers, walletId)
+	}
+	for _, walletId := range toWalletId {
+		enrollment.Recipients = helper.ArrayRemove(enrollment.Recipients, walletId)
+	}
+	// the sets stay restricted, removing every wallet of a set denies all of them
+	return w.updateEnrollment(ctx, enrollment)
+}
+
+func (w *walletService) SetIssuanceQuota(ctx contractapi.TransactionContextInterface, tokenId, walletId, quota string) error {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Service - SetIssuanceQuota-----------")
+
+	if !helper.IsAdmin(ctx) {
+		glogger.GetInstance().Error(ctx, "SetIssuanceQuota - Caller is not admin")
+		return helper.RespError(errorcode.BizNotAdmin)
+	}
+
+	enrollment, isExisted, err := w.GetAndCheckExistEnrollment(ctx, tokenId)
+	if err != nil {
+		return err
+	}
+
+	if !isExisted {
+		enrollment = entity.NewEnrollment(ctx)
+		enrollment.TokenId = tokenId
+	}
+
+	// an empty quota removes the limit of wallet, the amount already issued is kept
+	if quota == "" {
+		delete(enrollment.Quota, walletId)
 	} else {
-		enrollmentEntity := entity.NewEnrollment(ctx)
-		enrollmentEntity.TokenId = tokenId
-		if len(fromWalletId) > 0 && !helper.ArrayContains(fromWalletId, "") {
-			enrollmentEntity.FromWalletId = strings.Join(fromWalletId, ",")
-		}
-		if len(toWalletId) > 0 && !helper.ArrayContains(toWalletId, "") {
-			enrollmentEntity.ToWalletId = strings.Join(toWalletId, ",")
-		}
-		if err := w.Repo.Create(ctx, enrollmentEntity, doc.Enrollments, helper.EnrollmentKey(tokenId)); err != nil {
-			glogger.GetInstance().Errorf(ctx, "Wallet Service - Create enrollment failed with err (%v)", err)
-			return helper.RespError(errorcode.BizUnableCreateEnrollment)
+		if enrollment.Quota == nil {
+			enrollment.Quota = make(map[string]string)
 		}
+		enrollment.Quota[walletId] = quota
+	}
+	return w.updateEnrollment(ctx, enrollment)
+}
+
+func (w *walletService) GetEnrollment(ctx contractapi.TransactionContextInterface, tokenId string) (string, error) {
+	glogger.GetInstance().Info(ctx, "-----------Wallet Service - GetEnrollment-----------")
+	enrollment, err := w.Base.GetEnrollment(ctx, tokenId)
+	if err != nil {
+		return "", err
+	}
+	return helper.MarshalStruct(enrollment), nil
+}
+
+func (w *walletService) updateEnrollment(ctx contractapi.TransactionContextInterface, enrollment *entity.Enrollment) error {
+	txTime, _ := ctx.GetStub().GetTxTimestamp()
+	enrollment.UpdatedAt = helper.TimestampISO(txTime.Seconds)
+	if err := w.Repo.Update(ctx, enrollment, doc.Enrollments, helper.EnrollmentKey(enrollment.TokenId)); err != nil {
+		glogger.GetInstance().Errorf(ctx, "Wallet Service - Update enrollment failed with err (%v)", err)
+		return helper.RespError(errorcode.BizUnableCreateEnrollment)
 	}
+	glogger.GetInstance().Infof(ctx, "-----------Wallet Service - Update enrollment succeed (%s)-----------", enrollment.TokenId)
 	return nil
 }
@@ -0,0 +1,78 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package basic
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+func (suite *BaseSCTestSuite) TestBaseToken_HtlcClaimNotCancelable() {
+	preimage := hex.EncodeToString([]byte("gringotts secret"))
+	secret, _ := hex.DecodeString(preimage)
+	hashLock := sha256.Sum256(secret)
+
+	lockDto := token.LockTransfer{
+		FromWalletId: suite.walletFromId,
+		ToWalletId:   suite.walletToId,
+		TokenId:      suite.STToken,
+		Amount:       "8900",
+		HashLock:     hex.EncodeToString(hashLock[:]),
+		Timeout:      time.Now().Add(2 * time.Second).Unix(),
+	}
+	paramByte, _ := json.Marshal(lockDto)
+	lockId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("LockTransfer"), paramByte})
+	suite.T().Log(lockId)
+	assert.NotContains(suite.T(), lockId, "ErrorCode", "Lock transfer return error")
+
+	// the receiver reveals the preimage
+	claimDto := token.ClaimTransfer{LockId: lockId, Preimage: preimage}
+	paramByte, _ = json.Marshal(claimDto)
+	claimTxId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ClaimTransfer"), paramByte})
+	suite.T().Log(claimTxId)
+	assert.NotContains(suite.T(), claimTxId, "ErrorCode", "Claim transfer return error")
+
+	// the sender can not take the pending claim back
+	cancelDto := token.CancelTransaction{WalletId: suite.walletFromId, TxId: claimTxId}
+	paramByte, _ = json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelTransaction"), paramByte})
+	suite.T().Log(cancelRes)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizTransactionNotCancelable), "Cancel claim transaction must be rejected")
+
+	// nor refund it after the timeout
+	time.Sleep(3 * time.Second)
+	refundDto := token.RefundTransfer{LockId: lockId}
+	paramByte, _ = json.Marshal(refundDto)
+	refundRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("RefundTransfer"), paramByte})
+	suite.T().Log(refundRes)
+	assert.Contains(suite.T(), refundRes, "ErrorCode", "Refund claimed transfer must be rejected")
+
+	// accounting balance
+	suite.accountingBalance()
+
+	assert.Equal(suite.T(), "670000", suite.getBalance(suite.walletFromId, suite.STToken), "Held amount of From wallet is not paid")
+	assert.Equal(suite.T(), "8900", suite.getBalance(suite.walletToId, suite.STToken), "Claimed amount is not added to To wallet")
+}
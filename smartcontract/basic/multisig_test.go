@@ -0,0 +1,258 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package basic
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"time"
+)
+
+func (suite *BaseSCTestSuite) TestBaseToken_MultiSigThreshold() {
+	owners := suite.newIdentities("owner-a", "owner-b", "owner-c")
+	outsider := suite.newIdentities("outsider")[0]
+	walletId := suite.createMultiSigWallet(owners, 2)
+
+	// the first owner proposes the transfer, it is kept as an open intent
+	suite.stub.Creator = owners[0]
+	suite.transferFrom(walletId, "1000")
+	intentId := suite.openIntentId(walletId)
+
+	signDto := token.SignIntent{IntentId: intentId}
+	paramByte, _ := json.Marshal(signDto)
+	signRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SignIntent"), paramByte})
+	suite.T().Log(signRes)
+	assert.Contains(suite.T(), signRes, string(errorcode.BizIntentAlreadySigned), "Owner must not sign twice")
+
+	suite.stub.Creator = outsider
+	signRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SignIntent"), paramByte})
+	suite.T().Log(signRes)
+	assert.Contains(suite.T(), signRes, string(errorcode.BizIntentNotOwner), "Outsider must not sign")
+
+	// nothing is settled under the threshold
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "5000", suite.getBalance(walletId, suite.STToken), "Transfer is settled under threshold")
+
+	// the second owner reaches the threshold and the transaction is written
+	suite.stub.Creator = owners[1]
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SignIntent"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Sign intent return error")
+	assert.NotEmpty(suite.T(), txId, "Executed intent has no transaction")
+
+	suite.stub.Creator = owners[2]
+	signRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SignIntent"), paramByte})
+	suite.T().Log(signRes)
+	assert.Contains(suite.T(), signRes, string(errorcode.BizIntentInvalidStatus), "Executed intent must not be signed")
+
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "4000", suite.getBalance(walletId, suite.STToken), "Sub balance of multi-signature wallet failed")
+	assert.Equal(suite.T(), "1000", suite.getBalance(suite.walletToId, suite.STToken), "Add balance of To wallet failed")
+}
+
+func (suite *BaseSCTestSuite) TestBaseToken_MultiSigOwner() {
+	owners := suite.newIdentities("owner-a", "owner-b")
+	outsider := suite.newIdentities("outsider")[0]
+	walletId := suite.createMultiSigWallet(owners, 2)
+
+	// only owners can move the funds of the wallet
+	suite.stub.Creator = outsider
+	transferDto := token.TransferToken{
+		FromWalletId: walletId,
+		ToWalletId:   suite.walletToId,
+		TokenId:      suite.STToken,
+		Amount:       "1000",
+	}
+	paramByte, _ := json.Marshal(transferDto)
+	transferRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Transfer"), paramByte})
+	suite.T().Log(transferRes)
+	assert.Contains(suite.T(), transferRes, string(errorcode.BizIntentNotOwner), "Outsider must not transfer")
+
+	suite.stub.Creator = owners[0]
+	suite.transferFrom(walletId, "1000")
+	intentId := suite.openIntentId(walletId)
+
+	// only owners can cancel the intent
+	cancelDto := token.SignIntent{IntentId: intentId}
+	paramByte, _ = json.Marshal(cancelDto)
+	suite.stub.Creator = outsider
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelIntent"), paramByte})
+	suite.T().Log(cancelRes)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizIntentNotOwner), "Outsider must not cancel")
+
+	suite.stub.Creator = owners[1]
+	cancelRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelIntent"), paramByte})
+	suite.T().Log(cancelRes)
+	assert.Empty(suite.T(), cancelRes, "Cancel intent return error")
+
+	signRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SignIntent"), paramByte})
+	suite.T().Log(signRes)
+	assert.Contains(suite.T(), signRes, string(errorcode.BizIntentInvalidStatus), "Canceled intent must not be signed")
+
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "5000", suite.getBalance(walletId, suite.STToken), "Canceled transfer is settled")
+}
+
+func (suite *BaseSCTestSuite) TestBaseToken_MultiSigSwapRejected() {
+	owners := suite.newIdentities("owner-a")
+	walletId := suite.createMultiSigWallet(owners, 1)
+	expiry := time.Now().Add(time.Hour).Unix()
+
+	// the swap proposed to the multi-signature wallet by a single-signature maker
+	proposeDto := token.ProposeSwap{
+		MakerWalletId: suite.walletFromId,
+		TakerWalletId: walletId,
+		MakerTokenId:  suite.STToken,
+		TakerTokenId:  suite.STToken,
+		MakerAmount:   "100",
+		TakerAmount:   "100",
+		Expiry:        expiry,
+	}
+	paramByte, _ := json.Marshal(proposeDto)
+	swapId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ProposeSwap"), paramByte})
+	suite.T().Log(swapId)
+	assert.NotContains(suite.T(), swapId, "ErrorCode", "Propose swap return error")
+
+	suite.stub.Creator = owners[0]
+
+	proposeDto.MakerWalletId, proposeDto.TakerWalletId = walletId, suite.walletToId
+	paramByte, _ = json.Marshal(proposeDto)
+	proposeRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ProposeSwap"), paramByte})
+	suite.T().Log(proposeRes)
+	assert.Contains(suite.T(), proposeRes, string(errorcode.BizWalletMultiSig), "Multi-signature maker must be rejected")
+
+	acceptDto := token.AcceptSwap{SwapId: swapId, TakerWalletId: walletId}
+	paramByte, _ = json.Marshal(acceptDto)
+	acceptRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AcceptSwap"), paramByte})
+	suite.T().Log(acceptRes)
+	assert.Contains(suite.T(), acceptRes, string(errorcode.BizWalletMultiSig), "Multi-signature taker must be rejected")
+
+	issueDto := token.IssueToken{
+		WalletId:        walletId,
+		FromTokenId:     suite.STToken,
+		ToTokenId:       suite.ATToken,
+		FromTokenAmount: "100",
+		ToTokenAmount:   "10",
+	}
+	paramByte, _ = json.Marshal(issueDto)
+	issueRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Issue"), paramByte})
+	suite.T().Log(issueRes)
+	assert.Contains(suite.T(), issueRes, string(errorcode.BizWalletMultiSig), "Issue of multi-signature wallet must be rejected")
+
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "5000", suite.getBalance(walletId, suite.STToken), "Balance of multi-signature wallet is changed")
+}
+
+// newIdentities return the serialized client identity of a self-signed certificate of every name
+func (suite *BaseSCTestSuite) newIdentities(names ...string) [][]byte {
+	identities := make([][]byte, 0, len(names))
+	for index, name := range names {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.Nil(suite.T(), err, "Generate key failed")
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(index + 1)),
+			Subject:      pkix.Name{CommonName: name, Organization: []string{"gringotts"}},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		assert.Nil(suite.T(), err, "Create certificate failed")
+
+		identity, err := msp.NewSerializedIdentity("Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+		assert.Nil(suite.T(), err, "Serialize identity failed")
+		identities = append(identities, identity)
+	}
+	return identities
+}
+
+// createMultiSigWallet create a wallet of the owners with threshold and mint 5000 ST to it
+func (suite *BaseSCTestSuite) createMultiSigWallet(owners [][]byte, threshold int) string {
+	ownerIds := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		suite.stub.Creator = owner
+		ownerId, err := cid.GetID(suite.stub)
+		assert.Nil(suite.T(), err, "Get id of owner failed")
+		ownerIds = append(ownerIds, ownerId)
+	}
+
+	wallet := token.CreateWallet{
+		TokenId:   suite.STToken,
+		Status:    "A",
+		Owners:    ownerIds,
+		Threshold: threshold,
+	}
+	paramByte, _ := json.Marshal(wallet)
+	walletId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateWallet"), paramByte})
+	assert.NotContains(suite.T(), walletId, "ErrorCode", "Create multi-signature wallet return error")
+
+	mintDto := token.MintToken{
+		WalletId: walletId,
+		TokenId:  suite.STToken,
+		Amount:   "5000",
+	}
+	paramByte, _ = json.Marshal(mintDto)
+	mintRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Mint"), paramByte})
+	assert.Empty(suite.T(), mintRes, "Mint invoke return err")
+
+	suite.accountingBalance()
+	return walletId
+}
+
+func (suite *BaseSCTestSuite) transferFrom(walletId, amount string) {
+	transferDto := token.TransferToken{
+		FromWalletId: walletId,
+		ToWalletId:   suite.walletToId,
+		TokenId:      suite.STToken,
+		Amount:       amount,
+	}
+	paramByte, _ := json.Marshal(transferDto)
+	transferRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Transfer"), paramByte})
+	suite.T().Log(transferRes)
+	assert.Empty(suite.T(), transferRes, "Transfer of owner return error")
+}
+
+// openIntentId return the id of the only open intent of wallet
+func (suite *BaseSCTestSuite) openIntentId(walletId string) string {
+	queryDto := token.QueryIntent{WalletId: walletId}
+	paramByte, _ := json.Marshal(queryDto)
+	intentsRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetOpenIntents"), paramByte})
+	suite.T().Log(intentsRes)
+
+	var intents []entity.Intent
+	assert.Nil(suite.T(), json.Unmarshal([]byte(intentsRes), &intents), "Unmarshal open intents failed")
+	if !assert.Len(suite.T(), intents, 1, "Wallet must have one open intent") {
+		suite.T().FailNow()
+	}
+	return intents[0].Id
+}
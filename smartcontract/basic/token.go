@@ -21,6 +21,7 @@ package basic
 
 import (
 	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/glossary/proposal"
 	"github.com/Akachain/gringotts/handler"
 	"github.com/Akachain/gringotts/helper/glogger"
 	"github.com/Akachain/gringotts/smartcontract"
@@ -30,17 +31,37 @@ import (
 type baseToken struct {
 	contractapi.Contract
 	tokenHandler       *handler.TokenHandler
+	swapHandler        *handler.SwapHandler
+	feeHandler         *handler.FeeHandler
+	proposalHandler    *handler.ProposalHandler
+	multiSigHandler    *handler.MultiSigHandler
+	metaTxHandler      *handler.MetaTxHandler
+	htlcHandler        *handler.HtlcHandler
+	scheduleHandler    *handler.ScheduleHandler
+	snapshotHandler    *handler.SnapshotHandler
+	vestingHandler     *handler.VestingHandler
 	walletHandler      *handler.WalletHandler
 	healthCheckHandler handler.HealthCheckHandler
 	accountingHandler  handler.AccountingHandler
+	idempotencyHandler *handler.IdempotencyHandler
 }
 
 func NewBaseToken() smartcontract.BasicToken {
 	return &baseToken{
 		tokenHandler:       handler.NewTokenHandler(),
+		swapHandler:        handler.NewSwapHandler(),
+		feeHandler:         handler.NewFeeHandler(),
+		proposalHandler:    handler.NewProposalHandler(),
+		multiSigHandler:    handler.NewMultiSigHandler(),
+		metaTxHandler:      handler.NewMetaTxHandler(),
+		htlcHandler:        handler.NewHtlcHandler(),
+		scheduleHandler:    handler.NewScheduleHandler(),
+		snapshotHandler:    handler.NewSnapshotHandler(),
+		vestingHandler:     handler.NewVestingHandler(),
 		walletHandler:      handler.NewWalletHandler(),
 		healthCheckHandler: handler.NewHealthCheckHandler(),
 		accountingHandler:  handler.NewAccountingHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
 	}
 }
 
@@ -51,11 +72,15 @@ func (b *baseToken) InitLedger(ctx contractapi.TransactionContextInterface) erro
 
 // Wallet feature
 func (b *baseToken) CreateWallet(ctx contractapi.TransactionContextInterface, createWallet token.CreateWallet) (string, error) {
-	return b.walletHandler.CreateWallet(ctx, createWallet)
+	return b.idempotencyHandler.Execute(ctx, "CreateWallet", createWallet, func() (string, error) {
+		return b.walletHandler.CreateWallet(ctx, createWallet)
+	})
 }
 
 func (b *baseToken) UpdateWallet(ctx contractapi.TransactionContextInterface, updateWallet token.UpdateWallet) error {
-	return b.walletHandler.UpdateWallet(ctx, updateWallet)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "UpdateWallet", updateWallet, func() error {
+		return b.walletHandler.UpdateWallet(ctx, updateWallet)
+	})
 }
 
 func (b *baseToken) GetBalance(ctx contractapi.TransactionContextInterface, balance token.Balance) (string, error) {
@@ -64,23 +89,87 @@ func (b *baseToken) GetBalance(ctx contractapi.TransactionContextInterface, bala
 
 // Token feature
 func (b *baseToken) Mint(ctx contractapi.TransactionContextInterface, mintDto token.MintToken) error {
-	return b.tokenHandler.Mint(ctx, mintDto)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "Mint", mintDto, func() error {
+		if err := b.proposalHandler.CheckApproval(ctx, proposal.Mint, mintDto.TokenId); err != nil {
+			return err
+		}
+		return b.tokenHandler.Mint(ctx, mintDto)
+	})
+}
+
+func (b *baseToken) MintBatch(ctx contractapi.TransactionContextInterface, mintBatch token.MintBatch) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "MintBatch", mintBatch, func() (string, error) {
+		if err := b.proposalHandler.CheckApproval(ctx, proposal.Mint, mintBatch.TokenId); err != nil {
+			return "", err
+		}
+		return b.tokenHandler.MintBatch(ctx, mintBatch)
+	})
 }
 
 func (b *baseToken) Burn(ctx contractapi.TransactionContextInterface, burnDto token.BurnToken) error {
-	return b.tokenHandler.Burn(ctx, burnDto)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "Burn", burnDto, func() error {
+		if err := b.proposalHandler.CheckApproval(ctx, proposal.Burn, burnDto.TokenId); err != nil {
+			return err
+		}
+		return b.tokenHandler.Burn(ctx, burnDto)
+	})
 }
 
 func (b *baseToken) Transfer(ctx contractapi.TransactionContextInterface, transferDto token.TransferToken) error {
-	return b.tokenHandler.Transfer(ctx, transferDto)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "Transfer", transferDto, func() error {
+		return b.tokenHandler.Transfer(ctx, transferDto)
+	})
+}
+
+func (b *baseToken) TransferBatch(ctx contractapi.TransactionContextInterface, transferBatch token.TransferBatch) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "TransferBatch", transferBatch, func() (string, error) {
+		return b.tokenHandler.TransferBatch(ctx, transferBatch)
+	})
 }
 
 func (b *baseToken) CreateTokenType(ctx contractapi.TransactionContextInterface, createTokenTypeDto token.CreateTokenType) (string, error) {
-	return b.tokenHandler.CreateTokenType(ctx, createTokenTypeDto)
+	return b.idempotencyHandler.Execute(ctx, "CreateTokenType", createTokenTypeDto, func() (string, error) {
+		if err := b.proposalHandler.CheckApproval(ctx, proposal.CreateTokenType, ""); err != nil {
+			return "", err
+		}
+		return b.tokenHandler.CreateTokenType(ctx, createTokenTypeDto)
+	})
 }
 
 func (b *baseToken) TransferSideChain(ctx contractapi.TransactionContextInterface, transferChain token.TransferSideChain) error {
-	return b.tokenHandler.TransferSideChain(ctx, transferChain)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "TransferSideChain", transferChain, func() error {
+		return b.tokenHandler.TransferSideChain(ctx, transferChain)
+	})
+}
+
+func (b *baseToken) PauseToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "PauseToken", tokenControl, func() error {
+		return b.tokenHandler.PauseToken(ctx, tokenControl)
+	})
+}
+
+func (b *baseToken) UnpauseToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "UnpauseToken", tokenControl, func() error {
+		return b.tokenHandler.UnpauseToken(ctx, tokenControl)
+	})
+}
+
+func (b *baseToken) DeactivateToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "DeactivateToken", tokenControl, func() error {
+		return b.tokenHandler.DeactivateToken(ctx, tokenControl)
+	})
+}
+
+func (b *baseToken) FreezeBalance(ctx contractapi.TransactionContextInterface, freezeBalance token.FreezeBalance) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "FreezeBalance", freezeBalance, func() error {
+		return b.tokenHandler.FreezeBalance(ctx, freezeBalance)
+	})
+}
+
+func (b *baseToken) UnfreezeBalance(ctx contractapi.TransactionContextInterface, unfreezeBalance token.UnfreezeBalance) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "UnfreezeBalance", unfreezeBalance, func() error {
+		return b.tokenHandler.UnfreezeBalance(ctx, unfreezeBalance)
+	})
 }
 
 // API healthcheck
@@ -98,13 +187,213 @@ func (b *baseToken) CalculateBalance(ctx contractapi.TransactionContextInterface
 }
 
 func (b *baseToken) Exchange(ctx contractapi.TransactionContextInterface, exchangeToken token.ExchangeToken) error {
-	return b.tokenHandler.Exchange(ctx, exchangeToken)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "Exchange", exchangeToken, func() error {
+		return b.tokenHandler.Exchange(ctx, exchangeToken)
+	})
+}
+
+// Swap feature
+func (b *baseToken) ProposeSwap(ctx contractapi.TransactionContextInterface, proposeSwap token.ProposeSwap) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "ProposeSwap", proposeSwap, func() (string, error) {
+		return b.swapHandler.ProposeSwap(ctx, proposeSwap)
+	})
+}
+
+func (b *baseToken) AcceptSwap(ctx contractapi.TransactionContextInterface, acceptSwap token.AcceptSwap) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "AcceptSwap", acceptSwap, func() (string, error) {
+		return b.swapHandler.AcceptSwap(ctx, acceptSwap)
+	})
+}
+
+func (b *baseToken) CancelSwap(ctx contractapi.TransactionContextInterface, cancelSwap token.CancelSwap) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "CancelSwap", cancelSwap, func() error {
+		return b.swapHandler.CancelSwap(ctx, cancelSwap)
+	})
+}
+
+func (b *baseToken) GetSwap(ctx contractapi.TransactionContextInterface, querySwap token.QuerySwap) (string, error) {
+	return b.swapHandler.GetSwap(ctx, querySwap)
+}
+
+// Fee feature
+func (b *baseToken) SetFeeSchedule(ctx contractapi.TransactionContextInterface, feeSchedule token.FeeSchedule) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "SetFeeSchedule", feeSchedule, func() (string, error) {
+		return b.feeHandler.SetFeeSchedule(ctx, feeSchedule)
+	})
+}
+
+func (b *baseToken) GetFeeSchedule(ctx contractapi.TransactionContextInterface, queryFeeSchedule token.QueryFeeSchedule) (string, error) {
+	return b.feeHandler.GetFeeSchedule(ctx, queryFeeSchedule)
+}
+
+func (b *baseToken) QuoteFee(ctx contractapi.TransactionContextInterface, quoteFee token.QuoteFee) (string, error) {
+	return b.feeHandler.QuoteFee(ctx, quoteFee)
 }
 
 func (b *baseToken) Issue(ctx contractapi.TransactionContextInterface, issueDto token.IssueToken) error {
-	return b.tokenHandler.Issue(ctx, issueDto)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "Issue", issueDto, func() error {
+		return b.tokenHandler.Issue(ctx, issueDto)
+	})
 }
 
 func (b *baseToken) EnrollToken(ctx contractapi.TransactionContextInterface, enrollmentDto token.Enrollment) error {
-	return b.walletHandler.EnrollToken(ctx, enrollmentDto)
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "EnrollToken", enrollmentDto, func() error {
+		return b.walletHandler.EnrollToken(ctx, enrollmentDto)
+	})
+}
+
+func (b *baseToken) RemoveEnrollment(ctx contractapi.TransactionContextInterface, enrollmentDto token.Enrollment) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "RemoveEnrollment", enrollmentDto, func() error {
+		return b.walletHandler.RemoveEnrollment(ctx, enrollmentDto)
+	})
+}
+
+func (b *baseToken) SetIssuanceQuota(ctx contractapi.TransactionContextInterface, quotaDto token.IssuanceQuota) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "SetIssuanceQuota", quotaDto, func() error {
+		return b.walletHandler.SetIssuanceQuota(ctx, quotaDto)
+	})
+}
+
+func (b *baseToken) GetEnrollment(ctx contractapi.TransactionContextInterface, queryDto token.QueryEnrollment) (string, error) {
+	return b.walletHandler.GetEnrollment(ctx, queryDto)
+}
+
+func (b *baseToken) SignIntent(ctx contractapi.TransactionContextInterface, signIntent token.SignIntent) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "SignIntent", signIntent, func() (string, error) {
+		return b.multiSigHandler.SignIntent(ctx, signIntent)
+	})
+}
+
+func (b *baseToken) CancelIntent(ctx contractapi.TransactionContextInterface, signIntent token.SignIntent) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "CancelIntent", signIntent, func() error {
+		return b.multiSigHandler.CancelIntent(ctx, signIntent)
+	})
+}
+
+func (b *baseToken) GetOpenIntents(ctx contractapi.TransactionContextInterface, queryIntent token.QueryIntent) (string, error) {
+	return b.multiSigHandler.GetOpenIntents(ctx, queryIntent)
+}
+
+func (b *baseToken) RegisterWalletKey(ctx contractapi.TransactionContextInterface, walletKey token.WalletKey) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "RegisterWalletKey", walletKey, func() error {
+		return b.metaTxHandler.RegisterWalletKey(ctx, walletKey)
+	})
+}
+
+func (b *baseToken) SubmitSignedTransfer(ctx contractapi.TransactionContextInterface, signedTransfer token.SignedTransfer) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "SubmitSignedTransfer", signedTransfer, func() error {
+		return b.metaTxHandler.SubmitSignedTransfer(ctx, signedTransfer)
+	})
+}
+
+func (b *baseToken) CleanupIdempotency(ctx contractapi.TransactionContextInterface) (int, error) {
+	return b.idempotencyHandler.Cleanup(ctx)
+}
+
+func (b *baseToken) CancelTransaction(ctx contractapi.TransactionContextInterface, cancelTransaction token.CancelTransaction) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "CancelTransaction", cancelTransaction, func() error {
+		return b.tokenHandler.CancelTransaction(ctx, cancelTransaction)
+	})
+}
+
+func (b *baseToken) ReverseTransaction(ctx contractapi.TransactionContextInterface, reverseDto token.ReverseTransaction) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "ReverseTransaction", reverseDto, func() (string, error) {
+		return b.tokenHandler.Reverse(ctx, reverseDto)
+	})
+}
+
+func (b *baseToken) LockTransfer(ctx contractapi.TransactionContextInterface, lockTransfer token.LockTransfer) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "LockTransfer", lockTransfer, func() (string, error) {
+		return b.htlcHandler.LockTransfer(ctx, lockTransfer)
+	})
+}
+
+func (b *baseToken) ClaimTransfer(ctx contractapi.TransactionContextInterface, claimTransfer token.ClaimTransfer) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "ClaimTransfer", claimTransfer, func() (string, error) {
+		return b.htlcHandler.ClaimTransfer(ctx, claimTransfer)
+	})
+}
+
+func (b *baseToken) RefundTransfer(ctx contractapi.TransactionContextInterface, refundTransfer token.RefundTransfer) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "RefundTransfer", refundTransfer, func() (string, error) {
+		return b.htlcHandler.RefundTransfer(ctx, refundTransfer)
+	})
+}
+
+func (b *baseToken) GetLock(ctx contractapi.TransactionContextInterface, queryLock token.QueryLock) (string, error) {
+	return b.htlcHandler.GetLock(ctx, queryLock)
+}
+
+func (b *baseToken) CreateSchedule(ctx contractapi.TransactionContextInterface, createSchedule token.CreateSchedule) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "CreateSchedule", createSchedule, func() (string, error) {
+		return b.scheduleHandler.CreateSchedule(ctx, createSchedule)
+	})
+}
+
+func (b *baseToken) PauseSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "PauseSchedule", updateSchedule, func() error {
+		return b.scheduleHandler.PauseSchedule(ctx, updateSchedule)
+	})
+}
+
+func (b *baseToken) ResumeSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "ResumeSchedule", updateSchedule, func() error {
+		return b.scheduleHandler.ResumeSchedule(ctx, updateSchedule)
+	})
+}
+
+func (b *baseToken) CancelSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "CancelSchedule", updateSchedule, func() error {
+		return b.scheduleHandler.CancelSchedule(ctx, updateSchedule)
+	})
+}
+
+func (b *baseToken) GetSchedules(ctx contractapi.TransactionContextInterface, querySchedule token.QuerySchedule) (string, error) {
+	return b.scheduleHandler.GetSchedules(ctx, querySchedule)
+}
+
+func (b *baseToken) ExecuteDueSchedules(ctx contractapi.TransactionContextInterface) (int, error) {
+	return b.scheduleHandler.ExecuteDueSchedules(ctx)
+}
+
+func (b *baseToken) CreateSnapshot(ctx contractapi.TransactionContextInterface, createSnapshot token.CreateSnapshot) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "CreateSnapshot", createSnapshot, func() (string, error) {
+		return b.snapshotHandler.CreateSnapshot(ctx, createSnapshot)
+	})
+}
+
+func (b *baseToken) ScanSnapshot(ctx contractapi.TransactionContextInterface, scanSnapshot token.ScanSnapshot) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "ScanSnapshot", scanSnapshot, func() error {
+		return b.snapshotHandler.ScanSnapshot(ctx, scanSnapshot)
+	})
+}
+
+func (b *baseToken) GetSnapshot(ctx contractapi.TransactionContextInterface, querySnapshot token.QuerySnapshot) (string, error) {
+	return b.snapshotHandler.GetSnapshot(ctx, querySnapshot)
+}
+
+func (b *baseToken) DistributeProRata(ctx contractapi.TransactionContextInterface, distributeDto token.DistributeProRata) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "DistributeProRata", distributeDto, func() (string, error) {
+		return b.snapshotHandler.DistributeProRata(ctx, distributeDto)
+	})
+}
+
+func (b *baseToken) ContinueDistribution(ctx contractapi.TransactionContextInterface, continueDto token.ContinueDistribution) error {
+	return b.idempotencyHandler.ExecuteNoResult(ctx, "ContinueDistribution", continueDto, func() error {
+		return b.snapshotHandler.ContinueDistribution(ctx, continueDto)
+	})
+}
+
+func (b *baseToken) GetDistribution(ctx contractapi.TransactionContextInterface, queryDistribution token.QueryDistribution) (string, error) {
+	return b.snapshotHandler.GetDistribution(ctx, queryDistribution)
+}
+
+func (b *baseToken) RevokeVesting(ctx contractapi.TransactionContextInterface, revokeVesting token.RevokeVesting) (string, error) {
+	return b.idempotencyHandler.Execute(ctx, "RevokeVesting", revokeVesting, func() (string, error) {
+		return b.vestingHandler.RevokeVesting(ctx, revokeVesting)
+	})
+}
+
+func (b *baseToken) GetVestingStatus(ctx contractapi.TransactionContextInterface, queryVesting token.QueryVesting) (string, error) {
+	return b.vestingHandler.GetVestingStatus(ctx, queryVesting)
 }
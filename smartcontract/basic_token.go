@@ -39,12 +39,18 @@ type BasicToken interface {
 	// Mint to init base token in the system
 	Mint(ctx contractapi.TransactionContextInterface, mintDto token.MintToken) error
 
+	// MintBatch to mint token to every item of the batch and return the status of every item
+	MintBatch(ctx contractapi.TransactionContextInterface, mintBatch token.MintBatch) (string, error)
+
 	// Burn to delete token in the system
 	Burn(ctx contractapi.TransactionContextInterface, burnDto token.BurnToken) error
 
 	// Transfer amount of tokens from address FromWalletId to address ToWalletId
 	Transfer(ctx contractapi.TransactionContextInterface, transferDto token.TransferToken) error
 
+	// TransferBatch to transfer token from a wallet to every item of the batch and return the status of every item
+	TransferBatch(ctx contractapi.TransactionContextInterface, transferBatch token.TransferBatch) (string, error)
+
 	// CreateTokenType to create new token type in the system
 	CreateTokenType(ctx contractapi.TransactionContextInterface, createTokenTypeDto token.CreateTokenType) (string, error)
 
@@ -60,12 +66,137 @@ type BasicToken interface {
 	// Exchange to swap between token type. Example from Stable token to X token
 	Exchange(ctx contractapi.TransactionContextInterface, exchangeToken token.ExchangeToken) error
 
+	// ProposeSwap to propose a swap to a counterparty wallet, amount of the maker is held until the swap is accepted or canceled
+	ProposeSwap(ctx contractapi.TransactionContextInterface, proposeSwap token.ProposeSwap) (string, error)
+
+	// AcceptSwap the counterparty accepts a swap. It returns id of the exchange transaction
+	AcceptSwap(ctx contractapi.TransactionContextInterface, acceptSwap token.AcceptSwap) (string, error)
+
+	// CancelSwap to cancel an open swap by the maker, or by anyone once it has expired
+	CancelSwap(ctx contractapi.TransactionContextInterface, cancelSwap token.CancelSwap) error
+
+	// GetSwap return the swap and its status
+	GetSwap(ctx contractapi.TransactionContextInterface, querySwap token.QuerySwap) (string, error)
+
+	// SetFeeSchedule to create or update the fee schedule of a transaction type of a token. Only admin is allowed
+	SetFeeSchedule(ctx contractapi.TransactionContextInterface, feeSchedule token.FeeSchedule) (string, error)
+
+	// GetFeeSchedule return the fee schedule of a transaction type of a token
+	GetFeeSchedule(ctx contractapi.TransactionContextInterface, queryFeeSchedule token.QueryFeeSchedule) (string, error)
+
+	// QuoteFee return the fee that a transaction will be charged when it is settled
+	QuoteFee(ctx contractapi.TransactionContextInterface, quoteFee token.QuoteFee) (string, error)
+
 	// Issue to issue new token from stable token
 	Issue(ctx contractapi.TransactionContextInterface, issueDto token.IssueToken) error
 
-	// EnrollToken to register wallet policy use to issue new token
+	// EnrollToken to add issuers and recipients to the policy use to issue or mint new token
 	EnrollToken(ctx contractapi.TransactionContextInterface, enrollmentDto token.Enrollment) error
 
+	// RemoveEnrollment to remove issuers and recipients from the policy use to issue or mint new token
+	RemoveEnrollment(ctx contractapi.TransactionContextInterface, enrollmentDto token.Enrollment) error
+
+	// SetIssuanceQuota to limit the total amount of new token issued or minted to a wallet
+	SetIssuanceQuota(ctx contractapi.TransactionContextInterface, quotaDto token.IssuanceQuota) error
+
+	// GetEnrollment return the policy use to issue or mint new token
+	GetEnrollment(ctx contractapi.TransactionContextInterface, queryDto token.QueryEnrollment) (string, error)
+
 	// TransferSideChain to transfer token from main chain to side chain
 	TransferSideChain(ctx contractapi.TransactionContextInterface, transferChain token.TransferSideChain) error
+
+	// PauseToken to block all submissions and settlements of a token until it is unpaused. Only admin is allowed
+	PauseToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error
+
+	// UnpauseToken to allow transactions of a paused token again. Only admin is allowed
+	UnpauseToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error
+
+	// DeactivateToken to block all submissions and settlements of a token for good. Only admin is allowed
+	DeactivateToken(ctx contractapi.TransactionContextInterface, tokenControl token.TokenControl) error
+
+	// FreezeBalance to block transactions of a token of a wallet with a reason. Only admin is allowed
+	FreezeBalance(ctx contractapi.TransactionContextInterface, freezeBalance token.FreezeBalance) error
+
+	// UnfreezeBalance to lift the freeze of balance of a token of a wallet. Only admin is allowed
+	UnfreezeBalance(ctx contractapi.TransactionContextInterface, unfreezeBalance token.UnfreezeBalance) error
+
+	// SignIntent to sign an open intent of a multi-signature wallet, the transaction is written once threshold of owners sign
+	SignIntent(ctx contractapi.TransactionContextInterface, signIntent token.SignIntent) (string, error)
+
+	// CancelIntent to cancel an open intent of a multi-signature wallet. Only owners are allowed
+	CancelIntent(ctx contractapi.TransactionContextInterface, signIntent token.SignIntent) error
+
+	// GetOpenIntents return open intents of a multi-signature wallet
+	GetOpenIntents(ctx contractapi.TransactionContextInterface, queryIntent token.QueryIntent) (string, error)
+
+	// RegisterWalletKey to set the public key verifying signed transfers of a wallet. Only admin is allowed
+	RegisterWalletKey(ctx contractapi.TransactionContextInterface, walletKey token.WalletKey) error
+
+	// SubmitSignedTransfer to submit a transfer, exchange or nft transfer signed off-chain by the key of the from wallet.
+	// Anyone can relay it, the nonce of the wallet protects against replay
+	SubmitSignedTransfer(ctx contractapi.TransactionContextInterface, signedTransfer token.SignedTransfer) error
+
+	// CancelTransaction to cancel a pending transaction of the spender wallet before it is settled, its held amount is released.
+	// Hashed time-locked claims and refunds, marketplace settlements and accepted swaps can not be canceled
+	CancelTransaction(ctx contractapi.TransactionContextInterface, cancelTransaction token.CancelTransaction) error
+
+	// ReverseTransaction to pay back all or part of a confirmed transfer. Only the receiver wallet or admin is allowed
+	ReverseTransaction(ctx contractapi.TransactionContextInterface, reverseDto token.ReverseTransaction) (string, error)
+
+	// LockTransfer to hold amount of a wallet for another wallet under a hash lock until timeout
+	LockTransfer(ctx contractapi.TransactionContextInterface, lockTransfer token.LockTransfer) (string, error)
+
+	// ClaimTransfer to settle a locked transfer to its receiver with the preimage of the hash lock before timeout
+	ClaimTransfer(ctx contractapi.TransactionContextInterface, claimTransfer token.ClaimTransfer) (string, error)
+
+	// RefundTransfer to settle a locked transfer back to its sender after timeout
+	RefundTransfer(ctx contractapi.TransactionContextInterface, refundTransfer token.RefundTransfer) (string, error)
+
+	// GetLock return the hashed time-locked transfer with its status
+	GetLock(ctx contractapi.TransactionContextInterface, queryLock token.QueryLock) (string, error)
+
+	// CreateSchedule to create a scheduled or recurring transfer from a wallet
+	CreateSchedule(ctx contractapi.TransactionContextInterface, createSchedule token.CreateSchedule) (string, error)
+
+	// PauseSchedule to stop the runs of a schedule until it is resumed
+	PauseSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error
+
+	// ResumeSchedule to continue the runs of a paused schedule, runs missed while paused are skipped
+	ResumeSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error
+
+	// CancelSchedule to stop the runs of a schedule permanently
+	CancelSchedule(ctx contractapi.TransactionContextInterface, updateSchedule token.UpdateSchedule) error
+
+	// GetSchedules return the schedules from a wallet
+	GetSchedules(ctx contractapi.TransactionContextInterface, querySchedule token.QuerySchedule) (string, error)
+
+	// ExecuteDueSchedules to write the pending transfers of due schedules. Only admin is allowed
+	ExecuteDueSchedules(ctx contractapi.TransactionContextInterface) (int, error)
+
+	// CreateSnapshot to start a snapshot of the holder balances of a token and scan its first page. Only admin is allowed
+	CreateSnapshot(ctx contractapi.TransactionContextInterface, createSnapshot token.CreateSnapshot) (string, error)
+
+	// ScanSnapshot to scan the next page of holders of a snapshot until it is completed. Only admin is allowed
+	ScanSnapshot(ctx contractapi.TransactionContextInterface, scanSnapshot token.ScanSnapshot) error
+
+	// GetSnapshot return the snapshot with its status and total balance
+	GetSnapshot(ctx contractapi.TransactionContextInterface, querySnapshot token.QuerySnapshot) (string, error)
+
+	// DistributeProRata to pay an amount to the holders of a completed snapshot in proportion to their balances. Only admin is allowed
+	DistributeProRata(ctx contractapi.TransactionContextInterface, distributeDto token.DistributeProRata) (string, error)
+
+	// ContinueDistribution to pay the next page of holders of a distribution until it is completed. Only admin is allowed
+	ContinueDistribution(ctx contractapi.TransactionContextInterface, continueDto token.ContinueDistribution) error
+
+	// GetDistribution return the distribution with its status and distributed amount
+	GetDistribution(ctx contractapi.TransactionContextInterface, queryDistribution token.QueryDistribution) (string, error)
+
+	// RevokeVesting to take the unvested amount of a vesting grant back to its issuer wallet
+	RevokeVesting(ctx contractapi.TransactionContextInterface, revokeVesting token.RevokeVesting) (string, error)
+
+	// GetVestingStatus return the vested and unvested balance of a token in a wallet with its vesting grants
+	GetVestingStatus(ctx contractapi.TransactionContextInterface, queryVesting token.QueryVesting) (string, error)
+
+	// CleanupIdempotency to delete the expired results of request ids of write operations. Only admin is allowed
+	CleanupIdempotency(ctx contractapi.TransactionContextInterface) (int, error)
 }
@@ -37,4 +37,13 @@ type Erc721 interface {
 
 	// TransferFrom to transfers the ownership of an NFT from one wallet to another wallet
 	TransferFrom(ctx contractapi.TransactionContextInterface, transferNFT nft.TransferNFT) error
+
+	// RoyaltyInfo to get the royalty wallet and royalty amount of an NFT sold at a price
+	RoyaltyInfo(ctx contractapi.TransactionContextInterface, royaltyInfo nft.RoyaltyInfoNFT) (string, error)
+
+	// FractionalizeNft to lock an NFT in a vault and mint fungible shares backed by it to the owner
+	FractionalizeNft(ctx contractapi.TransactionContextInterface, fractionalizeNFT nft.FractionalizeNFT) (string, error)
+
+	// RedeemNft to burn all the shares of a vault and release the NFT to the redeemer
+	RedeemNft(ctx contractapi.TransactionContextInterface, redeemNFT nft.RedeemNFT) error
 }
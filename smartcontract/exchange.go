@@ -22,4 +22,7 @@ package smartcontract
 type Exchange interface {
 	BasicToken
 	Iao
+	OrderBook
+	LiquidityPool
+	Governance
 }
@@ -22,17 +22,26 @@ package exchange
 import (
 	"github.com/Akachain/gringotts/smartcontract"
 	"github.com/Akachain/gringotts/smartcontract/basic"
+	"github.com/Akachain/gringotts/smartcontract/governance"
 	"github.com/Akachain/gringotts/smartcontract/iao"
+	"github.com/Akachain/gringotts/smartcontract/order_book"
+	"github.com/Akachain/gringotts/smartcontract/pool"
 )
 
 type exchange struct {
 	smartcontract.BasicToken
 	smartcontract.Iao
+	smartcontract.OrderBook
+	smartcontract.LiquidityPool
+	smartcontract.Governance
 }
 
 func NewExchange() smartcontract.Exchange {
 	return &exchange{
 		basic.NewBaseToken(),
 		iao.NewIaoSc(),
+		order_book.NewOrderBook(),
+		pool.NewLiquidityPool(),
+		governance.NewGovernance(),
 	}
 }
@@ -0,0 +1,307 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/dto/nft"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/Akachain/gringotts/smartcontract"
+	"github.com/Akachain/gringotts/smartcontract/marketplace"
+	nftSc "github.com/Akachain/gringotts/smartcontract/nft"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// attributeOid is the certificate extension of the fabric ca that carries the attributes of an identity
+var attributeOid = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// marketContract serves the exchange together with the marketplace and the nft contracts in one chaincode
+type marketContract struct {
+	smartcontract.Exchange
+	smartcontract.Marketplace
+	smartcontract.Erc721
+}
+
+func setupMock() (*mock.MockStubExtend, error) {
+	// Initialize MockStubExtend
+	chaincodeName := "EXCHANGE"
+	sc := &marketContract{NewExchange(), marketplace.NewMarketplace(), nftSc.NewNFT()}
+	chaincode, _ := contractapi.NewChaincode(sc)
+	stub := mock.NewMockStubExtend(shimtest.NewMockStub(chaincodeName, chaincode), chaincode, ".")
+
+	// Create a new database, Drop old database
+	db, err := mock.NewCouchDBHandler(true, chaincodeName)
+	if err != nil {
+		return nil, err
+	}
+	stub.SetCouchDBConfiguration(db)
+
+	// Process indexes
+	err = db.ProcessIndexesForChaincodeDeploy("./../../META-INF/statedb/couchdb/indexes/indexPendingTx.json")
+	if err != nil {
+		return nil, err
+	}
+	return stub, nil
+}
+
+type ExchangeSCTestSuite struct {
+	suite.Suite
+	walletFromId string
+	walletToId   string
+	STToken      string
+	ATToken      string
+	admin        []byte
+	stub         *mock.MockStubExtend
+}
+
+func (suite *ExchangeSCTestSuite) SetupTest() {
+	stub, err := setupMock()
+	assert.Nilf(suite.T(), err, "Setup Mock return error not nil")
+	suite.stub = stub
+
+	// the setup and the administration of the system are invoked by an admin
+	suite.admin = suite.newIdentity("admin", true)
+	suite.stub.Creator = suite.admin
+
+	suite.STToken = suite.createTokenType("Stable Token", "ST", "12345678900")
+	suite.ATToken = suite.createTokenType("Asset Token", "AT", "78900")
+
+	suite.walletFromId = suite.createWallet()
+	suite.walletToId = suite.createWallet()
+
+	// the from wallet pays in ST, the to wallet sells AT
+	suite.mint(suite.walletFromId, suite.STToken, "678900")
+	suite.mint(suite.walletToId, suite.ATToken, "10000")
+
+	// accounting balance
+	suite.accountingBalance()
+}
+
+func TestExchangeSCTestSuite(t *testing.T) {
+	suite.Run(t, new(ExchangeSCTestSuite))
+}
+
+func (suite *ExchangeSCTestSuite) createTokenType(name, ticker, maxSupply string) string {
+	tokenType := token.CreateTokenType{
+		Name:        name,
+		TickerToken: ticker,
+		MaxSupply:   maxSupply,
+	}
+	paramByte, _ := json.Marshal(tokenType)
+	tokenId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateTokenType"), paramByte})
+	suite.T().Log(tokenId)
+	assert.NotContains(suite.T(), tokenId, "ErrorCode", "Create token type return error")
+	return tokenId
+}
+
+func (suite *ExchangeSCTestSuite) createWallet() string {
+	wallet := token.CreateWallet{
+		TokenId: suite.STToken,
+		Status:  "A",
+	}
+	paramByte, _ := json.Marshal(wallet)
+	walletId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateWallet"), paramByte})
+	assert.NotContains(suite.T(), walletId, "ErrorCode", "Create wallet return error")
+	return walletId
+}
+
+func (suite *ExchangeSCTestSuite) mint(walletId, tokenId, amount string) {
+	mintDto := token.MintToken{
+		WalletId: walletId,
+		TokenId:  tokenId,
+		Amount:   amount,
+	}
+	paramByte, _ := json.Marshal(mintDto)
+	mintRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Mint"), paramByte})
+	assert.Empty(suite.T(), mintRes, "Mint invoke return err")
+}
+
+// mintNft mint a nft token to owner wallet, the royalty is paid to royalty wallet when it is set
+func (suite *ExchangeSCTestSuite) mintNft(ownerWalletId, royaltyWalletId string, royaltyBps int64) string {
+	mintDto := nft.MintNFT{
+		GS1Number:       "GS1-" + ownerWalletId,
+		OwnerWalletId:   ownerWalletId,
+		HashData:        "hash",
+		Metadata:        "metadata",
+		RoyaltyWalletId: royaltyWalletId,
+		RoyaltyBps:      royaltyBps,
+	}
+	paramByte, _ := json.Marshal(mintDto)
+	nftTokenId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("MintNft"), paramByte})
+	suite.T().Log(nftTokenId)
+	assert.NotContains(suite.T(), nftTokenId, "ErrorCode", "Mint nft return error")
+	return nftTokenId
+}
+
+// listNft list the nft token of seller wallet for price in ST and return the id of the listing
+func (suite *ExchangeSCTestSuite) listNft(sellerWalletId, nftTokenId, price string, expiry int64) string {
+	listDto := exchangeDto.ListNft{
+		SellerWalletId: sellerWalletId,
+		NftTokenId:     nftTokenId,
+		PriceTokenId:   suite.STToken,
+		Price:          price,
+		Expiry:         expiry,
+	}
+	paramByte, _ := json.Marshal(listDto)
+	listingId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ListNft"), paramByte})
+	suite.T().Log(listingId)
+	assert.NotContains(suite.T(), listingId, "ErrorCode", "List nft return error")
+	return listingId
+}
+
+// makeOffer offer price in ST for the nft token and return the id of the offer
+func (suite *ExchangeSCTestSuite) makeOffer(buyerWalletId, nftTokenId, price string, expiry int64) string {
+	offerDto := exchangeDto.MakeOffer{
+		BuyerWalletId: buyerWalletId,
+		NftTokenId:    nftTokenId,
+		PriceTokenId:  suite.STToken,
+		Price:         price,
+		Expiry:        expiry,
+	}
+	paramByte, _ := json.Marshal(offerDto)
+	offerId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("MakeOffer"), paramByte})
+	suite.T().Log(offerId)
+	assert.NotContains(suite.T(), offerId, "ErrorCode", "Make offer return error")
+	return offerId
+}
+
+// createPool create the ST/AT pool without fee and return its id
+func (suite *ExchangeSCTestSuite) createPool() string {
+	poolDto := exchangeDto.CreatePool{
+		TokenA: suite.STToken,
+		TokenB: suite.ATToken,
+	}
+	paramByte, _ := json.Marshal(poolDto)
+	poolId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreatePool"), paramByte})
+	suite.T().Log(poolId)
+	assert.NotContains(suite.T(), poolId, "ErrorCode", "Create pool return error")
+	return poolId
+}
+
+func (suite *ExchangeSCTestSuite) ownerOf(nftTokenId string) string {
+	ownerDto := nft.OwnerNFT{NFTTokenId: nftTokenId}
+	paramByte, _ := json.Marshal(ownerDto)
+	return mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("OwnerOf"), paramByte})
+}
+
+// newIdentity return the serialized client identity of a self-signed certificate, admin identity has the admin attribute
+func (suite *ExchangeSCTestSuite) newIdentity(name string, admin bool) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(suite.T(), err, "Generate key failed")
+
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name, Organization: []string{"gringotts"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if admin {
+		template.ExtraExtensions = []pkix.Extension{{
+			Id:    attributeOid,
+			Value: []byte(`{"attrs":{"gringotts.admin":"true"}}`),
+		}}
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(suite.T(), err, "Create certificate failed")
+
+	identity, err := msp.NewSerializedIdentity("Org1MSP", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	assert.Nil(suite.T(), err, "Serialize identity failed")
+	return identity
+}
+
+// getTransaction read the transaction from the ledger
+func (suite *ExchangeSCTestSuite) getTransaction(txId string) *entity.Transaction {
+	txEntity := new(entity.Transaction)
+	suite.getDocument(doc.Transactions, helper.TransactionKey(txId), txEntity)
+	return txEntity
+}
+
+// getDocument read the document of the key from the ledger
+func (suite *ExchangeSCTestSuite) getDocument(docType string, key []string, document interface{}) {
+	compositeKey, _ := suite.stub.CreateCompositeKey(docType, key)
+	state, err := suite.stub.GetState(compositeKey)
+	assert.Nilf(suite.T(), err, "Get %s failed", docType)
+	assert.Nilf(suite.T(), json.Unmarshal(state, document), "Parse %s failed", docType)
+}
+
+// identityId return the client identity id of the serialized identity
+func (suite *ExchangeSCTestSuite) identityId(identity []byte) string {
+	creator := suite.stub.Creator
+	defer func() { suite.stub.Creator = creator }()
+
+	suite.stub.Creator = identity
+	id, err := cid.GetID(suite.stub)
+	assert.Nil(suite.T(), err, "Get id of identity failed")
+	return id
+}
+
+func (suite *ExchangeSCTestSuite) accountingBalance() {
+	lstTx := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetAccountingTx")})
+	suite.T().Log(lstTx)
+
+	lstTx = strings.ReplaceAll(lstTx, "[", "")
+	lstTx = strings.ReplaceAll(lstTx, "]", "")
+	lstTx = strings.ReplaceAll(lstTx, "\"", "")
+	if lstTx == "" {
+		return
+	}
+
+	// accounting
+	accountingDto := token.AccountingBalance{
+		TxId: strings.Split(lstTx, ","),
+	}
+	paramByte, _ := json.Marshal(accountingDto)
+	accountingRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CalculateBalance"), paramByte})
+	assert.Empty(suite.T(), accountingRes, "CalculateBalance invoke return err")
+}
+
+func (suite *ExchangeSCTestSuite) getBalance(walletId, tokenId string) string {
+	balanceDto := token.Balance{
+		WalletId: walletId,
+		TokenId:  tokenId,
+	}
+	paramByte, _ := json.Marshal(balanceDto)
+	balanceOf := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetBalance"), paramByte})
+	suite.T().Log(balanceOf)
+
+	return balanceOf
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	proposalDto "github.com/Akachain/gringotts/dto/proposal"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/proposal"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+func (suite *ExchangeSCTestSuite) TestGovernance_ApprovalPolicyChange() {
+	approvers := [][]byte{suite.newIdentity("approver-a", false), suite.newIdentity("approver-b", false)}
+	suite.setApprovalPolicy(proposal.Mint, "", approvers, 2)
+
+	// the admin alone can neither lift the policy nor override it for a token
+	policyDto := proposalDto.ApprovalPolicy{Operation: proposal.Mint, Approvers: []string{}}
+	paramByte, _ := json.Marshal(policyDto)
+	policyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetApprovalPolicy"), paramByte})
+	suite.T().Log(policyRes)
+	assert.Contains(suite.T(), policyRes, string(errorcode.BizApprovalRequired), "Policy is lifted without approval")
+
+	policyDto.TokenId = suite.STToken
+	paramByte, _ = json.Marshal(policyDto)
+	policyRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetApprovalPolicy"), paramByte})
+	suite.T().Log(policyRes)
+	assert.Contains(suite.T(), policyRes, string(errorcode.BizApprovalRequired), "Policy is overridden for token without approval")
+
+	// the approvers of the policy approve its change
+	proposalId := suite.createProposal(proposal.SetApprovalPolicy, proposalDto.ApprovalPolicy{Operation: proposal.Mint, Approvers: []string{}})
+	suite.approveProposal(approvers[0], proposalId)
+	suite.approveProposal(approvers[1], proposalId)
+
+	policy := suite.getApprovalPolicy(proposal.Mint, "")
+	assert.Equal(suite.T(), 0, policy.Threshold, "Approved policy change is not applied")
+
+	mintDto := token.MintToken{WalletId: suite.walletFromId, TokenId: suite.STToken, Amount: "100"}
+	paramByte, _ = json.Marshal(mintDto)
+	mintRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Mint"), paramByte})
+	assert.Empty(suite.T(), mintRes, "Mint without approval policy return error")
+}
+
+func (suite *ExchangeSCTestSuite) TestGovernance_MintBatchProposal() {
+	approver := suite.newIdentity("approver-a", false)
+	suite.setApprovalPolicy(proposal.Mint, suite.STToken, [][]byte{approver}, 1)
+
+	mintBatch := token.MintBatch{
+		TokenId: suite.STToken,
+		Items: []token.BatchItem{
+			{WalletId: suite.walletFromId, Amount: "100"},
+			{WalletId: suite.walletToId, Amount: "200"},
+		},
+	}
+	paramByte, _ := json.Marshal(mintBatch)
+	batchRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("MintBatch"), paramByte})
+	suite.T().Log(batchRes)
+	assert.Contains(suite.T(), batchRes, string(errorcode.BizApprovalRequired), "Batch is minted without approval")
+
+	// the batch is approved under the mint policy of the token
+	proposalId := suite.createProposal(proposal.MintBatch, mintBatch)
+	batchRes = suite.approveProposal(approver, proposalId)
+	assert.Contains(suite.T(), batchRes, suite.walletToId, "Approved batch return no item result")
+
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "679000", suite.getBalance(suite.walletFromId, suite.STToken), "Balance of from wallet is not minted")
+	assert.Equal(suite.T(), "200", suite.getBalance(suite.walletToId, suite.STToken), "Balance of to wallet is not minted")
+}
+
+func (suite *ExchangeSCTestSuite) TestGovernance_DuplicateApprover() {
+	approverId := suite.identityId(suite.newIdentity("approver-a", false))
+	policyDto := proposalDto.ApprovalPolicy{
+		Operation: proposal.Mint,
+		Approvers: []string{approverId, approverId},
+		Threshold: 2,
+	}
+	paramByte, _ := json.Marshal(policyDto)
+	policyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetApprovalPolicy"), paramByte})
+	suite.T().Log(policyRes)
+	assert.Contains(suite.T(), policyRes, string(errorcode.InvalidParam), "Policy with duplicated approver is accepted")
+
+	queryDto := proposalDto.QueryApprovalPolicy{Operation: proposal.Mint}
+	paramByte, _ = json.Marshal(queryDto)
+	policyRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetApprovalPolicy"), paramByte})
+	assert.Contains(suite.T(), policyRes, string(errorcode.BizUnableGetApprovalPolicy), "Policy with duplicated approver is stored")
+}
+
+func (suite *ExchangeSCTestSuite) TestGovernance_ProposalLifecycle() {
+	approvers := [][]byte{
+		suite.newIdentity("approver-a", false),
+		suite.newIdentity("approver-b", false),
+		suite.newIdentity("approver-c", false),
+	}
+	suite.setApprovalPolicy(proposal.Mint, suite.STToken, approvers, 2)
+
+	mintDto := token.MintToken{WalletId: suite.walletToId, TokenId: suite.STToken, Amount: "100"}
+	proposalId := suite.createProposal(proposal.Mint, mintDto)
+
+	// only the approvers of the policy vote, and each of them once
+	suite.stub.Creator = suite.newIdentity("outsider", false)
+	voteRes := suite.voteProposal("ApproveProposal", proposalId)
+	suite.stub.Creator = suite.admin
+	assert.Contains(suite.T(), voteRes, string(errorcode.BizProposalNotApprover), "Proposal is approved by outsider")
+
+	suite.approveProposal(approvers[0], proposalId)
+	assert.EqualValues(suite.T(), proposal.Pending, suite.getProposal(proposalId).Status, "Proposal is approved under threshold")
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "0", suite.getBalance(suite.walletToId, suite.STToken), "Mint is executed under threshold")
+
+	suite.stub.Creator = approvers[0]
+	voteRes = suite.voteProposal("ApproveProposal", proposalId)
+	suite.stub.Creator = suite.admin
+	assert.Contains(suite.T(), voteRes, string(errorcode.BizProposalAlreadyVoted), "Approver vote twice")
+
+	// the approval reaching the threshold executes the operation
+	suite.approveProposal(approvers[1], proposalId)
+	executed := suite.getProposal(proposalId)
+	assert.EqualValues(suite.T(), proposal.Executed, executed.Status, "Approved proposal is not executed")
+	assert.Len(suite.T(), executed.Approvals, 2, "Approvals are not recorded")
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "100", suite.getBalance(suite.walletToId, suite.STToken), "Approved mint is not executed")
+
+	suite.stub.Creator = approvers[2]
+	voteRes = suite.voteProposal("ApproveProposal", proposalId)
+	suite.stub.Creator = suite.admin
+	assert.Contains(suite.T(), voteRes, string(errorcode.BizProposalInvalidStatus), "Executed proposal is approved again")
+
+	// the proposal is rejected once the approvers left can not reach the threshold
+	rejectedId := suite.createProposal(proposal.Mint, mintDto)
+	suite.stub.Creator = approvers[0]
+	assert.Empty(suite.T(), suite.voteProposal("RejectProposal", rejectedId), "Reject proposal return error")
+	suite.stub.Creator = suite.admin
+	assert.EqualValues(suite.T(), proposal.Pending, suite.getProposal(rejectedId).Status, "Proposal is rejected while threshold is reachable")
+
+	suite.stub.Creator = approvers[1]
+	assert.Empty(suite.T(), suite.voteProposal("RejectProposal", rejectedId), "Reject proposal return error")
+	suite.stub.Creator = approvers[2]
+	voteRes = suite.voteProposal("ApproveProposal", rejectedId)
+	suite.stub.Creator = suite.admin
+	assert.EqualValues(suite.T(), proposal.Rejected, suite.getProposal(rejectedId).Status, "Proposal is not rejected")
+	assert.Contains(suite.T(), voteRes, string(errorcode.BizProposalInvalidStatus), "Rejected proposal is approved")
+
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "100", suite.getBalance(suite.walletToId, suite.STToken), "Rejected mint is executed")
+}
+
+func (suite *ExchangeSCTestSuite) TestGovernance_ProposalExpired() {
+	approver := suite.newIdentity("approver-a", false)
+	policyDto := proposalDto.ApprovalPolicy{
+		Operation: proposal.Mint,
+		TokenId:   suite.STToken,
+		Approvers: []string{suite.identityId(approver)},
+		Threshold: 1,
+		Ttl:       1,
+	}
+	paramByte, _ := json.Marshal(policyDto)
+	policyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetApprovalPolicy"), paramByte})
+	assert.Empty(suite.T(), policyRes, "Set approval policy return error")
+
+	proposalId := suite.createProposal(proposal.Mint, token.MintToken{WalletId: suite.walletToId, TokenId: suite.STToken, Amount: "100"})
+	time.Sleep(2 * time.Second)
+
+	assert.EqualValues(suite.T(), proposal.Expired, suite.getProposal(proposalId).Status, "Proposal past its expiry is not expired")
+	suite.stub.Creator = approver
+	voteRes := suite.voteProposal("ApproveProposal", proposalId)
+	suite.stub.Creator = suite.admin
+	assert.Contains(suite.T(), voteRes, string(errorcode.BizProposalExpired), "Expired proposal is approved")
+}
+
+// setApprovalPolicy set the policy of operation as admin
+func (suite *ExchangeSCTestSuite) setApprovalPolicy(operation proposal.Operation, tokenId string, approvers [][]byte, threshold int) {
+	approverIds := make([]string, 0, len(approvers))
+	for _, approver := range approvers {
+		approverIds = append(approverIds, suite.identityId(approver))
+	}
+
+	policyDto := proposalDto.ApprovalPolicy{
+		Operation: operation,
+		TokenId:   tokenId,
+		Approvers: approverIds,
+		Threshold: threshold,
+	}
+	paramByte, _ := json.Marshal(policyDto)
+	policyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetApprovalPolicy"), paramByte})
+	assert.Empty(suite.T(), policyRes, "Set approval policy return error")
+}
+
+func (suite *ExchangeSCTestSuite) getApprovalPolicy(operation proposal.Operation, tokenId string) *entity.ApprovalPolicy {
+	queryDto := proposalDto.QueryApprovalPolicy{Operation: operation, TokenId: tokenId}
+	paramByte, _ := json.Marshal(queryDto)
+	policyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetApprovalPolicy"), paramByte})
+	suite.T().Log(policyRes)
+
+	policy := new(entity.ApprovalPolicy)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(policyRes), policy), "Parse approval policy failed")
+	return policy
+}
+
+// createProposal propose the operation with its dto as admin and return the id of the proposal
+func (suite *ExchangeSCTestSuite) createProposal(operation proposal.Operation, payload interface{}) string {
+	payloadByte, _ := json.Marshal(payload)
+	createDto := proposalDto.CreateProposal{Operation: operation, Payload: string(payloadByte)}
+	paramByte, _ := json.Marshal(createDto)
+	proposalId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateProposal"), paramByte})
+	suite.T().Log(proposalId)
+	assert.NotContains(suite.T(), proposalId, "Error", "Create proposal return error")
+	return proposalId
+}
+
+// approveProposal approve the proposal as approver and return the result of the executed operation
+func (suite *ExchangeSCTestSuite) approveProposal(approver []byte, proposalId string) string {
+	suite.stub.Creator = approver
+	defer func() { suite.stub.Creator = suite.admin }()
+
+	voteDto := proposalDto.VoteProposal{ProposalId: proposalId}
+	paramByte, _ := json.Marshal(voteDto)
+	approveRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ApproveProposal"), paramByte})
+	suite.T().Log(approveRes)
+	assert.NotContains(suite.T(), approveRes, "Error", "Approve proposal return error")
+	return approveRes
+}
+
+// voteProposal approve or reject the proposal as the current creator and return the response
+func (suite *ExchangeSCTestSuite) voteProposal(function, proposalId string) string {
+	voteDto := proposalDto.VoteProposal{ProposalId: proposalId}
+	paramByte, _ := json.Marshal(voteDto)
+	voteRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte(function), paramByte})
+	suite.T().Log(voteRes)
+	return voteRes
+}
+
+func (suite *ExchangeSCTestSuite) getProposal(proposalId string) *entity.Proposal {
+	queryDto := proposalDto.VoteProposal{ProposalId: proposalId}
+	paramByte, _ := json.Marshal(queryDto)
+	proposalRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetProposal"), paramByte})
+	suite.T().Log(proposalRes)
+
+	proposalEntity := new(entity.Proposal)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(proposalRes), proposalEntity), "Parse proposal failed")
+	return proposalEntity
+}
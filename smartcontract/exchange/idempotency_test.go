@@ -0,0 +1,101 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	"github.com/Akachain/gringotts/dto"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ExchangeSCTestSuite) TestIdempotency_Replay() {
+	// a retry of the create returns the wallet of the first request
+	walletDto := token.CreateWallet{TokenId: suite.STToken, Status: "A", Idempotency: dto.Idempotency{RequestId: "wallet-1"}}
+	paramByte, _ := json.Marshal(walletDto)
+	walletId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateWallet"), paramByte})
+	retryWalletId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateWallet"), paramByte})
+	assert.NotEmpty(suite.T(), walletId, "Create wallet return empty")
+	assert.Equal(suite.T(), walletId, retryWalletId, "Retry of create wallet return another wallet")
+
+	// a retry of the transfer is not written twice
+	transferDto := suite.transferRequest("transfer-1", "100")
+	assert.Empty(suite.T(), suite.invokeTransfer(transferDto), "Transfer return error")
+	assert.Empty(suite.T(), suite.invokeTransfer(transferDto), "Retry of transfer return error")
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "678800", suite.getBalance(suite.walletFromId, suite.STToken), "Retry of transfer is written twice")
+	assert.Equal(suite.T(), "100", suite.getBalance(suite.walletToId, suite.STToken), "Retry of transfer is written twice")
+
+	// the request id can not be reused with another payload
+	transferRes := suite.invokeTransfer(suite.transferRequest("transfer-1", "200"))
+	suite.T().Log(transferRes)
+	assert.Contains(suite.T(), transferRes, string(errorcode.BizIdempotencyConflict), "Request id is reused by another payload")
+}
+
+func (suite *ExchangeSCTestSuite) TestIdempotency_Cleanup() {
+	assert.Empty(suite.T(), suite.invokeTransfer(suite.transferRequest("transfer-1", "100")), "Transfer return error")
+	assert.Empty(suite.T(), suite.invokeTransfer(suite.transferRequest("transfer-2", "100")), "Transfer return error")
+
+	// expire the record of the first request
+	record := new(entity.Idempotency)
+	suite.getDocument(doc.Idempotency, helper.IdempotencyKey("transfer-1"), record)
+	record.Expiry = 1
+	recordByte, _ := json.Marshal(record)
+	recordKey, _ := suite.stub.CreateCompositeKey(doc.Idempotency, helper.IdempotencyKey("transfer-1"))
+	assert.Nil(suite.T(), suite.stub.PutState(recordKey, recordByte), "Expire idempotency record failed")
+
+	suite.stub.Creator = suite.newIdentity("user", false)
+	cleanupRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CleanupIdempotency")})
+	suite.stub.Creator = suite.admin
+	suite.T().Log(cleanupRes)
+	assert.Contains(suite.T(), cleanupRes, string(errorcode.BizNotAdmin), "Cleanup is allowed to non admin")
+
+	// the mock stub does not delete from couch db, so only the count of deleted records is checked
+	cleanupRes = mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CleanupIdempotency")})
+	assert.Equal(suite.T(), "1", cleanupRes, "Cleanup does not delete only the expired record")
+
+	// the request id of the expired record is free again, the live one is still replayed
+	assert.Empty(suite.T(), suite.invokeTransfer(suite.transferRequest("transfer-1", "200")), "Request id of expired record is not reusable")
+	transferRes := suite.invokeTransfer(suite.transferRequest("transfer-2", "200"))
+	assert.Contains(suite.T(), transferRes, string(errorcode.BizIdempotencyConflict), "Live record is deleted")
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "400", suite.getBalance(suite.walletToId, suite.STToken), "Transfers are not written")
+}
+
+// transferRequest return a transfer of ST from the from wallet to the to wallet with the request id
+func (suite *ExchangeSCTestSuite) transferRequest(requestId, amount string) token.TransferToken {
+	return token.TransferToken{
+		FromWalletId: suite.walletFromId,
+		ToWalletId:   suite.walletToId,
+		TokenId:      suite.STToken,
+		Amount:       amount,
+		Idempotency:  dto.Idempotency{RequestId: requestId},
+	}
+}
+
+func (suite *ExchangeSCTestSuite) invokeTransfer(transferDto token.TransferToken) string {
+	paramByte, _ := json.Marshal(transferDto)
+	return mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Transfer"), paramByte})
+}
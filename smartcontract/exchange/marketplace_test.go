@@ -0,0 +1,269 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	nftDto "github.com/Akachain/gringotts/dto/nft"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/fee"
+	"github.com/Akachain/gringotts/glossary/listing"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_ListingSettlement() {
+	royaltyWalletId := suite.createWallet()
+	nftTokenId := suite.mintNft(suite.walletToId, royaltyWalletId, 500)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", time.Now().Add(time.Hour).Unix())
+
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ := json.Marshal(buyDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Buy listing return error")
+	assert.EqualValues(suite.T(), listing.Filled, suite.getListing(listingId).Status, "Bought listing is not filled")
+
+	// a filled listing can not be bought again
+	buyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	assert.Contains(suite.T(), buyRes, "ErrorCode", "Filled listing is bought again")
+
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(txId).Status, "Settlement is not confirmed")
+	assert.Equal(suite.T(), suite.walletFromId, suite.ownerOf(nftTokenId), "Nft is not moved to buyer")
+	assert.Equal(suite.T(), "677900", suite.getBalance(suite.walletFromId, suite.STToken), "Price is not paid by buyer")
+	assert.Equal(suite.T(), "950", suite.getBalance(suite.walletToId, suite.STToken), "Price without royalty is not paid to seller")
+	assert.Equal(suite.T(), "50", suite.getBalance(royaltyWalletId, suite.STToken), "Royalty is not paid")
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_CancelListing() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	nftTokenId := suite.mintNft(suite.walletToId, "", 0)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+
+	// the nft is held by the open listing
+	listDto := exchangeDto.ListNft{
+		SellerWalletId: suite.walletToId,
+		NftTokenId:     nftTokenId,
+		PriceTokenId:   suite.STToken,
+		Price:          "1000",
+		Expiry:         expiry,
+	}
+	paramByte, _ := json.Marshal(listDto)
+	listRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ListNft"), paramByte})
+	assert.Contains(suite.T(), listRes, "ErrorCode", "Listed nft is listed again")
+
+	cancelDto := exchangeDto.CancelListing{ListingId: listingId, SellerWalletId: suite.walletToId}
+	paramByte, _ = json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelListing"), paramByte})
+	assert.Empty(suite.T(), cancelRes, "Cancel listing return error")
+	assert.EqualValues(suite.T(), listing.Canceled, suite.getListing(listingId).Status, "Listing is not canceled")
+
+	// a canceled listing can not be bought and its nft can be listed again
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ = json.Marshal(buyDto)
+	buyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	assert.Contains(suite.T(), buyRes, "ErrorCode", "Canceled listing is bought")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Price is held for canceled listing")
+	suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_OfferSettlement() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	nftTokenId := suite.mintNft(suite.walletToId, "", 0)
+	offerId := suite.makeOffer(suite.walletFromId, nftTokenId, "2000", expiry)
+	canceledOfferId := suite.makeOffer(suite.walletFromId, nftTokenId, "1500", expiry)
+	assert.Equal(suite.T(), "675400", suite.getBalance(suite.walletFromId, suite.STToken), "Price of offers is not held")
+
+	// the canceled offer gives back its held price
+	cancelDto := exchangeDto.CancelOffer{OfferId: canceledOfferId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ := json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelOffer"), paramByte})
+	assert.Empty(suite.T(), cancelRes, "Cancel offer return error")
+	assert.EqualValues(suite.T(), listing.Canceled, suite.getOffer(canceledOfferId).Status, "Offer is not canceled")
+	assert.Equal(suite.T(), "676900", suite.getBalance(suite.walletFromId, suite.STToken), "Price of canceled offer is not released")
+
+	acceptDto := exchangeDto.AcceptOffer{OfferId: offerId, SellerWalletId: suite.walletToId}
+	paramByte, _ = json.Marshal(acceptDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AcceptOffer"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Accept offer return error")
+	assert.EqualValues(suite.T(), listing.Filled, suite.getOffer(offerId).Status, "Accepted offer is not filled")
+
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(txId).Status, "Settlement is not confirmed")
+	assert.Equal(suite.T(), suite.walletFromId, suite.ownerOf(nftTokenId), "Nft is not moved to buyer")
+	assert.Equal(suite.T(), "676900", suite.getBalance(suite.walletFromId, suite.STToken), "Price is not paid by buyer")
+	assert.Equal(suite.T(), "2000", suite.getBalance(suite.walletToId, suite.STToken), "Price is not paid to seller")
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_SettlementOfPausedToken() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	nftTokenId := suite.mintNft(suite.walletToId, "", 0)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ := json.Marshal(buyDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Buy listing return error")
+	assert.Equal(suite.T(), "677900", suite.getBalance(suite.walletFromId, suite.STToken), "Price is not held from buyer")
+
+	// the price token is paused while the settlement is pending
+	pauseDto := token.TokenControl{TokenId: suite.STToken}
+	paramByte, _ = json.Marshal(pauseDto)
+	pauseRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("PauseToken"), paramByte})
+	assert.Empty(suite.T(), pauseRes, "Pause token return error")
+
+	suite.accountingBalance()
+
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(txId).Status, "Settlement of paused token is not rejected")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Held price is not released to buyer")
+	assert.Equal(suite.T(), suite.walletToId, suite.ownerOf(nftTokenId), "Nft is moved by rejected settlement")
+
+	// the nft is unlocked and can be listed again
+	mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("UnpauseToken"), paramByte})
+	suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_RoyaltyInfo() {
+	nftTokenId := suite.mintNft(suite.walletToId, suite.walletFromId, 250)
+
+	// the price is in the unit of TransferFrom, the royalty amount in base unit
+	royaltyDto := nftDto.RoyaltyInfoNFT{NftTokenId: nftTokenId, Price: 10.5}
+	paramByte, _ := json.Marshal(royaltyDto)
+	royaltyRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("RoyaltyInfo"), paramByte})
+	suite.T().Log(royaltyRes)
+
+	royaltyInfo := new(nftDto.RoyaltyInfo)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(royaltyRes), royaltyInfo), "Parse royalty info failed")
+	assert.Equal(suite.T(), suite.walletFromId, royaltyInfo.RoyaltyWalletId, "Royalty wallet is wrong")
+	assert.Equal(suite.T(), "26250000", royaltyInfo.RoyaltyAmount, "Royalty amount is wrong")
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_SettlementNotCancelable() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	nftTokenId := suite.mintNft(suite.walletToId, "", 0)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ := json.Marshal(buyDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Buy listing return error")
+
+	cancelDto := token.CancelTransaction{WalletId: suite.walletFromId, TxId: txId}
+	paramByte, _ = json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelTransaction"), paramByte})
+	suite.T().Log(cancelRes)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizTransactionNotCancelable), "Settlement is canceled by the buyer")
+
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(txId).Status, "Settlement is not confirmed")
+	assert.Equal(suite.T(), suite.walletFromId, suite.ownerOf(nftTokenId), "Nft is not moved to buyer")
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_SettlementFeeNotCovered() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	feeWalletId := suite.createWallet()
+	buyerWalletId := suite.createWallet()
+	suite.mint(buyerWalletId, suite.STToken, "1000")
+	suite.accountingBalance()
+
+	// the buyer holds its whole balance for the price, nothing is left in spot for the fee
+	nftTokenId := suite.mintNft(suite.walletToId, "", 0)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: buyerWalletId}
+	paramByte, _ := json.Marshal(buyDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Buy listing return error")
+
+	feeDto := token.FeeSchedule{
+		TokenId:    suite.STToken,
+		TxType:     transaction.NftSettlement,
+		FeeType:    fee.Flat,
+		FlatAmount: "10",
+		Tiers:      []token.FeeTier{},
+		FeeWallet:  feeWalletId,
+	}
+	paramByte, _ = json.Marshal(feeDto)
+	feeRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SetFeeSchedule"), paramByte})
+	suite.T().Log(feeRes)
+	assert.NotContains(suite.T(), feeRes, "Error", "Set fee schedule return error")
+
+	suite.accountingBalance()
+
+	// the fee is not taken from the held price, the settlement is rejected and the price released
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(txId).Status, "Settlement without fee is not rejected")
+	assert.Equal(suite.T(), "1000", suite.getBalance(buyerWalletId, suite.STToken), "Held price is not released to buyer")
+	assert.Equal(suite.T(), "0", suite.getBalance(feeWalletId, suite.STToken), "Fee is charged for rejected settlement")
+	assert.Equal(suite.T(), suite.walletToId, suite.ownerOf(nftTokenId), "Nft is moved by rejected settlement")
+}
+
+func (suite *ExchangeSCTestSuite) TestMarketplace_SettlementRoyaltyWalletMissing() {
+	expiry := time.Now().Add(time.Hour).Unix()
+	royaltyWalletId := suite.createWallet()
+	nftTokenId := suite.mintNft(suite.walletToId, royaltyWalletId, 1000)
+	listingId := suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+
+	buyDto := exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: suite.walletFromId}
+	paramByte, _ := json.Marshal(buyDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("BuyListing"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "ErrorCode", "Buy listing return error")
+
+	// the royalty wallet is gone before the settlement is accounted
+	updateDto := token.UpdateWallet{WalletId: royaltyWalletId, Status: glossary.InActive}
+	paramByte, _ = json.Marshal(updateDto)
+	updateRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("UpdateWallet"), paramByte})
+	assert.Empty(suite.T(), updateRes, "Update wallet return error")
+
+	suite.accountingBalance()
+
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(txId).Status, "Settlement without royalty wallet is not rejected")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Held price is not released to buyer")
+	assert.Equal(suite.T(), "0", suite.getBalance(suite.walletToId, suite.STToken), "Seller is credited by rejected settlement")
+	assert.Equal(suite.T(), "0", suite.getBalance(royaltyWalletId, suite.STToken), "Royalty is credited by rejected settlement")
+	assert.Equal(suite.T(), suite.walletToId, suite.ownerOf(nftTokenId), "Nft is moved by rejected settlement")
+
+	// the nft is unlocked and can be listed again
+	suite.listNft(suite.walletToId, nftTokenId, "1000", expiry)
+}
+
+func (suite *ExchangeSCTestSuite) getListing(listingId string) *entity.Exchange {
+	listingEntity := new(entity.Exchange)
+	suite.getDocument(doc.Exchange, helper.ExchangeKey(listingId), listingEntity)
+	return listingEntity
+}
+
+func (suite *ExchangeSCTestSuite) getOffer(offerId string) *entity.Offer {
+	offerEntity := new(entity.Offer)
+	suite.getDocument(doc.Offer, helper.OfferKey(offerId), offerEntity)
+	return offerEntity
+}
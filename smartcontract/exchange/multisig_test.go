@@ -0,0 +1,106 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/dto/nft"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+// TestExchange_MultiSigRejected check that every entry point holding or debiting a wallet refuses a
+// multi-signature wallet, even when it is invoked by an owner of the wallet
+func (suite *ExchangeSCTestSuite) TestExchange_MultiSigRejected() {
+	owner := suite.newIdentity("owner", false)
+	walletId := suite.createMultiSigWallet([][]byte{owner}, 1)
+	expiry := time.Now().Add(time.Hour).Unix()
+
+	// the counterparties of the multi-signature wallet
+	listingNftId := suite.mintNft(suite.walletToId, "", 0)
+	listingId := suite.listNft(suite.walletToId, listingNftId, "1000", expiry)
+
+	ownedNftId := suite.mintNft(walletId, "", 0)
+	offerId := suite.makeOffer(suite.walletFromId, ownedNftId, "1000", expiry)
+	poolId := suite.createPool()
+
+	cases := []struct {
+		name  string
+		fn    string
+		param interface{}
+	}{
+		{"ListNft", "ListNft", exchangeDto.ListNft{SellerWalletId: walletId, NftTokenId: ownedNftId,
+			PriceTokenId: suite.STToken, Price: "1000", Expiry: expiry}},
+		{"BuyListing", "BuyListing", exchangeDto.BuyListing{ListingId: listingId, BuyerWalletId: walletId}},
+		{"MakeOffer", "MakeOffer", exchangeDto.MakeOffer{BuyerWalletId: walletId, NftTokenId: listingNftId,
+			PriceTokenId: suite.STToken, Price: "1000", Expiry: expiry}},
+		{"AcceptOffer", "AcceptOffer", exchangeDto.AcceptOffer{OfferId: offerId, SellerWalletId: walletId}},
+		{"PlaceLimitOrder", "PlaceLimitOrder", exchangeDto.PlaceLimitOrder{WalletId: walletId, BaseTokenId: suite.ATToken,
+			QuoteTokenId: suite.STToken, Side: order.Buy, Amount: "10", Price: "2"}},
+		{"AddLiquidity", "AddLiquidity", exchangeDto.AddLiquidity{PoolId: poolId, WalletId: walletId, AmountA: "100", AmountB: "100"}},
+		{"RemoveLiquidity", "RemoveLiquidity", exchangeDto.RemoveLiquidity{PoolId: poolId, WalletId: walletId, Liquidity: "10"}},
+		{"SwapExactIn", "SwapExactIn", exchangeDto.SwapExactIn{PoolId: poolId, WalletId: walletId, FromTokenId: suite.STToken,
+			AmountIn: "100", MinAmountOut: "1"}},
+		{"TransferFrom", "TransferFrom", nft.TransferNFT{FromWalletId: walletId, ToWalletId: suite.walletToId,
+			FromTokenId: suite.STToken, NftTokenId: listingNftId, Price: 10}},
+		{"RedeemNft", "RedeemNft", nft.RedeemNFT{NftTokenId: ownedNftId, RedeemerWalletId: walletId}},
+	}
+
+	suite.stub.Creator = owner
+	for _, c := range cases {
+		paramByte, _ := json.Marshal(c.param)
+		res := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte(c.fn), paramByte})
+		suite.T().Log(res)
+		assert.Containsf(suite.T(), res, string(errorcode.BizWalletMultiSig), "%s of multi-signature wallet must be rejected", c.name)
+	}
+	suite.stub.Creator = suite.admin
+
+	// nothing is held from the multi-signature wallet
+	suite.accountingBalance()
+	assert.Equal(suite.T(), "5000", suite.getBalance(walletId, suite.STToken), "Balance of multi-signature wallet is changed")
+	assert.Equal(suite.T(), walletId, suite.ownerOf(ownedNftId), "Nft of multi-signature wallet is moved")
+}
+
+// createMultiSigWallet create a wallet of the owners with threshold and mint 5000 ST to it
+func (suite *ExchangeSCTestSuite) createMultiSigWallet(owners [][]byte, threshold int) string {
+	ownerIds := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		ownerIds = append(ownerIds, suite.identityId(owner))
+	}
+
+	wallet := token.CreateWallet{
+		TokenId:   suite.STToken,
+		Status:    "A",
+		Owners:    ownerIds,
+		Threshold: threshold,
+	}
+	paramByte, _ := json.Marshal(wallet)
+	walletId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateWallet"), paramByte})
+	assert.NotContains(suite.T(), walletId, "ErrorCode", "Create multi-signature wallet return error")
+
+	suite.mint(walletId, suite.STToken, "5000")
+	suite.accountingBalance()
+	return walletId
+}
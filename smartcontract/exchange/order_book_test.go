@@ -0,0 +1,114 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/doc"
+	"github.com/Akachain/gringotts/glossary/order"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/Akachain/gringotts/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *ExchangeSCTestSuite) TestOrderBook_MatchAndCancel() {
+	// wallet to sells 100 AT at 2 ST, wallet from buys 60 AT at up to 3 ST
+	sellOrderId := suite.placeOrder(suite.walletToId, order.Sell, "100", "200000000")
+	buyOrderId := suite.placeOrder(suite.walletFromId, order.Buy, "60", "300000000")
+	assert.Equal(suite.T(), "9900", suite.getBalance(suite.walletToId, suite.ATToken), "Amount of sell order is not locked")
+	assert.Equal(suite.T(), "678720", suite.getBalance(suite.walletFromId, suite.STToken), "Quote of buy order is not locked")
+
+	// the sell order is the maker, the fill is executed at its price
+	results := suite.matchOrders(buyOrderId, sellOrderId)
+	assert.EqualValues(suite.T(), transaction.Confirmed, results[0].Status, "Match is not confirmed")
+	assert.Equal(suite.T(), "60", results[0].Amount, "Fill amount is wrong")
+	assert.Equal(suite.T(), "120", results[0].QuoteAmount, "Quote amount of fill is wrong")
+
+	assert.EqualValues(suite.T(), order.Filled, suite.getOrder(buyOrderId).Status, "Buy order is not filled")
+	assert.EqualValues(suite.T(), order.PartiallyFilled, suite.getOrder(sellOrderId).Status, "Sell order is not partially filled")
+	assert.Equal(suite.T(), "60", suite.getBalance(suite.walletFromId, suite.ATToken), "Base token is not received by buyer")
+	assert.Equal(suite.T(), "678780", suite.getBalance(suite.walletFromId, suite.STToken), "Quote locked over the fill price is not unlocked")
+	assert.Equal(suite.T(), "120", suite.getBalance(suite.walletToId, suite.STToken), "Quote token is not received by seller")
+
+	// a filled order can not be matched again
+	results = suite.matchOrders(buyOrderId, sellOrderId)
+	assert.EqualValues(suite.T(), transaction.Rejected, results[0].Status, "Filled order is matched again")
+
+	// only the owner cancels the order, the remaining amount is unlocked
+	cancelRes := suite.cancelOrder(sellOrderId, suite.walletFromId)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizOrderNotPermission), "Order is canceled by another wallet")
+
+	cancelRes = suite.cancelOrder(sellOrderId, suite.walletToId)
+	assert.Empty(suite.T(), cancelRes, "Cancel order return error")
+	assert.EqualValues(suite.T(), order.Canceled, suite.getOrder(sellOrderId).Status, "Order is not canceled")
+	assert.Equal(suite.T(), "9940", suite.getBalance(suite.walletToId, suite.ATToken), "Remaining amount of order is not unlocked")
+
+	cancelRes = suite.cancelOrder(sellOrderId, suite.walletToId)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizOrderInvalidStatus), "Canceled order is canceled again")
+}
+
+// placeOrder place a limit order on the AT/ST pair and return its id
+func (suite *ExchangeSCTestSuite) placeOrder(walletId string, side order.Side, amount, price string) string {
+	orderDto := exchangeDto.PlaceLimitOrder{
+		WalletId:     walletId,
+		BaseTokenId:  suite.ATToken,
+		QuoteTokenId: suite.STToken,
+		Side:         side,
+		Amount:       amount,
+		Price:        price,
+	}
+	paramByte, _ := json.Marshal(orderDto)
+	orderId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("PlaceLimitOrder"), paramByte})
+	suite.T().Log(orderId)
+	assert.NotContains(suite.T(), orderId, "Error", "Place limit order return error")
+	return orderId
+}
+
+func (suite *ExchangeSCTestSuite) matchOrders(buyOrderId, sellOrderId string) []exchangeDto.MatchResult {
+	matchDto := exchangeDto.MatchBatchOrders{
+		Requests: []exchangeDto.MatchOrder{{ReqId: "1", BuyOrderId: buyOrderId, SellOrderId: sellOrderId}},
+	}
+	paramByte, _ := json.Marshal(matchDto)
+	matchRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("MatchOrders"), paramByte})
+	suite.T().Log(matchRes)
+
+	results := make([]exchangeDto.MatchResult, 0)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(matchRes), &results), "Parse match result failed")
+	assert.Len(suite.T(), results, 1, "Match result is missing")
+	return results
+}
+
+func (suite *ExchangeSCTestSuite) cancelOrder(orderId, walletId string) string {
+	cancelDto := exchangeDto.CancelOrder{OrderId: orderId, WalletId: walletId}
+	paramByte, _ := json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelOrder"), paramByte})
+	suite.T().Log(cancelRes)
+	return cancelRes
+}
+
+func (suite *ExchangeSCTestSuite) getOrder(orderId string) *entity.Order {
+	orderEntity := new(entity.Order)
+	suite.getDocument(doc.Order, helper.OrderKey(orderId), orderEntity)
+	return orderEntity
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	exchangeDto "github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+// poolState is the reserves of a pool returned by GetPoolState
+type poolState struct {
+	LpTokenId      string
+	ReserveA       string `json:"reserveA"`
+	ReserveB       string `json:"reserveB"`
+	TotalLiquidity string `json:"totalLiquidity"`
+	AmountOut      string `json:"amountOut"`
+}
+
+func (suite *ExchangeSCTestSuite) TestPool_AddLiquidityAndSwap() {
+	poolId := suite.createPool()
+	suite.mint(suite.walletFromId, suite.ATToken, "5000")
+
+	// the first deposit sets the price of the pool and mints sqrt(4000 * 1000) lp token
+	addTxId := suite.addLiquidity(poolId, suite.walletFromId, "4000", "1000")
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(addTxId).Status, "Add liquidity is not confirmed")
+
+	state := suite.getPoolState(poolId, "", "")
+	assert.Equal(suite.T(), "4000", state.ReserveA, "Reserve of token A is wrong")
+	assert.Equal(suite.T(), "1000", state.ReserveB, "Reserve of token B is wrong")
+	assert.Equal(suite.T(), "2000", state.TotalLiquidity, "Total liquidity is wrong")
+	assert.Equal(suite.T(), "2000", suite.getBalance(suite.walletFromId, state.LpTokenId), "Lp token is not minted to provider")
+	assert.Equal(suite.T(), "674900", suite.getBalance(suite.walletFromId, suite.STToken), "Token A is not deposited")
+	assert.Equal(suite.T(), "4000", suite.getBalance(suite.walletFromId, suite.ATToken), "Token B is not deposited")
+
+	// 100 AT in returns 4000 * 100 / (1000 + 100) ST out
+	quote := suite.getPoolState(poolId, suite.ATToken, "100")
+	assert.Equal(suite.T(), "363", quote.AmountOut, "Quote of swap is wrong")
+
+	// a swap under its minimum amount out is rejected
+	rejectedTxId := suite.swapExactIn(poolId, suite.walletToId, "100", "364")
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(rejectedTxId).Status, "Swap under minimum is not rejected")
+	assert.Equal(suite.T(), "10000", suite.getBalance(suite.walletToId, suite.ATToken), "Balance is taken by rejected swap")
+
+	swapTxId := suite.swapExactIn(poolId, suite.walletToId, "100", "363")
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(swapTxId).Status, "Swap is not confirmed")
+	assert.Equal(suite.T(), "363", suite.getBalance(suite.walletToId, suite.STToken), "Amount out is not received")
+	assert.Equal(suite.T(), "9900", suite.getBalance(suite.walletToId, suite.ATToken), "Amount in is not paid")
+
+	state = suite.getPoolState(poolId, "", "")
+	assert.Equal(suite.T(), "3637", state.ReserveA, "Reserve of token A is not updated by swap")
+	assert.Equal(suite.T(), "1100", state.ReserveB, "Reserve of token B is not updated by swap")
+}
+
+func (suite *ExchangeSCTestSuite) addLiquidity(poolId, walletId, amountA, amountB string) string {
+	addDto := exchangeDto.AddLiquidity{PoolId: poolId, WalletId: walletId, AmountA: amountA, AmountB: amountB}
+	paramByte, _ := json.Marshal(addDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AddLiquidity"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "Error", "Add liquidity return error")
+	return txId
+}
+
+// swapExactIn swap amount in of AT for ST in the pool
+func (suite *ExchangeSCTestSuite) swapExactIn(poolId, walletId, amountIn, minAmountOut string) string {
+	swapDto := exchangeDto.SwapExactIn{
+		PoolId:       poolId,
+		WalletId:     walletId,
+		FromTokenId:  suite.ATToken,
+		AmountIn:     amountIn,
+		MinAmountOut: minAmountOut,
+	}
+	paramByte, _ := json.Marshal(swapDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("SwapExactIn"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "Error", "Swap exact in return error")
+	return txId
+}
+
+func (suite *ExchangeSCTestSuite) getPoolState(poolId, fromTokenId, amountIn string) *poolState {
+	queryDto := exchangeDto.QueryPool{PoolId: poolId, FromTokenId: fromTokenId, AmountIn: amountIn}
+	paramByte, _ := json.Marshal(queryDto)
+	stateRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetPoolState"), paramByte})
+	suite.T().Log(stateRes)
+
+	state := new(poolState)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(stateRes), state), "Parse pool state failed")
+	return state
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/stretchr/testify/assert"
+	"strings"
+)
+
+func (suite *ExchangeSCTestSuite) TestReverse_PartialReversalsRejected() {
+	receiverWalletId := suite.createWallet()
+	originalId := suite.transfer(suite.walletFromId, receiverWalletId, "1000")
+	suite.accountingBalance()
+
+	// the receiver spends the transfer so its reversals can not be paid back
+	suite.transfer(receiverWalletId, suite.walletToId, "1000")
+	suite.accountingBalance()
+
+	firstId := suite.reverse(originalId, receiverWalletId, "300")
+	secondId := suite.reverse(originalId, receiverWalletId, "400")
+	assert.Equal(suite.T(), "700", suite.getTransaction(originalId).ReversedAmount, "Reversed amount is not reserved")
+
+	// both reversals are rejected in one accounting and give back their reserved amount
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(firstId).Status, "First reversal is not rejected")
+	assert.EqualValues(suite.T(), transaction.Rejected, suite.getTransaction(secondId).Status, "Second reversal is not rejected")
+	assert.Equal(suite.T(), "0", suite.getTransaction(originalId).ReversedAmount, "Reserved amount of a rejected reversal is lost")
+}
+
+func (suite *ExchangeSCTestSuite) TestReverse_PartialReversal() {
+	receiverWalletId := suite.createWallet()
+	originalId := suite.transfer(suite.walletFromId, receiverWalletId, "1000")
+	suite.accountingBalance()
+
+	firstId := suite.reverse(originalId, receiverWalletId, "300")
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(firstId).Status, "Partial reversal is not confirmed")
+	assert.Equal(suite.T(), "300", suite.getTransaction(originalId).ReversedAmount, "Reversed amount is not recorded")
+	assert.Equal(suite.T(), "700", suite.getBalance(receiverWalletId, suite.STToken), "Reversal is not taken from receiver")
+	assert.Equal(suite.T(), "678200", suite.getBalance(suite.walletFromId, suite.STToken), "Reversal is not paid back to sender")
+
+	// a reversal can not take more than the remaining amount of the transfer, nor be reversed itself
+	reverseRes := suite.reverseError(originalId, receiverWalletId, "800")
+	assert.Contains(suite.T(), reverseRes, string(errorcode.BizReverseOverAmount), "Reversal over the remaining amount is accepted")
+	reverseRes = suite.reverseError(firstId, suite.walletFromId, "100")
+	assert.Contains(suite.T(), reverseRes, string(errorcode.BizTransactionNotReversible), "Reversal is reversed")
+
+	// a reversal without amount takes the remaining amount
+	secondId := suite.reverse(originalId, receiverWalletId, "")
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(secondId).Status, "Remaining reversal is not confirmed")
+	assert.Equal(suite.T(), "700", suite.getTransaction(secondId).ToTokenAmount, "Reversal does not take the remaining amount")
+	assert.Equal(suite.T(), "1000", suite.getTransaction(originalId).ReversedAmount, "Reversed amount does not accumulate")
+	assert.Equal(suite.T(), "0", suite.getBalance(receiverWalletId, suite.STToken), "Reversal is not taken from receiver")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Reversal is not paid back to sender")
+
+	reverseRes = suite.reverseError(originalId, receiverWalletId, "1")
+	assert.Contains(suite.T(), reverseRes, string(errorcode.BizReverseOverAmount), "Fully reversed transfer is reversed again")
+}
+
+// transfer token ST between the wallets and return the id of the pending transfer
+func (suite *ExchangeSCTestSuite) transfer(fromWalletId, toWalletId, amount string) string {
+	transferDto := token.TransferToken{
+		FromWalletId: fromWalletId,
+		ToWalletId:   toWalletId,
+		TokenId:      suite.STToken,
+		Amount:       amount,
+	}
+	paramByte, _ := json.Marshal(transferDto)
+	transferRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("Transfer"), paramByte})
+	assert.Empty(suite.T(), transferRes, "Transfer return error")
+
+	lstTx := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetAccountingTx")})
+	txIds := make([]string, 0)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(lstTx), &txIds), "Parse pending transactions failed")
+	assert.Len(suite.T(), txIds, 1, "Transfer is not the only pending transaction")
+	return strings.Join(txIds, "")
+}
+
+func (suite *ExchangeSCTestSuite) reverse(originalId, walletId, amount string) string {
+	reverseDto := token.ReverseTransaction{TxId: originalId, WalletId: walletId, Amount: amount}
+	paramByte, _ := json.Marshal(reverseDto)
+	reverseId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ReverseTransaction"), paramByte})
+	suite.T().Log(reverseId)
+	assert.NotContains(suite.T(), reverseId, "Error", "Reverse transaction return error")
+	return reverseId
+}
+
+// reverseError reverse the transaction expecting an error and return the response
+func (suite *ExchangeSCTestSuite) reverseError(originalId, walletId, amount string) string {
+	reverseDto := token.ReverseTransaction{TxId: originalId, WalletId: walletId, Amount: amount}
+	paramByte, _ := json.Marshal(reverseDto)
+	reverseRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ReverseTransaction"), paramByte})
+	suite.T().Log(reverseRes)
+	return reverseRes
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package exchange
+
+import (
+	"encoding/json"
+	"github.com/Akachain/akc-go-sdk-v2/mock"
+	"github.com/Akachain/gringotts/dto/token"
+	"github.com/Akachain/gringotts/entity"
+	"github.com/Akachain/gringotts/errorcode"
+	"github.com/Akachain/gringotts/glossary/swap"
+	"github.com/Akachain/gringotts/glossary/transaction"
+	"github.com/stretchr/testify/assert"
+	"time"
+)
+
+func (suite *ExchangeSCTestSuite) TestSwap_AcceptAndSettle() {
+	swapId := suite.proposeSwap("100", "50", time.Now().Add(time.Hour).Unix())
+	assert.Equal(suite.T(), "678800", suite.getBalance(suite.walletFromId, suite.STToken), "Maker amount is not held")
+
+	txId := suite.acceptSwap(swapId)
+	assert.Equal(suite.T(), "9950", suite.getBalance(suite.walletToId, suite.ATToken), "Taker amount is not held")
+	assert.EqualValues(suite.T(), swap.Accepted, suite.getSwap(swapId).Status, "Swap is not accepted")
+
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(txId).Status, "Swap transaction is not confirmed")
+	assert.EqualValues(suite.T(), swap.Settled, suite.getSwap(swapId).Status, "Swap is not settled")
+	assert.Equal(suite.T(), "678800", suite.getBalance(suite.walletFromId, suite.STToken), "Maker amount is not paid")
+	assert.Equal(suite.T(), "50", suite.getBalance(suite.walletFromId, suite.ATToken), "Taker amount is not received by maker")
+	assert.Equal(suite.T(), "100", suite.getBalance(suite.walletToId, suite.STToken), "Maker amount is not received by taker")
+	assert.Equal(suite.T(), "9950", suite.getBalance(suite.walletToId, suite.ATToken), "Taker amount is not paid")
+}
+
+func (suite *ExchangeSCTestSuite) TestSwap_Cancel() {
+	swapId := suite.proposeSwap("100", "50", time.Now().Add(time.Hour).Unix())
+
+	// only the maker cancels an open swap before its expiry
+	cancelRes := suite.cancelSwap(swapId, suite.walletToId)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizSwapNotPermission), "Swap is canceled by the taker")
+
+	cancelRes = suite.cancelSwap(swapId, suite.walletFromId)
+	assert.Empty(suite.T(), cancelRes, "Cancel swap return error")
+	assert.EqualValues(suite.T(), swap.Canceled, suite.getSwap(swapId).Status, "Swap is not canceled")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Maker amount is not released")
+
+	acceptDto := token.AcceptSwap{SwapId: swapId, TakerWalletId: suite.walletToId}
+	paramByte, _ := json.Marshal(acceptDto)
+	acceptRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AcceptSwap"), paramByte})
+	assert.Contains(suite.T(), acceptRes, "ErrorCode", "Canceled swap is accepted")
+}
+
+func (suite *ExchangeSCTestSuite) TestSwap_Expired() {
+	swapId := suite.proposeSwap("100", "50", time.Now().Add(time.Second).Unix())
+	time.Sleep(2 * time.Second)
+
+	acceptDto := token.AcceptSwap{SwapId: swapId, TakerWalletId: suite.walletToId}
+	paramByte, _ := json.Marshal(acceptDto)
+	acceptRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AcceptSwap"), paramByte})
+	assert.Contains(suite.T(), acceptRes, string(errorcode.BizSwapExpired), "Expired swap is accepted")
+	assert.EqualValues(suite.T(), swap.Expired, suite.getSwap(swapId).Status, "Swap is not expired")
+
+	// anyone releases the held amount of an expired swap
+	cancelRes := suite.cancelSwap(swapId, suite.walletToId)
+	assert.Empty(suite.T(), cancelRes, "Cancel expired swap return error")
+	assert.EqualValues(suite.T(), swap.Expired, suite.getSwap(swapId).Status, "Released swap is not expired")
+	assert.Equal(suite.T(), "678900", suite.getBalance(suite.walletFromId, suite.STToken), "Maker amount is not released")
+}
+
+func (suite *ExchangeSCTestSuite) TestSwap_AcceptedNotCancelable() {
+	swapId := suite.proposeSwap("100", "50", time.Now().Add(time.Hour).Unix())
+	txId := suite.acceptSwap(swapId)
+
+	cancelDto := token.CancelTransaction{WalletId: suite.walletToId, TxId: txId}
+	paramByte, _ := json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelTransaction"), paramByte})
+	suite.T().Log(cancelRes)
+	assert.Contains(suite.T(), cancelRes, string(errorcode.BizTransactionNotCancelable), "Accepted swap is canceled by the taker")
+
+	suite.accountingBalance()
+	assert.EqualValues(suite.T(), transaction.Confirmed, suite.getTransaction(txId).Status, "Accepted swap is not settled")
+	assert.EqualValues(suite.T(), swap.Settled, suite.getSwap(swapId).Status, "Swap is not settled")
+}
+
+// proposeSwap propose to swap ST of wallet from against AT of wallet to
+func (suite *ExchangeSCTestSuite) proposeSwap(makerAmount, takerAmount string, expiry int64) string {
+	proposeDto := token.ProposeSwap{
+		MakerWalletId: suite.walletFromId,
+		TakerWalletId: suite.walletToId,
+		MakerTokenId:  suite.STToken,
+		TakerTokenId:  suite.ATToken,
+		MakerAmount:   makerAmount,
+		TakerAmount:   takerAmount,
+		Expiry:        expiry,
+	}
+	paramByte, _ := json.Marshal(proposeDto)
+	swapId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("ProposeSwap"), paramByte})
+	suite.T().Log(swapId)
+	assert.NotContains(suite.T(), swapId, "Error", "Propose swap return error")
+	return swapId
+}
+
+// acceptSwap accept the swap as wallet to and return the id of its pending transaction
+func (suite *ExchangeSCTestSuite) acceptSwap(swapId string) string {
+	acceptDto := token.AcceptSwap{SwapId: swapId, TakerWalletId: suite.walletToId}
+	paramByte, _ := json.Marshal(acceptDto)
+	txId := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("AcceptSwap"), paramByte})
+	suite.T().Log(txId)
+	assert.NotContains(suite.T(), txId, "Error", "Accept swap return error")
+	return txId
+}
+
+func (suite *ExchangeSCTestSuite) cancelSwap(swapId, walletId string) string {
+	cancelDto := token.CancelSwap{SwapId: swapId, WalletId: walletId}
+	paramByte, _ := json.Marshal(cancelDto)
+	cancelRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CancelSwap"), paramByte})
+	suite.T().Log(cancelRes)
+	return cancelRes
+}
+
+func (suite *ExchangeSCTestSuite) getSwap(swapId string) *entity.Swap {
+	queryDto := token.QuerySwap{SwapId: swapId}
+	paramByte, _ := json.Marshal(queryDto)
+	swapRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("GetSwap"), paramByte})
+	suite.T().Log(swapRes)
+
+	swapEntity := new(entity.Swap)
+	assert.Nil(suite.T(), json.Unmarshal([]byte(swapRes), swapEntity), "Parse swap failed")
+	return swapEntity
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package smartcontract
+
+import (
+	"github.com/Akachain/gringotts/dto/proposal"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type Governance interface {
+	// SetApprovalPolicy to set approvers and threshold of a privileged operation of a token. Only admin is allowed
+	SetApprovalPolicy(ctx contractapi.TransactionContextInterface, policy proposal.ApprovalPolicy) error
+
+	// GetApprovalPolicy return the approval policy that applies to an operation of a token
+	GetApprovalPolicy(ctx contractapi.TransactionContextInterface, query proposal.QueryApprovalPolicy) (string, error)
+
+	// CreateProposal to propose a privileged operation with its serialized dto. It returns id of the proposal
+	CreateProposal(ctx contractapi.TransactionContextInterface, createProposal proposal.CreateProposal) (string, error)
+
+	// ApproveProposal to approve a proposal, the operation is executed once the threshold is reached
+	ApproveProposal(ctx contractapi.TransactionContextInterface, vote proposal.VoteProposal) (string, error)
+
+	// RejectProposal to reject a proposal
+	RejectProposal(ctx contractapi.TransactionContextInterface, vote proposal.VoteProposal) error
+
+	// GetProposal return the proposal and its status
+	GetProposal(ctx contractapi.TransactionContextInterface, query proposal.VoteProposal) (string, error)
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package governance
+
+import (
+	"github.com/Akachain/gringotts/dto/proposal"
+	"github.com/Akachain/gringotts/handler"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/smartcontract"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type governance struct {
+	proposalHandler    *handler.ProposalHandler
+	idempotencyHandler *handler.IdempotencyHandler
+}
+
+func NewGovernance() smartcontract.Governance {
+	return &governance{
+		proposalHandler:    handler.NewProposalHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
+	}
+}
+
+func (g *governance) SetApprovalPolicy(ctx contractapi.TransactionContextInterface, policy proposal.ApprovalPolicy) error {
+	glogger.GetInstance().Info(ctx, "------------SetApprovalPolicy Governance SmartContract------------")
+	return g.idempotencyHandler.ExecuteNoResult(ctx, "SetApprovalPolicy", policy, func() error {
+		// a policy that requires approval is changed through a proposal approved under the same policy
+		if err := g.proposalHandler.CheckApproval(ctx, policy.Operation, policy.TokenId); err != nil {
+			return err
+		}
+		return g.proposalHandler.SetApprovalPolicy(ctx, policy)
+	})
+}
+
+func (g *governance) GetApprovalPolicy(ctx contractapi.TransactionContextInterface, query proposal.QueryApprovalPolicy) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetApprovalPolicy Governance SmartContract------------")
+	return g.proposalHandler.GetApprovalPolicy(ctx, query)
+}
+
+func (g *governance) CreateProposal(ctx contractapi.TransactionContextInterface, createProposal proposal.CreateProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------CreateProposal Governance SmartContract------------")
+	return g.idempotencyHandler.Execute(ctx, "CreateProposal", createProposal, func() (string, error) {
+		return g.proposalHandler.CreateProposal(ctx, createProposal)
+	})
+}
+
+func (g *governance) ApproveProposal(ctx contractapi.TransactionContextInterface, vote proposal.VoteProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------ApproveProposal Governance SmartContract------------")
+	return g.idempotencyHandler.Execute(ctx, "ApproveProposal", vote, func() (string, error) {
+		return g.proposalHandler.ApproveProposal(ctx, vote)
+	})
+}
+
+func (g *governance) RejectProposal(ctx contractapi.TransactionContextInterface, vote proposal.VoteProposal) error {
+	glogger.GetInstance().Info(ctx, "------------RejectProposal Governance SmartContract------------")
+	return g.idempotencyHandler.ExecuteNoResult(ctx, "RejectProposal", vote, func() error {
+		return g.proposalHandler.RejectProposal(ctx, vote)
+	})
+}
+
+func (g *governance) GetProposal(ctx contractapi.TransactionContextInterface, query proposal.VoteProposal) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetProposal Governance SmartContract------------")
+	return g.proposalHandler.GetProposal(ctx, query)
+}
@@ -31,15 +31,32 @@ type Iao interface {
 	// CreateIao to create new iao for asset. It will return address of Iao to investor buy asset token
 	CreateIao(ctx contractapi.TransactionContextInterface, assetIao iao.AssetIao) (string, error)
 
-	// UpdateStatusIao to update status of IAO
+	// UpdateStatusIao to update status of IAO. It only moves New -> Open -> Distributing -> Done or Open -> Canceling -> Canceled
 	UpdateStatusIao(ctx contractapi.TransactionContextInterface, updateIao iao.UpdateIao) error
 
-	// BuyAssetToken investor call to buy asset token
+	// BuyAssetToken investor call to buy asset token, or to commit to buy it when the iao allocates commitments
 	BuyAssetToken(ctx contractapi.TransactionContextInterface, asset iao.BuyBatchAsset) (string, error)
 
 	// FinalizeIao to finish iao and distribute AT to investor
 	FinalizeIao(ctx contractapi.TransactionContextInterface, finishIao iao.FinishIao) error
 
+	// AllocateIao to fill the commitments of a closed iao with pro-rata or tiered allocation and return the excess stable token.
+	// It returns the transaction id keying the investor book of the fills with the iao id
+	AllocateIao(ctx contractapi.TransactionContextInterface, allocateIao iao.AllocateIao) (string, error)
+
+	// GetIaoStatus return the progress of the sale of an iao toward its soft and hard cap
+	GetIaoStatus(ctx contractapi.TransactionContextInterface, queryIao iao.QueryIao) (string, error)
+
+	// AddEligibility to add or replace wallets with their KYC tier, jurisdiction and expiry in the eligibility list of an iao.
+	// Only admin is allowed
+	AddEligibility(ctx contractapi.TransactionContextInterface, addEligibility iao.AddEligibility) error
+
+	// RemoveEligibility to remove wallets from the eligibility list of an iao. Only admin is allowed
+	RemoveEligibility(ctx contractapi.TransactionContextInterface, removeEligibility iao.RemoveEligibility) error
+
+	// GetEligibility return the eligibility of a wallet in an iao, or a page of the eligibility list of the iao after lastWalletId
+	GetEligibility(ctx contractapi.TransactionContextInterface, queryEligibility iao.QueryEligibility) (string, error)
+
 	// CancelIao to cancel iao and return ST to investor
 	CancelIao(ctx contractapi.TransactionContextInterface, finishIao iao.FinishIao) error
 }
@@ -21,25 +21,36 @@ package iao
 
 import (
 	"github.com/Akachain/gringotts/dto/iao"
+	"github.com/Akachain/gringotts/glossary/proposal"
 	"github.com/Akachain/gringotts/handler"
 	"github.com/Akachain/gringotts/smartcontract"
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
 
 type iaoSc struct {
-	iaoHandler handler.IaoHandler
+	iaoHandler         handler.IaoHandler
+	proposalHandler    *handler.ProposalHandler
+	idempotencyHandler *handler.IdempotencyHandler
 }
 
 func NewIaoSc() smartcontract.Iao {
-	return &iaoSc{iaoHandler: handler.NewIaoHandler()}
+	return &iaoSc{
+		iaoHandler:         handler.NewIaoHandler(),
+		proposalHandler:    handler.NewProposalHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
+	}
 }
 
 func (i *iaoSc) CreateAsset(ctx contractapi.TransactionContextInterface, asset iao.CreateAsset) (string, error) {
-	return i.iaoHandler.CreateAsset(ctx, asset)
+	return i.idempotencyHandler.Execute(ctx, "CreateAsset", asset, func() (string, error) {
+		return i.iaoHandler.CreateAsset(ctx, asset)
+	})
 }
 
 func (i *iaoSc) CreateIao(ctx contractapi.TransactionContextInterface, assetIao iao.AssetIao) (string, error) {
-	return i.iaoHandler.CreateIao(ctx, assetIao)
+	return i.idempotencyHandler.Execute(ctx, "CreateIao", assetIao, func() (string, error) {
+		return i.iaoHandler.CreateIao(ctx, assetIao)
+	})
 }
 
 func (i *iaoSc) BuyAssetToken(ctx contractapi.TransactionContextInterface, batchAsset iao.BuyBatchAsset) (string, error) {
@@ -47,13 +58,57 @@ func (i *iaoSc) BuyAssetToken(ctx contractapi.TransactionContextInterface, batch
 }
 
 func (i *iaoSc) UpdateStatusIao(ctx contractapi.TransactionContextInterface, updateIao iao.UpdateIao) error {
-	return i.iaoHandler.UpdateStatusIao(ctx, updateIao)
+	return i.idempotencyHandler.ExecuteNoResult(ctx, "UpdateStatusIao", updateIao, func() error {
+		if err := i.proposalHandler.CheckApproval(ctx, proposal.UpdateStatusIao, ""); err != nil {
+			return err
+		}
+		return i.iaoHandler.UpdateStatusIao(ctx, updateIao)
+	})
 }
 
 func (i *iaoSc) FinalizeIao(ctx contractapi.TransactionContextInterface, finishIao iao.FinishIao) error {
-	return i.iaoHandler.FinalizeIao(ctx, finishIao)
+	return i.idempotencyHandler.ExecuteNoResult(ctx, "FinalizeIao", finishIao, func() error {
+		if err := i.proposalHandler.CheckApproval(ctx, proposal.FinalizeIao, ""); err != nil {
+			return err
+		}
+		return i.iaoHandler.FinalizeIao(ctx, finishIao)
+	})
+}
+
+func (i *iaoSc) AllocateIao(ctx contractapi.TransactionContextInterface, allocateIao iao.AllocateIao) (string, error) {
+	return i.idempotencyHandler.Execute(ctx, "AllocateIao", allocateIao, func() (string, error) {
+		if err := i.proposalHandler.CheckApproval(ctx, proposal.AllocateIao, ""); err != nil {
+			return "", err
+		}
+		return i.iaoHandler.AllocateIao(ctx, allocateIao)
+	})
+}
+
+func (i *iaoSc) GetIaoStatus(ctx contractapi.TransactionContextInterface, queryIao iao.QueryIao) (string, error) {
+	return i.iaoHandler.GetIaoStatus(ctx, queryIao)
+}
+
+func (i *iaoSc) AddEligibility(ctx contractapi.TransactionContextInterface, addEligibility iao.AddEligibility) error {
+	return i.idempotencyHandler.ExecuteNoResult(ctx, "AddEligibility", addEligibility, func() error {
+		return i.iaoHandler.AddEligibility(ctx, addEligibility)
+	})
+}
+
+func (i *iaoSc) RemoveEligibility(ctx contractapi.TransactionContextInterface, removeEligibility iao.RemoveEligibility) error {
+	return i.idempotencyHandler.ExecuteNoResult(ctx, "RemoveEligibility", removeEligibility, func() error {
+		return i.iaoHandler.RemoveEligibility(ctx, removeEligibility)
+	})
+}
+
+func (i *iaoSc) GetEligibility(ctx contractapi.TransactionContextInterface, queryEligibility iao.QueryEligibility) (string, error) {
+	return i.iaoHandler.GetEligibility(ctx, queryEligibility)
 }
 
 func (i *iaoSc) CancelIao(ctx contractapi.TransactionContextInterface, finishIao iao.FinishIao) error {
-	return i.iaoHandler.CancelIao(ctx, finishIao)
+	return i.idempotencyHandler.ExecuteNoResult(ctx, "CancelIao", finishIao, func() error {
+		if err := i.proposalHandler.CheckApproval(ctx, proposal.CancelIao, ""); err != nil {
+			return err
+		}
+		return i.iaoHandler.CancelIao(ctx, finishIao)
+	})
 }
@@ -237,8 +237,9 @@ func (suite *IaoSCTestSuite) createIao() {
 		AssetId:          suite.AssetId,
 		AssetTokenAmount: "8900",
 		StartDate:        fmt.Sprint(time.Now().Unix()),
-		EndDate:          fmt.Sprint(time.Now().Unix()),
+		EndDate:          fmt.Sprint(time.Now().Add(24 * time.Hour).Unix()),
 		Rate:             1,
+		StableTokenId:    suite.STToken,
 	}
 	paramByte, _ = json.Marshal(assetIaoDto)
 	iaoRes := mock.MockInvokeTransaction(suite.T(), suite.stub, [][]byte{[]byte("CreateIao"), paramByte})
@@ -0,0 +1,51 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package smartcontract
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type Marketplace interface {
+	// ListNft to list an NFT for sale at a price, the NFT is held in escrow until the listing is canceled or filled
+	ListNft(ctx contractapi.TransactionContextInterface, listNft exchange.ListNft) (string, error)
+
+	// CancelListing to cancel an open listing and release the NFT
+	CancelListing(ctx contractapi.TransactionContextInterface, cancelListing exchange.CancelListing) error
+
+	// BuyListing to buy a listed NFT. It returns the id of the settlement transaction
+	BuyListing(ctx contractapi.TransactionContextInterface, buyListing exchange.BuyListing) (string, error)
+
+	// MakeOffer to make an offer on an NFT, the price is held from the balance of buyer
+	MakeOffer(ctx contractapi.TransactionContextInterface, makeOffer exchange.MakeOffer) (string, error)
+
+	// CancelOffer to cancel an open offer and release the held price
+	CancelOffer(ctx contractapi.TransactionContextInterface, cancelOffer exchange.CancelOffer) error
+
+	// AcceptOffer the owner of an NFT accepts an offer. It returns the id of the settlement transaction
+	AcceptOffer(ctx contractapi.TransactionContextInterface, acceptOffer exchange.AcceptOffer) (string, error)
+
+	// GetListings to get the listings of an NFT or a seller
+	GetListings(ctx contractapi.TransactionContextInterface, queryListing exchange.QueryListing) (string, error)
+
+	// GetOffers to get the offers of an NFT
+	GetOffers(ctx contractapi.TransactionContextInterface, queryOffer exchange.QueryOffer) (string, error)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package marketplace
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/handler"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/smartcontract"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type marketplace struct {
+	exchangeHandler    handler.ExchangeHandler
+	idempotencyHandler *handler.IdempotencyHandler
+}
+
+func NewMarketplace() smartcontract.Marketplace {
+	return &marketplace{
+		exchangeHandler:    handler.NewExchangeHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
+	}
+}
+
+func (m *marketplace) ListNft(ctx contractapi.TransactionContextInterface, listNft exchange.ListNft) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------ListNft Marketplace SmartContract------------")
+	return m.idempotencyHandler.Execute(ctx, "ListNft", listNft, func() (string, error) {
+		return m.exchangeHandler.ListNft(ctx, listNft)
+	})
+}
+
+func (m *marketplace) CancelListing(ctx contractapi.TransactionContextInterface, cancelListing exchange.CancelListing) error {
+	glogger.GetInstance().Info(ctx, "------------CancelListing Marketplace SmartContract------------")
+	return m.idempotencyHandler.ExecuteNoResult(ctx, "CancelListing", cancelListing, func() error {
+		return m.exchangeHandler.CancelListing(ctx, cancelListing)
+	})
+}
+
+func (m *marketplace) BuyListing(ctx contractapi.TransactionContextInterface, buyListing exchange.BuyListing) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------BuyListing Marketplace SmartContract------------")
+	return m.idempotencyHandler.Execute(ctx, "BuyListing", buyListing, func() (string, error) {
+		return m.exchangeHandler.BuyListing(ctx, buyListing)
+	})
+}
+
+func (m *marketplace) MakeOffer(ctx contractapi.TransactionContextInterface, makeOffer exchange.MakeOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------MakeOffer Marketplace SmartContract------------")
+	return m.idempotencyHandler.Execute(ctx, "MakeOffer", makeOffer, func() (string, error) {
+		return m.exchangeHandler.MakeOffer(ctx, makeOffer)
+	})
+}
+
+func (m *marketplace) CancelOffer(ctx contractapi.TransactionContextInterface, cancelOffer exchange.CancelOffer) error {
+	glogger.GetInstance().Info(ctx, "------------CancelOffer Marketplace SmartContract------------")
+	return m.idempotencyHandler.ExecuteNoResult(ctx, "CancelOffer", cancelOffer, func() error {
+		return m.exchangeHandler.CancelOffer(ctx, cancelOffer)
+	})
+}
+
+func (m *marketplace) AcceptOffer(ctx contractapi.TransactionContextInterface, acceptOffer exchange.AcceptOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------AcceptOffer Marketplace SmartContract------------")
+	return m.idempotencyHandler.Execute(ctx, "AcceptOffer", acceptOffer, func() (string, error) {
+		return m.exchangeHandler.AcceptOffer(ctx, acceptOffer)
+	})
+}
+
+func (m *marketplace) GetListings(ctx contractapi.TransactionContextInterface, queryListing exchange.QueryListing) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetListings Marketplace SmartContract------------")
+	return m.exchangeHandler.GetListings(ctx, queryListing)
+}
+
+func (m *marketplace) GetOffers(ctx contractapi.TransactionContextInterface, queryOffer exchange.QueryOffer) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetOffers Marketplace SmartContract------------")
+	return m.exchangeHandler.GetOffers(ctx, queryOffer)
+}
@@ -28,18 +28,22 @@ import (
 )
 
 type nft struct {
-	nftHandler handler.NftHandler
+	nftHandler         handler.NftHandler
+	idempotencyHandler *handler.IdempotencyHandler
 }
 
 func NewNFT() smartcontract.Erc721 {
 	return &nft{
-		nftHandler: handler.NewNftHandler(),
+		nftHandler:         handler.NewNftHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
 	}
 }
 
 func (n *nft) MintNft(ctx contractapi.TransactionContextInterface, mintNFT nft2.MintNFT) (string, error) {
 	glogger.GetInstance().Info(ctx, "------------Mint NFT SmartContract------------")
-	return n.nftHandler.Mint(ctx, mintNFT)
+	return n.idempotencyHandler.Execute(ctx, "MintNft", mintNFT, func() (string, error) {
+		return n.nftHandler.Mint(ctx, mintNFT)
+	})
 }
 
 func (n *nft) OwnerOf(ctx contractapi.TransactionContextInterface, ownerNFT nft2.OwnerNFT) (string, error) {
@@ -54,5 +58,26 @@ func (n *nft) BalanceOf(ctx contractapi.TransactionContextInterface, balanceOfNF
 
 func (n *nft) TransferFrom(ctx contractapi.TransactionContextInterface, transferNFT nft2.TransferNFT) error {
 	glogger.GetInstance().Info(ctx, "------------TransferFrom NFT SmartContract------------")
-	return n.nftHandler.TransferNFT(ctx, transferNFT)
+	return n.idempotencyHandler.ExecuteNoResult(ctx, "TransferFrom", transferNFT, func() error {
+		return n.nftHandler.TransferNFT(ctx, transferNFT)
+	})
+}
+
+func (n *nft) RoyaltyInfo(ctx contractapi.TransactionContextInterface, royaltyInfo nft2.RoyaltyInfoNFT) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------RoyaltyInfo NFT SmartContract------------")
+	return n.nftHandler.RoyaltyInfo(ctx, royaltyInfo)
+}
+
+func (n *nft) FractionalizeNft(ctx contractapi.TransactionContextInterface, fractionalizeNFT nft2.FractionalizeNFT) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------FractionalizeNft NFT SmartContract------------")
+	return n.idempotencyHandler.Execute(ctx, "FractionalizeNft", fractionalizeNFT, func() (string, error) {
+		return n.nftHandler.FractionalizeNft(ctx, fractionalizeNFT)
+	})
+}
+
+func (n *nft) RedeemNft(ctx contractapi.TransactionContextInterface, redeemNFT nft2.RedeemNFT) error {
+	glogger.GetInstance().Info(ctx, "------------RedeemNft NFT SmartContract------------")
+	return n.idempotencyHandler.ExecuteNoResult(ctx, "RedeemNft", redeemNFT, func() error {
+		return n.nftHandler.RedeemNft(ctx, redeemNFT)
+	})
 }
@@ -0,0 +1,42 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package smartcontract
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type OrderBook interface {
+	// PlaceLimitOrder to place an order to buy or sell a token pair at a limit price, the balance is locked until the order is filled or canceled
+	PlaceLimitOrder(ctx contractapi.TransactionContextInterface, placeOrder exchange.PlaceLimitOrder) (string, error)
+
+	// CancelOrder to cancel an open order and unlock the remaining balance
+	CancelOrder(ctx contractapi.TransactionContextInterface, cancelOrder exchange.CancelOrder) error
+
+	// MatchOrders to match multiple pairs of buy and sell orders. The operator will call this
+	MatchOrders(ctx contractapi.TransactionContextInterface, batchOrders exchange.MatchBatchOrders) (string, error)
+
+	// GetDepth return the open orders of a token pair group by price
+	GetDepth(ctx contractapi.TransactionContextInterface, queryDepth exchange.QueryDepth) (string, error)
+
+	// GetOrderHistory return the orders and trades of a wallet
+	GetOrderHistory(ctx contractapi.TransactionContextInterface, queryHistory exchange.QueryOrderHistory) (string, error)
+}
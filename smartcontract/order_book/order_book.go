@@ -0,0 +1,69 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package order_book
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/handler"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/smartcontract"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type orderBook struct {
+	orderBookHandler   handler.OrderBookHandler
+	idempotencyHandler *handler.IdempotencyHandler
+}
+
+func NewOrderBook() smartcontract.OrderBook {
+	return &orderBook{
+		orderBookHandler:   handler.NewOrderBookHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
+	}
+}
+
+func (o *orderBook) PlaceLimitOrder(ctx contractapi.TransactionContextInterface, placeOrder exchange.PlaceLimitOrder) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------PlaceLimitOrder OrderBook SmartContract------------")
+	return o.idempotencyHandler.Execute(ctx, "PlaceLimitOrder", placeOrder, func() (string, error) {
+		return o.orderBookHandler.PlaceLimitOrder(ctx, placeOrder)
+	})
+}
+
+func (o *orderBook) CancelOrder(ctx contractapi.TransactionContextInterface, cancelOrder exchange.CancelOrder) error {
+	glogger.GetInstance().Info(ctx, "------------CancelOrder OrderBook SmartContract------------")
+	return o.idempotencyHandler.ExecuteNoResult(ctx, "CancelOrder", cancelOrder, func() error {
+		return o.orderBookHandler.CancelOrder(ctx, cancelOrder)
+	})
+}
+
+func (o *orderBook) MatchOrders(ctx contractapi.TransactionContextInterface, batchOrders exchange.MatchBatchOrders) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------MatchOrders OrderBook SmartContract------------")
+	return o.orderBookHandler.MatchOrders(ctx, batchOrders)
+}
+
+func (o *orderBook) GetDepth(ctx contractapi.TransactionContextInterface, queryDepth exchange.QueryDepth) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetDepth OrderBook SmartContract------------")
+	return o.orderBookHandler.GetDepth(ctx, queryDepth)
+}
+
+func (o *orderBook) GetOrderHistory(ctx contractapi.TransactionContextInterface, queryHistory exchange.QueryOrderHistory) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetOrderHistory OrderBook SmartContract------------")
+	return o.orderBookHandler.GetOrderHistory(ctx, queryHistory)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package smartcontract
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type LiquidityPool interface {
+	// CreatePool to create the constant-product liquidity pool of a token pair
+	CreatePool(ctx contractapi.TransactionContextInterface, createPool exchange.CreatePool) (string, error)
+
+	// AddLiquidity to deposit a token pair into a pool for lp token. It returns id of the transaction
+	AddLiquidity(ctx contractapi.TransactionContextInterface, addLiquidity exchange.AddLiquidity) (string, error)
+
+	// RemoveLiquidity to burn lp token for the share of the reserves of a pool. It returns id of the transaction
+	RemoveLiquidity(ctx contractapi.TransactionContextInterface, removeLiquidity exchange.RemoveLiquidity) (string, error)
+
+	// SwapExactIn to swap an exact amount of a token with a minimum amount out. It returns id of the transaction
+	SwapExactIn(ctx contractapi.TransactionContextInterface, swapExactIn exchange.SwapExactIn) (string, error)
+
+	// GetPoolState return the reserves of a pool and the quote of a swap
+	GetPoolState(ctx contractapi.TransactionContextInterface, queryPool exchange.QueryPool) (string, error)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2021 akachain
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of
+// this software and associated documentation files (the "Software"), to deal in
+// the Software without restriction, including without limitation the rights to
+// use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of
+// the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS
+// FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR
+// COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER
+// IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package pool
+
+import (
+	"github.com/Akachain/gringotts/dto/exchange"
+	"github.com/Akachain/gringotts/handler"
+	"github.com/Akachain/gringotts/helper/glogger"
+	"github.com/Akachain/gringotts/smartcontract"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+type liquidityPool struct {
+	poolHandler        handler.PoolHandler
+	idempotencyHandler *handler.IdempotencyHandler
+}
+
+func NewLiquidityPool() smartcontract.LiquidityPool {
+	return &liquidityPool{
+		poolHandler:        handler.NewPoolHandler(),
+		idempotencyHandler: handler.NewIdempotencyHandler(),
+	}
+}
+
+func (l *liquidityPool) CreatePool(ctx contractapi.TransactionContextInterface, createPool exchange.CreatePool) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------CreatePool LiquidityPool SmartContract------------")
+	return l.idempotencyHandler.Execute(ctx, "CreatePool", createPool, func() (string, error) {
+		return l.poolHandler.CreatePool(ctx, createPool)
+	})
+}
+
+func (l *liquidityPool) AddLiquidity(ctx contractapi.TransactionContextInterface, addLiquidity exchange.AddLiquidity) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------AddLiquidity LiquidityPool SmartContract------------")
+	return l.idempotencyHandler.Execute(ctx, "AddLiquidity", addLiquidity, func() (string, error) {
+		return l.poolHandler.AddLiquidity(ctx, addLiquidity)
+	})
+}
+
+func (l *liquidityPool) RemoveLiquidity(ctx contractapi.TransactionContextInterface, removeLiquidity exchange.RemoveLiquidity) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------RemoveLiquidity LiquidityPool SmartContract------------")
+	return l.idempotencyHandler.Execute(ctx, "RemoveLiquidity", removeLiquidity, func() (string, error) {
+		return l.poolHandler.RemoveLiquidity(ctx, removeLiquidity)
+	})
+}
+
+func (l *liquidityPool) SwapExactIn(ctx contractapi.TransactionContextInterface, swapExactIn exchange.SwapExactIn) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------SwapExactIn LiquidityPool SmartContract------------")
+	return l.idempotencyHandler.Execute(ctx, "SwapExactIn", swapExactIn, func() (string, error) {
+		return l.poolHandler.SwapExactIn(ctx, swapExactIn)
+	})
+}
+
+func (l *liquidityPool) GetPoolState(ctx contractapi.TransactionContextInterface, queryPool exchange.QueryPool) (string, error) {
+	glogger.GetInstance().Info(ctx, "------------GetPoolState LiquidityPool SmartContract------------")
+	return l.poolHandler.GetPoolState(ctx, queryPool)
+}